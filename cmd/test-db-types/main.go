@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"github.com/yhonda-ohishi-pub-dev/desktop-server/server"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// typeCase is a single value expression and the typed cell it must produce.
+// kind is not checked when it is CELL_KIND_UNSPECIFIED.
+type typeCase struct {
+	name string
+	expr string
+	kind pb.CellKind
+	want *pb.Cell
+}
+
+func null() *pb.Cell            { return &pb.Cell{Value: &pb.Cell_NullValue{NullValue: true}} }
+func str(s string) *pb.Cell     { return &pb.Cell{Value: &pb.Cell_StringValue{StringValue: s}} }
+func integer(n int64) *pb.Cell  { return &pb.Cell{Value: &pb.Cell_IntValue{IntValue: n}} }
+func decimal(s string) *pb.Cell { return &pb.Cell{Value: &pb.Cell_DecimalValue{DecimalValue: s}} }
+func float(f float64) *pb.Cell  { return &pb.Cell{Value: &pb.Cell_FloatValue{FloatValue: f}} }
+func boolean(b bool) *pb.Cell   { return &pb.Cell{Value: &pb.Cell_BoolValue{BoolValue: b}} }
+func blob(b ...byte) *pb.Cell   { return &pb.Cell{Value: &pb.Cell_BytesValue{BytesValue: b}} }
+func timestamp(t time.Time, zoned bool) *pb.Cell {
+	_, offset := t.Zone()
+	if !zoned {
+		offset = 0
+	}
+	return &pb.Cell{Value: &pb.Cell_TimestampValue{TimestampValue: &pb.ZonedTimestamp{
+		Time:             timestamppb.New(t),
+		UtcOffsetSeconds: int32(offset),
+		HasZone:          zoned,
+	}}}
+}
+
+var sqlServerCases = []typeCase{
+	{"null", "CAST(NULL AS NVARCHAR(10))", pb.CellKind_CELL_KIND_STRING, null()},
+	{"empty string", "N''", pb.CellKind_CELL_KIND_STRING, str("")},
+	{"nvarchar", "N'日本語テキスト'", pb.CellKind_CELL_KIND_STRING, str("日本語テキスト")},
+	{"int", "CAST(-42 AS INT)", pb.CellKind_CELL_KIND_INT, integer(-42)},
+	{"bigint", "CAST(9223372036854775807 AS BIGINT)", pb.CellKind_CELL_KIND_INT, integer(9223372036854775807)},
+	{"decimal", "CAST(1.50 AS DECIMAL(10,2))", pb.CellKind_CELL_KIND_DECIMAL, decimal("1.50")},
+	{"decimal 38", "CAST('12345678901234567890.123456789012345678' AS DECIMAL(38,18))",
+		pb.CellKind_CELL_KIND_DECIMAL, decimal("12345678901234567890.123456789012345678")},
+	{"money", "CAST(12.3456 AS MONEY)", pb.CellKind_CELL_KIND_DECIMAL, decimal("12.3456")},
+	{"float", "CAST(1.5 AS FLOAT)", pb.CellKind_CELL_KIND_FLOAT, float(1.5)},
+	{"bit", "CAST(1 AS BIT)", pb.CellKind_CELL_KIND_BOOL, boolean(true)},
+	{"varbinary", "CAST(0x0102FF AS VARBINARY(10))", pb.CellKind_CELL_KIND_BYTES, blob(0x01, 0x02, 0xff)},
+	{"uniqueidentifier", "CAST('6F9619FF-8B86-D011-B42D-00C04FC964FF' AS UNIQUEIDENTIFIER)",
+		pb.CellKind_CELL_KIND_STRING, str("6F9619FF-8B86-D011-B42D-00C04FC964FF")},
+	{"datetime2", "CAST('2024-01-02 03:04:05.1234567' AS DATETIME2(7))", pb.CellKind_CELL_KIND_TIMESTAMP,
+		timestamp(time.Date(2024, 1, 2, 3, 4, 5, 123456700, time.UTC), false)},
+	{"datetimeoffset", "CAST('2024-01-02 03:04:05.1234567 +09:00' AS DATETIMEOFFSET(7))", pb.CellKind_CELL_KIND_TIMESTAMP,
+		timestamp(time.Date(2024, 1, 2, 3, 4, 5, 123456700, time.FixedZone("", 9*60*60)), true)},
+	{"date", "CAST('2024-01-02' AS DATE)", pb.CellKind_CELL_KIND_DATE,
+		timestamp(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), false)},
+	{"time", "CAST('12:34:56.1234567' AS TIME(7))", pb.CellKind_CELL_KIND_TIME, str("12:34:56.1234567")},
+}
+
+var mySQLCases = []typeCase{
+	{"null", "CAST(NULL AS CHAR)", pb.CellKind_CELL_KIND_UNSPECIFIED, null()},
+	{"empty string", "''", pb.CellKind_CELL_KIND_STRING, str("")},
+	{"varchar", "'日本語テキスト'", pb.CellKind_CELL_KIND_STRING, str("日本語テキスト")},
+	{"int", "-42", pb.CellKind_CELL_KIND_INT, integer(-42)},
+	{"bigint", "CAST(9223372036854775807 AS SIGNED)", pb.CellKind_CELL_KIND_INT, integer(9223372036854775807)},
+	{"unsigned bigint", "CAST(18446744073709551615 AS UNSIGNED)", pb.CellKind_CELL_KIND_INT, decimal("18446744073709551615")},
+	{"decimal", "CAST(1.50 AS DECIMAL(10,2))", pb.CellKind_CELL_KIND_DECIMAL, decimal("1.50")},
+	{"decimal 38", "CAST('12345678901234567890.123456789012345678' AS DECIMAL(38,18))",
+		pb.CellKind_CELL_KIND_DECIMAL, decimal("12345678901234567890.123456789012345678")},
+	{"double", "1.5e0", pb.CellKind_CELL_KIND_FLOAT, float(1.5)},
+	{"varbinary", "CAST(x'0102FF' AS BINARY)", pb.CellKind_CELL_KIND_BYTES, blob(0x01, 0x02, 0xff)},
+	{"json", `CAST('{"a":1}' AS JSON)`, pb.CellKind_CELL_KIND_STRING, str(`{"a": 1}`)},
+	{"datetime", "CAST('2024-01-02 03:04:05.123456' AS DATETIME(6))", pb.CellKind_CELL_KIND_TIMESTAMP,
+		timestamp(time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC), false)},
+	{"date", "CAST('2024-01-02' AS DATE)", pb.CellKind_CELL_KIND_DATE,
+		timestamp(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), false)},
+	{"time", "CAST('12:34:56' AS TIME)", pb.CellKind_CELL_KIND_TIME, str("12:34:56")},
+}
+
+// cellText renders a cell on one line
+func cellText(c *pb.Cell) string {
+	return protojson.MarshalOptions{}.Format(c)
+}
+
+func main() {
+	profile := flag.String("profile", "", "Database profile to test (default: DB_* environment variables)")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found, using environment variables")
+	}
+
+	profiles, err := server.NewDatabaseProfiles("")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer profiles.Close()
+
+	ctx := context.Background()
+	conn, err := profiles.Conn(ctx, *profile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	database := server.NewDatabaseService(profiles)
+
+	cases := sqlServerCases
+	// MySQL returns text for plain queries and binary values for prepared
+	// statements, so every case runs both ways
+	params := [][]*pb.SqlValue{nil}
+	where := ""
+	if conn.Driver == "mysql" {
+		cases = mySQLCases
+		params = append(params, []*pb.SqlValue{{Kind: &pb.SqlValue_IntValue{IntValue: 1}}})
+		where = " FROM DUAL WHERE 1 = ?"
+	}
+
+	fmt.Printf("Testing typed results on %s\n\n", conn.Driver)
+	failed := 0
+	for _, c := range cases {
+		for _, p := range params {
+			name := c.name
+			query := "SELECT " + c.expr + " AS v"
+			if p != nil {
+				name += " (prepared)"
+				query += where
+			}
+
+			resp, err := database.QueryDatabase(ctx, &pb.QueryRequest{Sql: query, Params: p, Profile: *profile, Typed: true})
+			if err != nil {
+				fmt.Printf("FAIL %-28s %v\n", name, err)
+				failed++
+				continue
+			}
+			if len(resp.Header) != 1 || len(resp.TypedRows) != 1 || len(resp.TypedRows[0].Cells) != 1 {
+				fmt.Printf("FAIL %-28s unexpected result shape: %d columns, %d rows\n", name, len(resp.Header), len(resp.TypedRows))
+				failed++
+				continue
+			}
+
+			header := resp.Header[0]
+			got := resp.TypedRows[0].Cells[0]
+			if c.kind != pb.CellKind_CELL_KIND_UNSPECIFIED && header.Kind != c.kind {
+				fmt.Printf("FAIL %-28s %s: kind %v, want %v\n", name, header.DatabaseType, header.Kind, c.kind)
+				failed++
+				continue
+			}
+			if !proto.Equal(got, c.want) {
+				fmt.Printf("FAIL %-28s %s: got %s, want %s\n", name, header.DatabaseType,
+					cellText(got), cellText(c.want))
+				failed++
+				continue
+			}
+			fmt.Printf("OK   %-28s %-16s %s\n", name, header.DatabaseType, cellText(got))
+		}
+	}
+
+	if failed > 0 {
+		fmt.Printf("\nFAIL: %d case(s) failed\n", failed)
+		os.Exit(1)
+	}
+	fmt.Println("\nOK: all cases passed")
+}
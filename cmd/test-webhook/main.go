@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"time"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"github.com/yhonda-ohishi-pub-dev/desktop-server/server"
+)
+
+const secret = "test-secret"
+
+func main() {
+	// Local receiver: rejects the first request to exercise retries and
+	// checks the signature of every request
+	var mu sync.Mutex
+	var received []string
+	requests := 0
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+
+		if requests == 1 {
+			fmt.Println("Receiver: rejecting first request with 503")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		valid := server.VerifyWebhookSignature(secret,
+			r.Header.Get(server.WebhookTimestampHeader), r.Header.Get(server.WebhookSignatureHeader), body)
+		fmt.Printf("Receiver: %s %s (signature valid: %v)\n  %s\n",
+			r.Header.Get(server.WebhookEventHeader), r.Header.Get(server.WebhookDeliveryHeader), valid, body)
+		if !valid {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		received = append(received, r.Header.Get(server.WebhookEventHeader))
+	}))
+	defer receiver.Close()
+
+	progress := server.NewProgressService()
+	webhooks, err := server.NewWebhookService(progress, []server.WebhookConfig{
+		{
+			Name:     "etc-failures",
+			URL:      receiver.URL,
+			Secret:   secret,
+			Events:   []string{server.WebhookEventError, server.WebhookEventDeadLetter},
+			JobTypes: []string{server.EtcDownloadJob},
+			Template: `{"text": {{json (printf "ETC取込に失敗しました: %s" .Message)}}, "job_id": {{json .JobID}}}`,
+		},
+		{
+			Name:   "all-events",
+			URL:    receiver.URL,
+			Secret: secret,
+		},
+	},
+		server.WithWebhookHTTPClient(receiver.Client()),
+		server.WithWebhookRetry(server.RetryPolicy{InitialBackoff: 100 * time.Millisecond}),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	webhooks.Start()
+	defer webhooks.Stop()
+
+	fmt.Println("Broadcasting job events...")
+	progress.BroadcastProgress(&pb.ProgressUpdate{
+		Type:    pb.ProgressType_PROGRESS_TYPE_STARTED,
+		JobId:   "etc-1",
+		JobType: server.EtcDownloadJob,
+		Message: "ダウンロード開始",
+	})
+	progress.BroadcastProgress(&pb.ProgressUpdate{
+		Type:    pb.ProgressType_PROGRESS_TYPE_PROGRESS,
+		JobId:   "etc-1",
+		Message: "ダウンロード中",
+	})
+	progress.BroadcastProgress(&pb.ProgressUpdate{
+		Type:    pb.ProgressType_PROGRESS_TYPE_ERROR,
+		JobId:   "etc-1",
+		Message: "ログインに失敗しました",
+	})
+
+	// started + error for all-events, error for etc-failures
+	want := 3
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		n := len(received)
+		mu.Unlock()
+		if n >= want {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	fmt.Println("\nDelivery log:")
+	deliveries, _ := webhooks.ListWebhookDeliveries(context.Background(), &pb.ListWebhookDeliveriesRequest{})
+	for _, d := range deliveries.Deliveries {
+		fmt.Printf("  %s %-12s %-8s %-10s attempts=%d status=%d %v %s\n",
+			d.Id, d.Webhook, d.Event, d.JobId, d.Attempts, d.ResponseStatus, d.Status, d.Error)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != want {
+		fmt.Printf("\nFAIL: received %d deliveries, want %d\n", len(received), want)
+		os.Exit(1)
+	}
+	fmt.Printf("\nOK: received %d deliveries in %d requests\n", len(received), requests)
+}
@@ -15,6 +15,9 @@ import (
 const (
 	FrontendRepo    = "yhonda-ohishi-pub-dev/desktop-server-front"
 	FrontendDistDir = "frontend/dist"
+
+	// versionFile records the installed release inside FrontendDistDir
+	versionFile = ".version"
 )
 
 type GitHubRelease struct {
@@ -89,6 +92,7 @@ func DownloadLatestRelease(forceUpdate bool) error {
 	// Fallback to v1.2.0 if latest doesn't exist
 	if !urlExists(downloadURL) {
 		fmt.Printf("%s not found, falling back to v1.2.0...\n", latestVersion)
+		latestVersion = "v1.2.0"
 		downloadURL = fmt.Sprintf("https://github.com/%s/releases/download/v1.2.0/desktop-server-frontend-v1.2.0.zip", FrontendRepo)
 		fmt.Printf("Fallback URL: %s\n", downloadURL)
 	} else {
@@ -107,10 +111,25 @@ func DownloadLatestRelease(forceUpdate bool) error {
 		return fmt.Errorf("failed to extract frontend: %w", err)
 	}
 
+	// Remember the version for update checks
+	if err := os.WriteFile(filepath.Join(FrontendDistDir, versionFile), []byte(latestVersion), 0644); err != nil {
+		fmt.Printf("Warning: Failed to record frontend version: %v\n", err)
+	}
+
 	fmt.Println("Frontend downloaded successfully")
 	return nil
 }
 
+// InstalledFrontendVersion returns the version of the downloaded frontend,
+// or "" if it is unknown (e.g. downloaded by an older build)
+func InstalledFrontendVersion() string {
+	data, err := os.ReadFile(filepath.Join(FrontendDistDir, versionFile))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
 // urlExists checks if a URL is accessible
 func urlExists(url string) bool {
 	client := &http.Client{
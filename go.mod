@@ -8,6 +8,7 @@ require (
 	github.com/microsoft/go-mssqldb v1.8.2
 	github.com/yhonda-ohishi/db_service v1.9.1
 	github.com/yhonda-ohishi/dtako_rows/v3 v3.4.2
+	golang.org/x/text v0.30.0
 	google.golang.org/grpc v1.76.0
 	google.golang.org/protobuf v1.36.10
 )
@@ -35,7 +36,6 @@ require (
 	golang.org/x/crypto v0.43.0 // indirect
 	golang.org/x/net v0.46.0 // indirect
 	golang.org/x/sys v0.37.0 // indirect
-	golang.org/x/text v0.30.0 // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20251022142026-3a174f9686a8 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20251022142026-3a174f9686a8 // indirect
 	gorm.io/driver/mysql v1.5.2 // indirect
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -47,6 +50,111 @@ func setupLogging() (*os.File, error) {
 	return logFile, nil
 }
 
+// progressOptions reads ProgressService settings from environment variables
+func progressOptions() []server.ProgressOption {
+	var opts []server.ProgressOption
+
+	if v := os.Getenv("PROGRESS_QUEUE_SIZE"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil {
+			log.Printf("Warning: Invalid PROGRESS_QUEUE_SIZE %q: %v", v, err)
+		} else {
+			opts = append(opts, server.WithQueueSize(size))
+		}
+	}
+
+	if v := os.Getenv("PROGRESS_OVERFLOW_POLICY"); v != "" {
+		policy, err := server.ParseOverflowPolicy(v)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+		} else {
+			opts = append(opts, server.WithOverflowPolicy(policy))
+		}
+	}
+
+	return opts
+}
+
+// jobQueueLimits applies JOB_QUEUE_LIMITS, a comma separated list of
+// group=limit pairs such as "etc_scraper=1,cleanup=2"
+func jobQueueLimits(queue *server.JobQueueService) {
+	v := os.Getenv("JOB_QUEUE_LIMITS")
+	if v == "" {
+		return
+	}
+
+	for _, pair := range strings.Split(v, ",") {
+		group, limit, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		n, err := strconv.Atoi(limit)
+		if !ok || group == "" || err != nil || n < 1 {
+			log.Printf("Warning: Invalid JOB_QUEUE_LIMITS entry %q", pair)
+			continue
+		}
+		queue.SetGroupLimit(group, n)
+	}
+}
+
+// openJobHistory opens the job history store in the data directory
+func openJobHistory() (*server.JobHistory, error) {
+	dataDir, err := server.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return server.OpenJobHistory(filepath.Join(dataDir, "job_history.jsonl"),
+		server.DefaultHistoryMaxAge, server.DefaultHistoryMaxBytes)
+}
+
+// openScheduler loads the schedules stored in the data directory
+func openScheduler(queue *server.JobQueueService) (*server.SchedulerService, error) {
+	dataDir, err := server.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return server.NewSchedulerService(queue, filepath.Join(dataDir, "schedules.json"))
+}
+
+// openDatabaseProfiles loads the database connection profiles stored in the
+// data directory
+func openDatabaseProfiles() (*server.DatabaseProfiles, error) {
+	dataDir, err := server.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return server.NewDatabaseProfiles(filepath.Join(dataDir, "db_profiles.json"))
+}
+
+// openSavedQueries loads the saved queries stored in the data directory
+func openSavedQueries() (*server.SavedQueries, error) {
+	dataDir, err := server.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return server.NewSavedQueries(filepath.Join(dataDir, "saved_queries.json"))
+}
+
+// openQueryHistory opens the query history store in the data directory
+func openQueryHistory() (*server.QueryHistory, error) {
+	dataDir, err := server.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return server.OpenQueryHistory(filepath.Join(dataDir, "query_history.jsonl"),
+		server.DefaultHistoryMaxAge, server.DefaultHistoryMaxBytes)
+}
+
+// openWebhooks loads the webhook settings from the data directory
+func openWebhooks(progressService *server.ProgressService) (*server.WebhookService, error) {
+	dataDir, err := server.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	configs, err := server.LoadWebhookConfig(filepath.Join(dataDir, "webhooks.json"))
+	if err != nil {
+		return nil, err
+	}
+	return server.NewWebhookService(progressService, configs)
+}
+
 func main() {
 	// Setup file logging
 	logFile, err := setupLogging()
@@ -108,10 +216,80 @@ func main() {
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 
 	// Initialize progress service for gRPC streaming
-	progressService := server.NewProgressService()
+	progressOpts := progressOptions()
+	if history, err := openJobHistory(); err != nil {
+		log.Printf("Warning: Job history disabled: %v", err)
+	} else {
+		defer history.Close()
+		progressOpts = append(progressOpts, server.WithJobHistory(history))
+	}
+	progressService := server.NewProgressService(progressOpts...)
+
+	// Initialize job queue and the job types it can run
+	jobQueue := server.NewJobQueueService(progressService)
+	jobQueue.RegisterJobType(server.EtcDownloadJobType("localhost:" + grpcPort))
+	jobQueue.RegisterJobType(server.FrontendUpdateCheckJobType())
+	jobQueue.RegisterJobType(server.DownloadCleanupJobType())
+	jobQueueLimits(jobQueue)
+
+	// Start scheduler for recurring jobs
+	scheduler, err := openScheduler(jobQueue)
+	if err != nil {
+		log.Printf("Warning: Scheduler disabled: %v", err)
+	} else {
+		scheduler.Start()
+		defer scheduler.Stop()
+	}
+
+	// Start webhook notifications for job lifecycle events
+	webhooks, err := openWebhooks(progressService)
+	if err != nil {
+		log.Printf("Warning: Webhooks disabled: %v", err)
+	} else {
+		webhooks.Start()
+		defer webhooks.Stop()
+	}
+
+	// DB_READ_ONLY=true blocks everything but reads on every connection
+	if v := os.Getenv("DB_READ_ONLY"); v != "" {
+		readOnly, err := strconv.ParseBool(v)
+		if err != nil {
+			log.Printf("Warning: Invalid DB_READ_ONLY %q: %v", v, err)
+		} else {
+			server.SetReadOnlyMode(readOnly)
+			log.Printf("Database read-only mode: %v", readOnly)
+		}
+	}
+
+	// Load database connection profiles; "default" is the one configured by
+	// DB_*. Connections are opened on first use, the selected one is checked
+	// now so that problems show up in the log.
+	profiles, err := openDatabaseProfiles()
+	if err != nil {
+		log.Printf("Warning: Saved database profiles disabled: %v", err)
+		profiles, _ = server.NewDatabaseProfiles("")
+	}
+	defer profiles.Close()
+	if _, err := profiles.Conn(context.Background(), ""); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	var databaseOpts []server.DatabaseOption
+	if queries, err := openSavedQueries(); err != nil {
+		log.Printf("Warning: Saved queries disabled: %v", err)
+	} else {
+		databaseOpts = append(databaseOpts, server.WithSavedQueries(queries))
+	}
+	if history, err := openQueryHistory(); err != nil {
+		log.Printf("Warning: Query history disabled: %v", err)
+	} else {
+		defer history.Close()
+		databaseOpts = append(databaseOpts, server.WithQueryHistory(history))
+	}
 
-	// Start gRPC server with ProgressService
-	grpcServer := server.NewGRPCServer(progressService)
+	// Start gRPC server with ProgressService, JobQueueService, SchedulerService, WebhookService and DatabaseService
+	database := server.NewDatabaseService(profiles, databaseOpts...)
+	grpcServer := server.NewGRPCServer(progressService, jobQueue, scheduler, webhooks, database)
 	go func() {
 		if err := grpcServer.Start(":" + grpcPort); err != nil {
 			log.Fatalf("Failed to start gRPC server: %v", err)
@@ -119,7 +297,7 @@ func main() {
 	}()
 
 	// Start HTTP + gRPC-Web proxy server
-	httpServer := server.NewHTTPServer(grpcServer, progressService)
+	httpServer := server.NewHTTPServer(grpcServer, progressService, database)
 	go func() {
 		if err := httpServer.Start(":" + httpPort); err != nil {
 			log.Fatalf("Failed to start HTTP server: %v", err)
@@ -0,0 +1,170 @@
+// Package progressclient lets processes running next to desktop-server
+// (such as etc_meisai_scraper.exe) publish progress to the browser through
+// ProgressService.PublishProgress.
+package progressclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// DefaultAddr is the default gRPC address of desktop-server
+	DefaultAddr = "localhost:50051"
+
+	// publisherIDKey must match server.PublisherIDMetadataKey. It is repeated
+	// here so that publishers don't have to link the whole server package.
+	publisherIDKey = "x-publisher-id"
+)
+
+// Environment variables desktop-server sets for processes it launches to run
+// a queued job
+const (
+	EnvAddr        = "DESKTOP_SERVER_GRPC_ADDR"
+	EnvJobID       = "DESKTOP_SERVER_JOB_ID"
+	EnvPublisherID = "DESKTOP_SERVER_PUBLISHER_ID"
+)
+
+// Publisher sends progress updates over a single PublishProgress stream
+type Publisher struct {
+	conn   *grpc.ClientConn
+	stream pb.ProgressService_PublishProgressClient
+	cancel context.CancelFunc
+}
+
+// Dial connects to desktop-server at addr. publisherID identifies this
+// process; reusing the same ID after a reconnect keeps ownership of jobs
+// started earlier. An empty ID makes the publisher anonymous, in which case
+// its unfinished jobs are failed when the stream ends.
+func Dial(addr, publisherID string) (*Publisher, error) {
+	if addr == "" {
+		addr = DefaultAddr
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to desktop-server: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if publisherID != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, publisherIDKey, publisherID)
+	}
+
+	stream, err := pb.NewProgressServiceClient(conn).PublishProgress(ctx)
+	if err != nil {
+		cancel()
+		conn.Close()
+		return nil, fmt.Errorf("failed to open progress stream: %w", err)
+	}
+
+	return &Publisher{
+		conn:   conn,
+		stream: stream,
+		cancel: cancel,
+	}, nil
+}
+
+// DialFromEnv connects with the settings desktop-server passes to a process
+// launched for a queued job, and returns the ID of the job to report on. The
+// job ID is empty if the process was not launched by desktop-server.
+func DialFromEnv() (*Publisher, string, error) {
+	p, err := Dial(os.Getenv(EnvAddr), os.Getenv(EnvPublisherID))
+	if err != nil {
+		return nil, "", err
+	}
+	return p, os.Getenv(EnvJobID), nil
+}
+
+// Send publishes a raw progress update
+func (p *Publisher) Send(update *pb.ProgressUpdate) error {
+	err := p.stream.Send(update)
+	if errors.Is(err, io.EOF) {
+		// The server closed the stream; the real reason comes from CloseAndRecv
+		_, err = p.stream.CloseAndRecv()
+	}
+	return err
+}
+
+// Started reports that a job has started
+func (p *Publisher) Started(jobID, jobType, message string) error {
+	return p.Send(&pb.ProgressUpdate{
+		Type:    pb.ProgressType_PROGRESS_TYPE_STARTED,
+		JobId:   jobID,
+		JobType: jobType,
+		Message: message,
+	})
+}
+
+// Progress reports step progress of a running job
+func (p *Publisher) Progress(jobID, message string, currentStep, totalSteps int32) error {
+	var percentage int32
+	if totalSteps > 0 {
+		percentage = currentStep * 100 / totalSteps
+	}
+	return p.Send(&pb.ProgressUpdate{
+		Type:        pb.ProgressType_PROGRESS_TYPE_PROGRESS,
+		JobId:       jobID,
+		Message:     message,
+		CurrentStep: currentStep,
+		TotalSteps:  totalSteps,
+		Percentage:  percentage,
+	})
+}
+
+// Transfer reports byte progress of a running job. The server derives the
+// transfer rate and remaining time from successive calls.
+func (p *Publisher) Transfer(jobID, message string, bytesTransferred, bytesTotal int64) error {
+	var percentage int32
+	if bytesTotal > 0 {
+		percentage = int32(bytesTransferred * 100 / bytesTotal)
+	}
+	return p.Send(&pb.ProgressUpdate{
+		Type:             pb.ProgressType_PROGRESS_TYPE_PROGRESS,
+		JobId:            jobID,
+		Message:          message,
+		BytesTransferred: bytesTransferred,
+		BytesTotal:       bytesTotal,
+		Percentage:       percentage,
+	})
+}
+
+// Complete reports that a job has finished successfully
+func (p *Publisher) Complete(jobID, message string) error {
+	return p.Send(&pb.ProgressUpdate{
+		Type:       pb.ProgressType_PROGRESS_TYPE_COMPLETE,
+		JobId:      jobID,
+		Message:    message,
+		Percentage: 100,
+	})
+}
+
+// Error reports that a job has failed
+func (p *Publisher) Error(jobID, message string) error {
+	return p.Send(&pb.ProgressUpdate{
+		Type:    pb.ProgressType_PROGRESS_TYPE_ERROR,
+		JobId:   jobID,
+		Message: message,
+	})
+}
+
+// Close ends the stream and returns the server's summary, including jobs
+// that were cancelled while publishing
+func (p *Publisher) Close() (*pb.PublishProgressResponse, error) {
+	defer p.conn.Close()
+	defer p.cancel()
+
+	resp, err := p.stream.CloseAndRecv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to close progress stream: %w", err)
+	}
+	return resp, nil
+}
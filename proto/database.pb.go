@@ -0,0 +1,5717 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: database.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 値の種類
+type CellKind int32
+
+const (
+	CellKind_CELL_KIND_UNSPECIFIED CellKind = 0
+	CellKind_CELL_KIND_INT         CellKind = 1 // int_value（UNSIGNED BIGINTでint64に収まらない値はdecimal_value）
+	CellKind_CELL_KIND_DECIMAL     CellKind = 2 // decimal_value（DECIMAL, NUMERIC, MONEY。桁を落とさないため文字列）
+	CellKind_CELL_KIND_FLOAT       CellKind = 3 // float_value
+	CellKind_CELL_KIND_BOOL        CellKind = 4 // bool_value（SQL ServerのBIT）
+	CellKind_CELL_KIND_STRING      CellKind = 5 // string_value（UNIQUEIDENTIFIERは "6F9619FF-8B86-D011-B42D-00C04FC964FF" の形式）
+	CellKind_CELL_KIND_BYTES       CellKind = 6 // bytes_value（BINARY, BLOB, MySQLのBITなど）
+	CellKind_CELL_KIND_TIMESTAMP   CellKind = 7 // timestamp_value
+	CellKind_CELL_KIND_DATE        CellKind = 8 // timestamp_value（時刻は0:00）
+	CellKind_CELL_KIND_TIME        CellKind = 9 // string_value（"15:04:05.9999999"）
+)
+
+// Enum value maps for CellKind.
+var (
+	CellKind_name = map[int32]string{
+		0: "CELL_KIND_UNSPECIFIED",
+		1: "CELL_KIND_INT",
+		2: "CELL_KIND_DECIMAL",
+		3: "CELL_KIND_FLOAT",
+		4: "CELL_KIND_BOOL",
+		5: "CELL_KIND_STRING",
+		6: "CELL_KIND_BYTES",
+		7: "CELL_KIND_TIMESTAMP",
+		8: "CELL_KIND_DATE",
+		9: "CELL_KIND_TIME",
+	}
+	CellKind_value = map[string]int32{
+		"CELL_KIND_UNSPECIFIED": 0,
+		"CELL_KIND_INT":         1,
+		"CELL_KIND_DECIMAL":     2,
+		"CELL_KIND_FLOAT":       3,
+		"CELL_KIND_BOOL":        4,
+		"CELL_KIND_STRING":      5,
+		"CELL_KIND_BYTES":       6,
+		"CELL_KIND_TIMESTAMP":   7,
+		"CELL_KIND_DATE":        8,
+		"CELL_KIND_TIME":        9,
+	}
+)
+
+func (x CellKind) Enum() *CellKind {
+	p := new(CellKind)
+	*p = x
+	return p
+}
+
+func (x CellKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CellKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_database_proto_enumTypes[0].Descriptor()
+}
+
+func (CellKind) Type() protoreflect.EnumType {
+	return &file_database_proto_enumTypes[0]
+}
+
+func (x CellKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CellKind.Descriptor instead.
+func (CellKind) EnumDescriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{0}
+}
+
+// 絞り込み条件の演算子
+type FilterOp int32
+
+const (
+	FilterOp_FILTER_OP_UNSPECIFIED FilterOp = 0
+	FilterOp_FILTER_OP_EQ          FilterOp = 1
+	FilterOp_FILTER_OP_NE          FilterOp = 2
+	FilterOp_FILTER_OP_LT          FilterOp = 3
+	FilterOp_FILTER_OP_LE          FilterOp = 4
+	FilterOp_FILTER_OP_GT          FilterOp = 5
+	FilterOp_FILTER_OP_GE          FilterOp = 6
+	FilterOp_FILTER_OP_CONTAINS    FilterOp = 7  // 部分一致（文字列）
+	FilterOp_FILTER_OP_STARTS_WITH FilterOp = 8  // 前方一致（文字列）
+	FilterOp_FILTER_OP_IS_NULL     FilterOp = 9  // valueは不要
+	FilterOp_FILTER_OP_IS_NOT_NULL FilterOp = 10 // valueは不要
+	FilterOp_FILTER_OP_IN          FilterOp = 11 // valuesのいずれかに一致
+)
+
+// Enum value maps for FilterOp.
+var (
+	FilterOp_name = map[int32]string{
+		0:  "FILTER_OP_UNSPECIFIED",
+		1:  "FILTER_OP_EQ",
+		2:  "FILTER_OP_NE",
+		3:  "FILTER_OP_LT",
+		4:  "FILTER_OP_LE",
+		5:  "FILTER_OP_GT",
+		6:  "FILTER_OP_GE",
+		7:  "FILTER_OP_CONTAINS",
+		8:  "FILTER_OP_STARTS_WITH",
+		9:  "FILTER_OP_IS_NULL",
+		10: "FILTER_OP_IS_NOT_NULL",
+		11: "FILTER_OP_IN",
+	}
+	FilterOp_value = map[string]int32{
+		"FILTER_OP_UNSPECIFIED": 0,
+		"FILTER_OP_EQ":          1,
+		"FILTER_OP_NE":          2,
+		"FILTER_OP_LT":          3,
+		"FILTER_OP_LE":          4,
+		"FILTER_OP_GT":          5,
+		"FILTER_OP_GE":          6,
+		"FILTER_OP_CONTAINS":    7,
+		"FILTER_OP_STARTS_WITH": 8,
+		"FILTER_OP_IS_NULL":     9,
+		"FILTER_OP_IS_NOT_NULL": 10,
+		"FILTER_OP_IN":          11,
+	}
+)
+
+func (x FilterOp) Enum() *FilterOp {
+	p := new(FilterOp)
+	*p = x
+	return p
+}
+
+func (x FilterOp) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FilterOp) Descriptor() protoreflect.EnumDescriptor {
+	return file_database_proto_enumTypes[1].Descriptor()
+}
+
+func (FilterOp) Type() protoreflect.EnumType {
+	return &file_database_proto_enumTypes[1]
+}
+
+func (x FilterOp) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FilterOp.Descriptor instead.
+func (FilterOp) EnumDescriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{1}
+}
+
+// 型付きパラメータ
+// SQL Serverでは @p1, @p2...、MySQLでは ? で順番に参照する
+type SqlValue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Kind:
+	//
+	//	*SqlValue_NullValue
+	//	*SqlValue_StringValue
+	//	*SqlValue_IntValue
+	//	*SqlValue_DoubleValue
+	//	*SqlValue_BoolValue
+	//	*SqlValue_BytesValue
+	//	*SqlValue_TimeValue
+	Kind          isSqlValue_Kind `protobuf_oneof:"kind"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SqlValue) Reset() {
+	*x = SqlValue{}
+	mi := &file_database_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SqlValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SqlValue) ProtoMessage() {}
+
+func (x *SqlValue) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SqlValue.ProtoReflect.Descriptor instead.
+func (*SqlValue) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SqlValue) GetKind() isSqlValue_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *SqlValue) GetNullValue() bool {
+	if x != nil {
+		if x, ok := x.Kind.(*SqlValue_NullValue); ok {
+			return x.NullValue
+		}
+	}
+	return false
+}
+
+func (x *SqlValue) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Kind.(*SqlValue_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+func (x *SqlValue) GetIntValue() int64 {
+	if x != nil {
+		if x, ok := x.Kind.(*SqlValue_IntValue); ok {
+			return x.IntValue
+		}
+	}
+	return 0
+}
+
+func (x *SqlValue) GetDoubleValue() float64 {
+	if x != nil {
+		if x, ok := x.Kind.(*SqlValue_DoubleValue); ok {
+			return x.DoubleValue
+		}
+	}
+	return 0
+}
+
+func (x *SqlValue) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Kind.(*SqlValue_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+func (x *SqlValue) GetBytesValue() []byte {
+	if x != nil {
+		if x, ok := x.Kind.(*SqlValue_BytesValue); ok {
+			return x.BytesValue
+		}
+	}
+	return nil
+}
+
+func (x *SqlValue) GetTimeValue() *timestamppb.Timestamp {
+	if x != nil {
+		if x, ok := x.Kind.(*SqlValue_TimeValue); ok {
+			return x.TimeValue
+		}
+	}
+	return nil
+}
+
+type isSqlValue_Kind interface {
+	isSqlValue_Kind()
+}
+
+type SqlValue_NullValue struct {
+	NullValue bool `protobuf:"varint,1,opt,name=null_value,json=nullValue,proto3,oneof"`
+}
+
+type SqlValue_StringValue struct {
+	StringValue string `protobuf:"bytes,2,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type SqlValue_IntValue struct {
+	IntValue int64 `protobuf:"varint,3,opt,name=int_value,json=intValue,proto3,oneof"`
+}
+
+type SqlValue_DoubleValue struct {
+	DoubleValue float64 `protobuf:"fixed64,4,opt,name=double_value,json=doubleValue,proto3,oneof"`
+}
+
+type SqlValue_BoolValue struct {
+	BoolValue bool `protobuf:"varint,5,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type SqlValue_BytesValue struct {
+	BytesValue []byte `protobuf:"bytes,6,opt,name=bytes_value,json=bytesValue,proto3,oneof"`
+}
+
+type SqlValue_TimeValue struct {
+	TimeValue *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=time_value,json=timeValue,proto3,oneof"`
+}
+
+func (*SqlValue_NullValue) isSqlValue_Kind() {}
+
+func (*SqlValue_StringValue) isSqlValue_Kind() {}
+
+func (*SqlValue_IntValue) isSqlValue_Kind() {}
+
+func (*SqlValue_DoubleValue) isSqlValue_Kind() {}
+
+func (*SqlValue_BoolValue) isSqlValue_Kind() {}
+
+func (*SqlValue_BytesValue) isSqlValue_Kind() {}
+
+func (*SqlValue_TimeValue) isSqlValue_Kind() {}
+
+// クエリリクエスト
+type QueryRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Sql    string                 `protobuf:"bytes,1,opt,name=sql,proto3" json:"sql,omitempty"`
+	Params []*SqlValue            `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty"`
+	// 最大行数（0の場合は1000、上限10000）
+	MaxRows int32 `protobuf:"varint,3,opt,name=max_rows,json=maxRows,proto3" json:"max_rows,omitempty"`
+	// 接続プロファイル名（空の場合は選択中のプロファイル）
+	Profile string `protobuf:"bytes,4,opt,name=profile,proto3" json:"profile,omitempty"`
+	// 実行時間の上限（秒、0の場合はプロファイルのクエリタイムアウト。プロファイルの上限は超えられない）
+	TimeoutSeconds int32 `protobuf:"varint,5,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	// trueの場合、結果をheaderとtyped_rows（型付きのセル）で返し、rowsは空にする
+	Typed         bool `protobuf:"varint,6,opt,name=typed,proto3" json:"typed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryRequest) Reset() {
+	*x = QueryRequest{}
+	mi := &file_database_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryRequest) ProtoMessage() {}
+
+func (x *QueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryRequest.ProtoReflect.Descriptor instead.
+func (*QueryRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *QueryRequest) GetSql() string {
+	if x != nil {
+		return x.Sql
+	}
+	return ""
+}
+
+func (x *QueryRequest) GetParams() []*SqlValue {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *QueryRequest) GetMaxRows() int32 {
+	if x != nil {
+		return x.MaxRows
+	}
+	return 0
+}
+
+func (x *QueryRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *QueryRequest) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *QueryRequest) GetTyped() bool {
+	if x != nil {
+		return x.Typed
+	}
+	return false
+}
+
+// クエリレスポンス
+type QueryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Rows  []*Row                 `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	// 返した行数
+	Count int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// 列名（SELECTの順）
+	Columns []string `protobuf:"bytes,3,rep,name=columns,proto3" json:"columns,omitempty"`
+	// max_rowsで打ち切られた場合true
+	Truncated bool `protobuf:"varint,4,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// 列の型情報（typedの場合のみ、SELECTの順）
+	Header []*ColumnMeta `protobuf:"bytes,5,rep,name=header,proto3" json:"header,omitempty"`
+	// 型付きの行（typedの場合のみ）
+	TypedRows     []*TypedRow `protobuf:"bytes,6,rep,name=typed_rows,json=typedRows,proto3" json:"typed_rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryResponse) Reset() {
+	*x = QueryResponse{}
+	mi := &file_database_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryResponse) ProtoMessage() {}
+
+func (x *QueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryResponse.ProtoReflect.Descriptor instead.
+func (*QueryResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *QueryResponse) GetRows() []*Row {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *QueryResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *QueryResponse) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *QueryResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *QueryResponse) GetHeader() []*ColumnMeta {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *QueryResponse) GetTypedRows() []*TypedRow {
+	if x != nil {
+		return x.TypedRows
+	}
+	return nil
+}
+
+// 行（NULLは空文字）
+type Row struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Columns       map[string]string      `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Row) Reset() {
+	*x = Row{}
+	mi := &file_database_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Row) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Row) ProtoMessage() {}
+
+func (x *Row) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Row.ProtoReflect.Descriptor instead.
+func (*Row) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Row) GetColumns() map[string]string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+// 列の型情報
+type ColumnMeta struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// データベースの型名（例: "NVARCHAR", "DECIMAL", "DATETIMEOFFSET", "UNSIGNED BIGINT"）
+	DatabaseType string   `protobuf:"bytes,2,opt,name=database_type,json=databaseType,proto3" json:"database_type,omitempty"`
+	Kind         CellKind `protobuf:"varint,3,opt,name=kind,proto3,enum=desktop_server.v1.CellKind" json:"kind,omitempty"`
+	// NULLを許可するか（ドライバーが返さない場合は未設定）
+	Nullable *bool `protobuf:"varint,4,opt,name=nullable,proto3,oneof" json:"nullable,omitempty"`
+	// 可変長の型の最大長（ドライバーが返さない場合は未設定）
+	Length *int64 `protobuf:"varint,5,opt,name=length,proto3,oneof" json:"length,omitempty"`
+	// DECIMALなどの精度と小数点以下の桁数
+	Precision     *int64 `protobuf:"varint,6,opt,name=precision,proto3,oneof" json:"precision,omitempty"`
+	Scale         *int64 `protobuf:"varint,7,opt,name=scale,proto3,oneof" json:"scale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColumnMeta) Reset() {
+	*x = ColumnMeta{}
+	mi := &file_database_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColumnMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColumnMeta) ProtoMessage() {}
+
+func (x *ColumnMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColumnMeta.ProtoReflect.Descriptor instead.
+func (*ColumnMeta) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ColumnMeta) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ColumnMeta) GetDatabaseType() string {
+	if x != nil {
+		return x.DatabaseType
+	}
+	return ""
+}
+
+func (x *ColumnMeta) GetKind() CellKind {
+	if x != nil {
+		return x.Kind
+	}
+	return CellKind_CELL_KIND_UNSPECIFIED
+}
+
+func (x *ColumnMeta) GetNullable() bool {
+	if x != nil && x.Nullable != nil {
+		return *x.Nullable
+	}
+	return false
+}
+
+func (x *ColumnMeta) GetLength() int64 {
+	if x != nil && x.Length != nil {
+		return *x.Length
+	}
+	return 0
+}
+
+func (x *ColumnMeta) GetPrecision() int64 {
+	if x != nil && x.Precision != nil {
+		return *x.Precision
+	}
+	return 0
+}
+
+func (x *ColumnMeta) GetScale() int64 {
+	if x != nil && x.Scale != nil {
+		return *x.Scale
+	}
+	return 0
+}
+
+// 型付きの行
+type TypedRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cells         []*Cell                `protobuf:"bytes,1,rep,name=cells,proto3" json:"cells,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypedRow) Reset() {
+	*x = TypedRow{}
+	mi := &file_database_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypedRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypedRow) ProtoMessage() {}
+
+func (x *TypedRow) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypedRow.ProtoReflect.Descriptor instead.
+func (*TypedRow) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TypedRow) GetCells() []*Cell {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+// 型付きのセル（NULLと空文字を区別する）
+type Cell struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Value:
+	//
+	//	*Cell_NullValue
+	//	*Cell_IntValue
+	//	*Cell_DecimalValue
+	//	*Cell_FloatValue
+	//	*Cell_BoolValue
+	//	*Cell_StringValue
+	//	*Cell_BytesValue
+	//	*Cell_TimestampValue
+	Value         isCell_Value `protobuf_oneof:"value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cell) Reset() {
+	*x = Cell{}
+	mi := &file_database_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cell) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cell) ProtoMessage() {}
+
+func (x *Cell) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cell.ProtoReflect.Descriptor instead.
+func (*Cell) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Cell) GetValue() isCell_Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *Cell) GetNullValue() bool {
+	if x != nil {
+		if x, ok := x.Value.(*Cell_NullValue); ok {
+			return x.NullValue
+		}
+	}
+	return false
+}
+
+func (x *Cell) GetIntValue() int64 {
+	if x != nil {
+		if x, ok := x.Value.(*Cell_IntValue); ok {
+			return x.IntValue
+		}
+	}
+	return 0
+}
+
+func (x *Cell) GetDecimalValue() string {
+	if x != nil {
+		if x, ok := x.Value.(*Cell_DecimalValue); ok {
+			return x.DecimalValue
+		}
+	}
+	return ""
+}
+
+func (x *Cell) GetFloatValue() float64 {
+	if x != nil {
+		if x, ok := x.Value.(*Cell_FloatValue); ok {
+			return x.FloatValue
+		}
+	}
+	return 0
+}
+
+func (x *Cell) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Value.(*Cell_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+func (x *Cell) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Value.(*Cell_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+func (x *Cell) GetBytesValue() []byte {
+	if x != nil {
+		if x, ok := x.Value.(*Cell_BytesValue); ok {
+			return x.BytesValue
+		}
+	}
+	return nil
+}
+
+func (x *Cell) GetTimestampValue() *ZonedTimestamp {
+	if x != nil {
+		if x, ok := x.Value.(*Cell_TimestampValue); ok {
+			return x.TimestampValue
+		}
+	}
+	return nil
+}
+
+type isCell_Value interface {
+	isCell_Value()
+}
+
+type Cell_NullValue struct {
+	NullValue bool `protobuf:"varint,1,opt,name=null_value,json=nullValue,proto3,oneof"`
+}
+
+type Cell_IntValue struct {
+	IntValue int64 `protobuf:"varint,2,opt,name=int_value,json=intValue,proto3,oneof"`
+}
+
+type Cell_DecimalValue struct {
+	DecimalValue string `protobuf:"bytes,3,opt,name=decimal_value,json=decimalValue,proto3,oneof"`
+}
+
+type Cell_FloatValue struct {
+	FloatValue float64 `protobuf:"fixed64,4,opt,name=float_value,json=floatValue,proto3,oneof"`
+}
+
+type Cell_BoolValue struct {
+	BoolValue bool `protobuf:"varint,5,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type Cell_StringValue struct {
+	StringValue string `protobuf:"bytes,6,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type Cell_BytesValue struct {
+	BytesValue []byte `protobuf:"bytes,7,opt,name=bytes_value,json=bytesValue,proto3,oneof"`
+}
+
+type Cell_TimestampValue struct {
+	TimestampValue *ZonedTimestamp `protobuf:"bytes,8,opt,name=timestamp_value,json=timestampValue,proto3,oneof"`
+}
+
+func (*Cell_NullValue) isCell_Value() {}
+
+func (*Cell_IntValue) isCell_Value() {}
+
+func (*Cell_DecimalValue) isCell_Value() {}
+
+func (*Cell_FloatValue) isCell_Value() {}
+
+func (*Cell_BoolValue) isCell_Value() {}
+
+func (*Cell_StringValue) isCell_Value() {}
+
+func (*Cell_BytesValue) isCell_Value() {}
+
+func (*Cell_TimestampValue) isCell_Value() {}
+
+// タイムゾーン情報つきの日時
+type ZonedTimestamp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 日時。has_zoneがfalseの場合は、保存されている日時をUTCとして表したもの
+	Time *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// 元の値のUTCからのオフセット（秒）
+	UtcOffsetSeconds int32 `protobuf:"varint,2,opt,name=utc_offset_seconds,json=utcOffsetSeconds,proto3" json:"utc_offset_seconds,omitempty"`
+	// 値がタイムゾーンを持つ場合true（SQL ServerのDATETIMEOFFSET）
+	HasZone       bool `protobuf:"varint,3,opt,name=has_zone,json=hasZone,proto3" json:"has_zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZonedTimestamp) Reset() {
+	*x = ZonedTimestamp{}
+	mi := &file_database_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZonedTimestamp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZonedTimestamp) ProtoMessage() {}
+
+func (x *ZonedTimestamp) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZonedTimestamp.ProtoReflect.Descriptor instead.
+func (*ZonedTimestamp) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ZonedTimestamp) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ZonedTimestamp) GetUtcOffsetSeconds() int32 {
+	if x != nil {
+		return x.UtcOffsetSeconds
+	}
+	return 0
+}
+
+func (x *ZonedTimestamp) GetHasZone() bool {
+	if x != nil {
+		return x.HasZone
+	}
+	return false
+}
+
+// ストリーミングクエリリクエスト
+type StreamQueryRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Sql    string                 `protobuf:"bytes,1,opt,name=sql,proto3" json:"sql,omitempty"`
+	Params []*SqlValue            `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty"`
+	// 最大行数（0の場合は無制限）
+	MaxRows int32 `protobuf:"varint,3,opt,name=max_rows,json=maxRows,proto3" json:"max_rows,omitempty"`
+	// 接続プロファイル名（空の場合は選択中のプロファイル）
+	Profile string `protobuf:"bytes,4,opt,name=profile,proto3" json:"profile,omitempty"`
+	// 実行時間の上限（秒、0の場合はプロファイルのクエリタイムアウト。プロファイルの上限は超えられない）
+	TimeoutSeconds int32 `protobuf:"varint,5,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	// trueの場合、各行をcells（型付きのセル）で返し、columnsは空にする。行の前に列名とheaderだけのメッセージを送る（結果が0行でも送る）
+	Typed         bool `protobuf:"varint,6,opt,name=typed,proto3" json:"typed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamQueryRequest) Reset() {
+	*x = StreamQueryRequest{}
+	mi := &file_database_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamQueryRequest) ProtoMessage() {}
+
+func (x *StreamQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamQueryRequest.ProtoReflect.Descriptor instead.
+func (*StreamQueryRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StreamQueryRequest) GetSql() string {
+	if x != nil {
+		return x.Sql
+	}
+	return ""
+}
+
+func (x *StreamQueryRequest) GetParams() []*SqlValue {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *StreamQueryRequest) GetMaxRows() int32 {
+	if x != nil {
+		return x.MaxRows
+	}
+	return 0
+}
+
+func (x *StreamQueryRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *StreamQueryRequest) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *StreamQueryRequest) GetTyped() bool {
+	if x != nil {
+		return x.Typed
+	}
+	return false
+}
+
+// ストリーミングの1行
+type QueryRow struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Columns map[string]string      `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 列名（SELECTの順、最初のメッセージのみ）
+	ColumnNames []string `protobuf:"bytes,2,rep,name=column_names,json=columnNames,proto3" json:"column_names,omitempty"`
+	// 型付きのセル（typedの場合のみ、headerの順）
+	Cells []*Cell `protobuf:"bytes,3,rep,name=cells,proto3" json:"cells,omitempty"`
+	// 列の型情報（typedの場合、最初のメッセージのみ。このメッセージにcellsはない）
+	Header        []*ColumnMeta `protobuf:"bytes,4,rep,name=header,proto3" json:"header,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryRow) Reset() {
+	*x = QueryRow{}
+	mi := &file_database_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryRow) ProtoMessage() {}
+
+func (x *QueryRow) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryRow.ProtoReflect.Descriptor instead.
+func (*QueryRow) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *QueryRow) GetColumns() map[string]string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *QueryRow) GetColumnNames() []string {
+	if x != nil {
+		return x.ColumnNames
+	}
+	return nil
+}
+
+func (x *QueryRow) GetCells() []*Cell {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+func (x *QueryRow) GetHeader() []*ColumnMeta {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+// テーブル一覧リクエスト
+type GetTablesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 接続プロファイル名（空の場合は選択中のプロファイル）
+	Profile       string `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTablesRequest) Reset() {
+	*x = GetTablesRequest{}
+	mi := &file_database_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTablesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTablesRequest) ProtoMessage() {}
+
+func (x *GetTablesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTablesRequest.ProtoReflect.Descriptor instead.
+func (*GetTablesRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetTablesRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+// テーブル一覧レスポンス
+type GetTablesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tables        []string               `protobuf:"bytes,1,rep,name=tables,proto3" json:"tables,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTablesResponse) Reset() {
+	*x = GetTablesResponse{}
+	mi := &file_database_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTablesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTablesResponse) ProtoMessage() {}
+
+func (x *GetTablesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTablesResponse.ProtoReflect.Descriptor instead.
+func (*GetTablesResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetTablesResponse) GetTables() []string {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+// 実行リクエスト
+type ExecuteRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Sql    string                 `protobuf:"bytes,1,opt,name=sql,proto3" json:"sql,omitempty"`
+	Params []*SqlValue            `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty"`
+	// 接続プロファイル名（空の場合は選択中のプロファイル）
+	Profile string `protobuf:"bytes,3,opt,name=profile,proto3" json:"profile,omitempty"`
+	// 実行時間の上限（秒、0の場合はプロファイルのクエリタイムアウト。プロファイルの上限は超えられない）
+	TimeoutSeconds int32 `protobuf:"varint,4,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExecuteRequest) Reset() {
+	*x = ExecuteRequest{}
+	mi := &file_database_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteRequest) ProtoMessage() {}
+
+func (x *ExecuteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteRequest.ProtoReflect.Descriptor instead.
+func (*ExecuteRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ExecuteRequest) GetSql() string {
+	if x != nil {
+		return x.Sql
+	}
+	return ""
+}
+
+func (x *ExecuteRequest) GetParams() []*SqlValue {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *ExecuteRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *ExecuteRequest) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+// 実行レスポンス
+type ExecuteResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	AffectedRows int64                  `protobuf:"varint,1,opt,name=affected_rows,json=affectedRows,proto3" json:"affected_rows,omitempty"`
+	// 最後に挿入された行のID（MySQLのみ）
+	LastInsertId  int64 `protobuf:"varint,2,opt,name=last_insert_id,json=lastInsertId,proto3" json:"last_insert_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteResponse) Reset() {
+	*x = ExecuteResponse{}
+	mi := &file_database_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteResponse) ProtoMessage() {}
+
+func (x *ExecuteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteResponse.ProtoReflect.Descriptor instead.
+func (*ExecuteResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExecuteResponse) GetAffectedRows() int64 {
+	if x != nil {
+		return x.AffectedRows
+	}
+	return 0
+}
+
+func (x *ExecuteResponse) GetLastInsertId() int64 {
+	if x != nil {
+		return x.LastInsertId
+	}
+	return 0
+}
+
+// スキーマ一覧リクエスト
+type GetSchemaRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// スキーマ名（SQL Serverでは空の場合すべてのスキーマ、MySQLでは空の場合接続中のデータベース）
+	Schema string `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	// 接続プロファイル名（空の場合は選択中のプロファイル）
+	Profile       string `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSchemaRequest) Reset() {
+	*x = GetSchemaRequest{}
+	mi := &file_database_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSchemaRequest) ProtoMessage() {}
+
+func (x *GetSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSchemaRequest.ProtoReflect.Descriptor instead.
+func (*GetSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetSchemaRequest) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *GetSchemaRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+// スキーマ一覧レスポンス
+type GetSchemaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tables        []*TableInfo           `protobuf:"bytes,1,rep,name=tables,proto3" json:"tables,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSchemaResponse) Reset() {
+	*x = GetSchemaResponse{}
+	mi := &file_database_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSchemaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSchemaResponse) ProtoMessage() {}
+
+func (x *GetSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSchemaResponse.ProtoReflect.Descriptor instead.
+func (*GetSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetSchemaResponse) GetTables() []*TableInfo {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+// テーブル定義リクエスト
+type DescribeTableRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// スキーマ名（空の場合はデフォルトスキーマ: SQL Serverはdbo等、MySQLは接続中のデータベース）
+	Schema string `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	Table  string `protobuf:"bytes,2,opt,name=table,proto3" json:"table,omitempty"`
+	// 接続プロファイル名（空の場合は選択中のプロファイル）
+	Profile       string `protobuf:"bytes,3,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DescribeTableRequest) Reset() {
+	*x = DescribeTableRequest{}
+	mi := &file_database_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DescribeTableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DescribeTableRequest) ProtoMessage() {}
+
+func (x *DescribeTableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DescribeTableRequest.ProtoReflect.Descriptor instead.
+func (*DescribeTableRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DescribeTableRequest) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *DescribeTableRequest) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+func (x *DescribeTableRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+// テーブルまたはビューの概要
+type TableInfo struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Schema string                 `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	Name   string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	IsView bool                   `protobuf:"varint,3,opt,name=is_view,json=isView,proto3" json:"is_view,omitempty"`
+	// 統計情報による推定行数（不明な場合は-1）
+	RowEstimate   int64 `protobuf:"varint,4,opt,name=row_estimate,json=rowEstimate,proto3" json:"row_estimate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TableInfo) Reset() {
+	*x = TableInfo{}
+	mi := &file_database_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TableInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableInfo) ProtoMessage() {}
+
+func (x *TableInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableInfo.ProtoReflect.Descriptor instead.
+func (*TableInfo) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TableInfo) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *TableInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TableInfo) GetIsView() bool {
+	if x != nil {
+		return x.IsView
+	}
+	return false
+}
+
+func (x *TableInfo) GetRowEstimate() int64 {
+	if x != nil {
+		return x.RowEstimate
+	}
+	return 0
+}
+
+// 列定義
+type ColumnInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 列の位置（1から）
+	Ordinal int32 `protobuf:"varint,2,opt,name=ordinal,proto3" json:"ordinal,omitempty"`
+	// 型名（例: "varchar"）
+	DataType string `protobuf:"bytes,3,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"`
+	// 長さ・精度つきの型（例: "varchar(50)", "decimal(10,2)"）
+	ColumnType string `protobuf:"bytes,4,opt,name=column_type,json=columnType,proto3" json:"column_type,omitempty"`
+	Nullable   bool   `protobuf:"varint,5,opt,name=nullable,proto3" json:"nullable,omitempty"`
+	// デフォルト値の式（未設定の場合はなし）
+	DefaultValue *string `protobuf:"bytes,6,opt,name=default_value,json=defaultValue,proto3,oneof" json:"default_value,omitempty"`
+	Collation    string  `protobuf:"bytes,7,opt,name=collation,proto3" json:"collation,omitempty"`
+	// 文字列・バイナリ型の最大長（-1はmax、それ以外の型は0）
+	MaxLength int64 `protobuf:"varint,8,opt,name=max_length,json=maxLength,proto3" json:"max_length,omitempty"`
+	Precision int32 `protobuf:"varint,9,opt,name=precision,proto3" json:"precision,omitempty"`
+	Scale     int32 `protobuf:"varint,10,opt,name=scale,proto3" json:"scale,omitempty"`
+	// IDENTITY / AUTO_INCREMENT列の場合true
+	AutoIncrement bool `protobuf:"varint,11,opt,name=auto_increment,json=autoIncrement,proto3" json:"auto_increment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColumnInfo) Reset() {
+	*x = ColumnInfo{}
+	mi := &file_database_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColumnInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColumnInfo) ProtoMessage() {}
+
+func (x *ColumnInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColumnInfo.ProtoReflect.Descriptor instead.
+func (*ColumnInfo) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ColumnInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ColumnInfo) GetOrdinal() int32 {
+	if x != nil {
+		return x.Ordinal
+	}
+	return 0
+}
+
+func (x *ColumnInfo) GetDataType() string {
+	if x != nil {
+		return x.DataType
+	}
+	return ""
+}
+
+func (x *ColumnInfo) GetColumnType() string {
+	if x != nil {
+		return x.ColumnType
+	}
+	return ""
+}
+
+func (x *ColumnInfo) GetNullable() bool {
+	if x != nil {
+		return x.Nullable
+	}
+	return false
+}
+
+func (x *ColumnInfo) GetDefaultValue() string {
+	if x != nil && x.DefaultValue != nil {
+		return *x.DefaultValue
+	}
+	return ""
+}
+
+func (x *ColumnInfo) GetCollation() string {
+	if x != nil {
+		return x.Collation
+	}
+	return ""
+}
+
+func (x *ColumnInfo) GetMaxLength() int64 {
+	if x != nil {
+		return x.MaxLength
+	}
+	return 0
+}
+
+func (x *ColumnInfo) GetPrecision() int32 {
+	if x != nil {
+		return x.Precision
+	}
+	return 0
+}
+
+func (x *ColumnInfo) GetScale() int32 {
+	if x != nil {
+		return x.Scale
+	}
+	return 0
+}
+
+func (x *ColumnInfo) GetAutoIncrement() bool {
+	if x != nil {
+		return x.AutoIncrement
+	}
+	return false
+}
+
+// インデックス定義
+type IndexInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// キー列（インデックスの順）
+	Columns       []string `protobuf:"bytes,2,rep,name=columns,proto3" json:"columns,omitempty"`
+	Unique        bool     `protobuf:"varint,3,opt,name=unique,proto3" json:"unique,omitempty"`
+	Primary       bool     `protobuf:"varint,4,opt,name=primary,proto3" json:"primary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IndexInfo) Reset() {
+	*x = IndexInfo{}
+	mi := &file_database_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndexInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexInfo) ProtoMessage() {}
+
+func (x *IndexInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexInfo.ProtoReflect.Descriptor instead.
+func (*IndexInfo) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *IndexInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *IndexInfo) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *IndexInfo) GetUnique() bool {
+	if x != nil {
+		return x.Unique
+	}
+	return false
+}
+
+func (x *IndexInfo) GetPrimary() bool {
+	if x != nil {
+		return x.Primary
+	}
+	return false
+}
+
+// 外部キー定義
+type ForeignKeyInfo struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Columns           []string               `protobuf:"bytes,2,rep,name=columns,proto3" json:"columns,omitempty"`
+	ReferencedSchema  string                 `protobuf:"bytes,3,opt,name=referenced_schema,json=referencedSchema,proto3" json:"referenced_schema,omitempty"`
+	ReferencedTable   string                 `protobuf:"bytes,4,opt,name=referenced_table,json=referencedTable,proto3" json:"referenced_table,omitempty"`
+	ReferencedColumns []string               `protobuf:"bytes,5,rep,name=referenced_columns,json=referencedColumns,proto3" json:"referenced_columns,omitempty"`
+	// 参照動作（"NO ACTION", "CASCADE", "SET NULL", "SET DEFAULT", "RESTRICT"）
+	OnDelete      string `protobuf:"bytes,6,opt,name=on_delete,json=onDelete,proto3" json:"on_delete,omitempty"`
+	OnUpdate      string `protobuf:"bytes,7,opt,name=on_update,json=onUpdate,proto3" json:"on_update,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForeignKeyInfo) Reset() {
+	*x = ForeignKeyInfo{}
+	mi := &file_database_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForeignKeyInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForeignKeyInfo) ProtoMessage() {}
+
+func (x *ForeignKeyInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForeignKeyInfo.ProtoReflect.Descriptor instead.
+func (*ForeignKeyInfo) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ForeignKeyInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ForeignKeyInfo) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *ForeignKeyInfo) GetReferencedSchema() string {
+	if x != nil {
+		return x.ReferencedSchema
+	}
+	return ""
+}
+
+func (x *ForeignKeyInfo) GetReferencedTable() string {
+	if x != nil {
+		return x.ReferencedTable
+	}
+	return ""
+}
+
+func (x *ForeignKeyInfo) GetReferencedColumns() []string {
+	if x != nil {
+		return x.ReferencedColumns
+	}
+	return nil
+}
+
+func (x *ForeignKeyInfo) GetOnDelete() string {
+	if x != nil {
+		return x.OnDelete
+	}
+	return ""
+}
+
+func (x *ForeignKeyInfo) GetOnUpdate() string {
+	if x != nil {
+		return x.OnUpdate
+	}
+	return ""
+}
+
+// テーブル定義
+type TableSchema struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Table   *TableInfo             `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	Columns []*ColumnInfo          `protobuf:"bytes,2,rep,name=columns,proto3" json:"columns,omitempty"`
+	// 主キー列（キーの順）
+	PrimaryKey  []string          `protobuf:"bytes,3,rep,name=primary_key,json=primaryKey,proto3" json:"primary_key,omitempty"`
+	Indexes     []*IndexInfo      `protobuf:"bytes,4,rep,name=indexes,proto3" json:"indexes,omitempty"`
+	ForeignKeys []*ForeignKeyInfo `protobuf:"bytes,5,rep,name=foreign_keys,json=foreignKeys,proto3" json:"foreign_keys,omitempty"`
+	// ビューの定義（ビューの場合のみ）
+	ViewDefinition string `protobuf:"bytes,6,opt,name=view_definition,json=viewDefinition,proto3" json:"view_definition,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TableSchema) Reset() {
+	*x = TableSchema{}
+	mi := &file_database_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TableSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableSchema) ProtoMessage() {}
+
+func (x *TableSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableSchema.ProtoReflect.Descriptor instead.
+func (*TableSchema) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TableSchema) GetTable() *TableInfo {
+	if x != nil {
+		return x.Table
+	}
+	return nil
+}
+
+func (x *TableSchema) GetColumns() []*ColumnInfo {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *TableSchema) GetPrimaryKey() []string {
+	if x != nil {
+		return x.PrimaryKey
+	}
+	return nil
+}
+
+func (x *TableSchema) GetIndexes() []*IndexInfo {
+	if x != nil {
+		return x.Indexes
+	}
+	return nil
+}
+
+func (x *TableSchema) GetForeignKeys() []*ForeignKeyInfo {
+	if x != nil {
+		return x.ForeignKeys
+	}
+	return nil
+}
+
+func (x *TableSchema) GetViewDefinition() string {
+	if x != nil {
+		return x.ViewDefinition
+	}
+	return ""
+}
+
+// エクスポートリクエスト（HTTP POST /export/query の本文、JSON）
+type ExportQueryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// SELECTなど読み取りのみのクエリ
+	Sql    string      `protobuf:"bytes,1,opt,name=sql,proto3" json:"sql,omitempty"`
+	Params []*SqlValue `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty"`
+	// "csv"（デフォルト）, "jsonl", "xlsx"
+	Format string `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`
+	// CSVの文字コード: "utf-8-bom"（デフォルト）, "utf-8", "shift_jis"
+	Encoding string `protobuf:"bytes,4,opt,name=encoding,proto3" json:"encoding,omitempty"`
+	// 最大行数（0の場合は無制限）
+	MaxRows int64 `protobuf:"varint,5,opt,name=max_rows,json=maxRows,proto3" json:"max_rows,omitempty"`
+	// ダウンロードするファイル名（拡張子なし、デフォルト "export"）
+	Filename string `protobuf:"bytes,6,opt,name=filename,proto3" json:"filename,omitempty"`
+	// 進捗を通知するジョブID（空の場合はサーバーが採番し、X-Job-Idヘッダーで返す）
+	JobId string `protobuf:"bytes,7,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// 接続プロファイル名（空の場合は選択中のプロファイル）
+	Profile string `protobuf:"bytes,8,opt,name=profile,proto3" json:"profile,omitempty"`
+	// 実行時間の上限（秒、0の場合はプロファイルのクエリタイムアウト。プロファイルの上限は超えられない）
+	TimeoutSeconds int32 `protobuf:"varint,9,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExportQueryRequest) Reset() {
+	*x = ExportQueryRequest{}
+	mi := &file_database_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportQueryRequest) ProtoMessage() {}
+
+func (x *ExportQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportQueryRequest.ProtoReflect.Descriptor instead.
+func (*ExportQueryRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ExportQueryRequest) GetSql() string {
+	if x != nil {
+		return x.Sql
+	}
+	return ""
+}
+
+func (x *ExportQueryRequest) GetParams() []*SqlValue {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *ExportQueryRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportQueryRequest) GetEncoding() string {
+	if x != nil {
+		return x.Encoding
+	}
+	return ""
+}
+
+func (x *ExportQueryRequest) GetMaxRows() int64 {
+	if x != nil {
+		return x.MaxRows
+	}
+	return 0
+}
+
+func (x *ExportQueryRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ExportQueryRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *ExportQueryRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *ExportQueryRequest) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+// 接続プロファイル
+type DatabaseProfile struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// プロファイル名（"default"はDB_*環境変数の接続で予約済み）
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// "sqlserver" または "mysql"
+	Driver string `protobuf:"bytes,2,opt,name=driver,proto3" json:"driver,omitempty"`
+	// ホスト名（SQL Serverの名前付きインスタンスは "host\instance"）
+	Host string `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+	// ポート（0の場合は既定: SQL Server 1433, MySQL 3306）
+	Port     int32  `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	Database string `protobuf:"bytes,5,opt,name=database,proto3" json:"database,omitempty"`
+	User     string `protobuf:"bytes,6,opt,name=user,proto3" json:"user,omitempty"`
+	// パスワード（応答では常に空。保存時に空の場合は既存のパスワードを維持。ただしdriver, host, port, user, paramsのいずれかを変更する場合は必須）
+	Password string `protobuf:"bytes,7,opt,name=password,proto3" json:"password,omitempty"`
+	// パスワードが設定されている場合true（応答のみ）
+	HasPassword bool `protobuf:"varint,8,opt,name=has_password,json=hasPassword,proto3" json:"has_password,omitempty"`
+	// 追加の接続パラメータ（例: "encrypt" => "disable", "charset" => "utf8mb4"）。SQL Serverではserver, port, user id, passwordなど接続先や認証情報を変えるキーは指定できない
+	Params map[string]string `protobuf:"bytes,9,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 読み取り専用（SELECTなどのみ許可）
+	ReadOnly bool `protobuf:"varint,10,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	// DDL（CREATE/ALTER/DROP/TRUNCATE）を許可
+	AllowDdl bool `protobuf:"varint,11,opt,name=allow_ddl,json=allowDdl,proto3" json:"allow_ddl,omitempty"`
+	// EXECなど分類できない文を許可
+	AllowOther bool `protobuf:"varint,12,opt,name=allow_other,json=allowOther,proto3" json:"allow_other,omitempty"`
+	// 1回の呼び出しで複数の文を許可
+	AllowMultiStatements bool `protobuf:"varint,13,opt,name=allow_multi_statements,json=allowMultiStatements,proto3" json:"allow_multi_statements,omitempty"`
+	// 選択中のプロファイルの場合true（応答のみ）
+	Selected bool `protobuf:"varint,14,opt,name=selected,proto3" json:"selected,omitempty"`
+	// DB_*環境変数から作られたプロファイルの場合true（変更・削除不可、応答のみ）
+	FromEnv bool `protobuf:"varint,15,opt,name=from_env,json=fromEnv,proto3" json:"from_env,omitempty"`
+	// 接続プールの設定（0の場合はDB_MAX_OPEN_CONNSなどの環境変数、未設定なら既定値）
+	MaxOpenConns int32 `protobuf:"varint,16,opt,name=max_open_conns,json=maxOpenConns,proto3" json:"max_open_conns,omitempty"`
+	MaxIdleConns int32 `protobuf:"varint,17,opt,name=max_idle_conns,json=maxIdleConns,proto3" json:"max_idle_conns,omitempty"`
+	// 接続を使い続ける最大時間（秒）
+	ConnMaxLifetimeSeconds int32 `protobuf:"varint,18,opt,name=conn_max_lifetime_seconds,json=connMaxLifetimeSeconds,proto3" json:"conn_max_lifetime_seconds,omitempty"`
+	// アイドル接続を閉じるまでの時間（秒）
+	ConnMaxIdleTimeSeconds int32 `protobuf:"varint,19,opt,name=conn_max_idle_time_seconds,json=connMaxIdleTimeSeconds,proto3" json:"conn_max_idle_time_seconds,omitempty"`
+	// 接続タイムアウト（秒、0の場合はDB_CONNECT_TIMEOUT、既定10秒）
+	ConnectTimeoutSeconds int32 `protobuf:"varint,20,opt,name=connect_timeout_seconds,json=connectTimeoutSeconds,proto3" json:"connect_timeout_seconds,omitempty"`
+	// クエリタイムアウト（秒、0の場合はDB_QUERY_TIMEOUT、既定は無制限）
+	QueryTimeoutSeconds int32 `protobuf:"varint,21,opt,name=query_timeout_seconds,json=queryTimeoutSeconds,proto3" json:"query_timeout_seconds,omitempty"`
+	// 1回のクエリで返す最大行数（0の場合はDB_MAX_ROWS、既定は無制限）。QueryDatabaseはさらに10000行まで
+	MaxRows       int64 `protobuf:"varint,22,opt,name=max_rows,json=maxRows,proto3" json:"max_rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DatabaseProfile) Reset() {
+	*x = DatabaseProfile{}
+	mi := &file_database_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DatabaseProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatabaseProfile) ProtoMessage() {}
+
+func (x *DatabaseProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatabaseProfile.ProtoReflect.Descriptor instead.
+func (*DatabaseProfile) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DatabaseProfile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DatabaseProfile) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *DatabaseProfile) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *DatabaseProfile) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *DatabaseProfile) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+func (x *DatabaseProfile) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *DatabaseProfile) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *DatabaseProfile) GetHasPassword() bool {
+	if x != nil {
+		return x.HasPassword
+	}
+	return false
+}
+
+func (x *DatabaseProfile) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *DatabaseProfile) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+func (x *DatabaseProfile) GetAllowDdl() bool {
+	if x != nil {
+		return x.AllowDdl
+	}
+	return false
+}
+
+func (x *DatabaseProfile) GetAllowOther() bool {
+	if x != nil {
+		return x.AllowOther
+	}
+	return false
+}
+
+func (x *DatabaseProfile) GetAllowMultiStatements() bool {
+	if x != nil {
+		return x.AllowMultiStatements
+	}
+	return false
+}
+
+func (x *DatabaseProfile) GetSelected() bool {
+	if x != nil {
+		return x.Selected
+	}
+	return false
+}
+
+func (x *DatabaseProfile) GetFromEnv() bool {
+	if x != nil {
+		return x.FromEnv
+	}
+	return false
+}
+
+func (x *DatabaseProfile) GetMaxOpenConns() int32 {
+	if x != nil {
+		return x.MaxOpenConns
+	}
+	return 0
+}
+
+func (x *DatabaseProfile) GetMaxIdleConns() int32 {
+	if x != nil {
+		return x.MaxIdleConns
+	}
+	return 0
+}
+
+func (x *DatabaseProfile) GetConnMaxLifetimeSeconds() int32 {
+	if x != nil {
+		return x.ConnMaxLifetimeSeconds
+	}
+	return 0
+}
+
+func (x *DatabaseProfile) GetConnMaxIdleTimeSeconds() int32 {
+	if x != nil {
+		return x.ConnMaxIdleTimeSeconds
+	}
+	return 0
+}
+
+func (x *DatabaseProfile) GetConnectTimeoutSeconds() int32 {
+	if x != nil {
+		return x.ConnectTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *DatabaseProfile) GetQueryTimeoutSeconds() int32 {
+	if x != nil {
+		return x.QueryTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *DatabaseProfile) GetMaxRows() int64 {
+	if x != nil {
+		return x.MaxRows
+	}
+	return 0
+}
+
+// プロファイル一覧リクエスト
+type ListProfilesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProfilesRequest) Reset() {
+	*x = ListProfilesRequest{}
+	mi := &file_database_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProfilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProfilesRequest) ProtoMessage() {}
+
+func (x *ListProfilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProfilesRequest.ProtoReflect.Descriptor instead.
+func (*ListProfilesRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{24}
+}
+
+// プロファイル一覧レスポンス（保存ファイルの形式も兼ねる）
+type ListProfilesResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Profiles []*DatabaseProfile     `protobuf:"bytes,1,rep,name=profiles,proto3" json:"profiles,omitempty"`
+	// 選択中のプロファイル名
+	Selected      string `protobuf:"bytes,2,opt,name=selected,proto3" json:"selected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProfilesResponse) Reset() {
+	*x = ListProfilesResponse{}
+	mi := &file_database_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProfilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProfilesResponse) ProtoMessage() {}
+
+func (x *ListProfilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProfilesResponse.ProtoReflect.Descriptor instead.
+func (*ListProfilesResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListProfilesResponse) GetProfiles() []*DatabaseProfile {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
+func (x *ListProfilesResponse) GetSelected() string {
+	if x != nil {
+		return x.Selected
+	}
+	return ""
+}
+
+// プロファイル保存リクエスト
+type SaveProfileRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Profile *DatabaseProfile       `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	// trueの場合パスワードを削除（profile.passwordが空の場合のみ）
+	ClearPassword bool `protobuf:"varint,2,opt,name=clear_password,json=clearPassword,proto3" json:"clear_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveProfileRequest) Reset() {
+	*x = SaveProfileRequest{}
+	mi := &file_database_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveProfileRequest) ProtoMessage() {}
+
+func (x *SaveProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveProfileRequest.ProtoReflect.Descriptor instead.
+func (*SaveProfileRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SaveProfileRequest) GetProfile() *DatabaseProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *SaveProfileRequest) GetClearPassword() bool {
+	if x != nil {
+		return x.ClearPassword
+	}
+	return false
+}
+
+// プロファイル削除リクエスト
+type RemoveProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveProfileRequest) Reset() {
+	*x = RemoveProfileRequest{}
+	mi := &file_database_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveProfileRequest) ProtoMessage() {}
+
+func (x *RemoveProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveProfileRequest.ProtoReflect.Descriptor instead.
+func (*RemoveProfileRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RemoveProfileRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// プロファイル削除レスポンス
+type RemoveProfileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveProfileResponse) Reset() {
+	*x = RemoveProfileResponse{}
+	mi := &file_database_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveProfileResponse) ProtoMessage() {}
+
+func (x *RemoveProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveProfileResponse.ProtoReflect.Descriptor instead.
+func (*RemoveProfileResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{28}
+}
+
+// 接続テストリクエスト（profileまたはnameのどちらかを指定）
+type TestProfileRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 保存前のプロファイル（パスワードが空で同名の保存済みプロファイルとdriver, host, port, user, paramsが同じ場合はそのパスワードを使う）
+	Profile *DatabaseProfile `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	// 保存済みプロファイル名
+	Name          string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestProfileRequest) Reset() {
+	*x = TestProfileRequest{}
+	mi := &file_database_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestProfileRequest) ProtoMessage() {}
+
+func (x *TestProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestProfileRequest.ProtoReflect.Descriptor instead.
+func (*TestProfileRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TestProfileRequest) GetProfile() *DatabaseProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *TestProfileRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// 接続テストレスポンス
+type TestProfileResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Ok    bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	// 失敗した場合のエラー
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// サーバーのバージョン
+	ServerVersion string `protobuf:"bytes,3,opt,name=server_version,json=serverVersion,proto3" json:"server_version,omitempty"`
+	// 接続とバージョン取得にかかった時間（ミリ秒）
+	LatencyMs     int64 `protobuf:"varint,4,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestProfileResponse) Reset() {
+	*x = TestProfileResponse{}
+	mi := &file_database_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestProfileResponse) ProtoMessage() {}
+
+func (x *TestProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestProfileResponse.ProtoReflect.Descriptor instead.
+func (*TestProfileResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TestProfileResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *TestProfileResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TestProfileResponse) GetServerVersion() string {
+	if x != nil {
+		return x.ServerVersion
+	}
+	return ""
+}
+
+func (x *TestProfileResponse) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+// プロファイル選択リクエスト
+type SelectProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelectProfileRequest) Reset() {
+	*x = SelectProfileRequest{}
+	mi := &file_database_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelectProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectProfileRequest) ProtoMessage() {}
+
+func (x *SelectProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectProfileRequest.ProtoReflect.Descriptor instead.
+func (*SelectProfileRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SelectProfileRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// 接続プール状態リクエスト
+type GetPoolStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPoolStatsRequest) Reset() {
+	*x = GetPoolStatsRequest{}
+	mi := &file_database_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPoolStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPoolStatsRequest) ProtoMessage() {}
+
+func (x *GetPoolStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPoolStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetPoolStatsRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{32}
+}
+
+// 接続プール状態レスポンス
+type GetPoolStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pools         []*PoolStats           `protobuf:"bytes,1,rep,name=pools,proto3" json:"pools,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPoolStatsResponse) Reset() {
+	*x = GetPoolStatsResponse{}
+	mi := &file_database_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPoolStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPoolStatsResponse) ProtoMessage() {}
+
+func (x *GetPoolStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPoolStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetPoolStatsResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetPoolStatsResponse) GetPools() []*PoolStats {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
+}
+
+// 接続プールの設定と状態
+type PoolStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// プロファイル名
+	Profile string `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	Driver  string `protobuf:"bytes,2,opt,name=driver,proto3" json:"driver,omitempty"`
+	// 設定（0は無制限）
+	MaxOpenConns           int32 `protobuf:"varint,3,opt,name=max_open_conns,json=maxOpenConns,proto3" json:"max_open_conns,omitempty"`
+	MaxIdleConns           int32 `protobuf:"varint,4,opt,name=max_idle_conns,json=maxIdleConns,proto3" json:"max_idle_conns,omitempty"`
+	ConnMaxLifetimeSeconds int64 `protobuf:"varint,5,opt,name=conn_max_lifetime_seconds,json=connMaxLifetimeSeconds,proto3" json:"conn_max_lifetime_seconds,omitempty"`
+	ConnMaxIdleTimeSeconds int64 `protobuf:"varint,6,opt,name=conn_max_idle_time_seconds,json=connMaxIdleTimeSeconds,proto3" json:"conn_max_idle_time_seconds,omitempty"`
+	QueryTimeoutSeconds    int64 `protobuf:"varint,7,opt,name=query_timeout_seconds,json=queryTimeoutSeconds,proto3" json:"query_timeout_seconds,omitempty"`
+	// 開いている接続数（使用中 + アイドル）
+	OpenConnections int32 `protobuf:"varint,8,opt,name=open_connections,json=openConnections,proto3" json:"open_connections,omitempty"`
+	// 使用中の接続数
+	InUse int32 `protobuf:"varint,9,opt,name=in_use,json=inUse,proto3" json:"in_use,omitempty"`
+	// アイドルの接続数
+	Idle int32 `protobuf:"varint,10,opt,name=idle,proto3" json:"idle,omitempty"`
+	// 空き接続を待った回数と合計時間（ミリ秒）。増え続ける場合はmax_open_connsが不足している
+	WaitCount      int64 `protobuf:"varint,11,opt,name=wait_count,json=waitCount,proto3" json:"wait_count,omitempty"`
+	WaitDurationMs int64 `protobuf:"varint,12,opt,name=wait_duration_ms,json=waitDurationMs,proto3" json:"wait_duration_ms,omitempty"`
+	// max_idle_conns、conn_max_idle_time、conn_max_lifetimeにより閉じた接続数
+	MaxIdleClosed     int64 `protobuf:"varint,13,opt,name=max_idle_closed,json=maxIdleClosed,proto3" json:"max_idle_closed,omitempty"`
+	MaxIdleTimeClosed int64 `protobuf:"varint,14,opt,name=max_idle_time_closed,json=maxIdleTimeClosed,proto3" json:"max_idle_time_closed,omitempty"`
+	MaxLifetimeClosed int64 `protobuf:"varint,15,opt,name=max_lifetime_closed,json=maxLifetimeClosed,proto3" json:"max_lifetime_closed,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PoolStats) Reset() {
+	*x = PoolStats{}
+	mi := &file_database_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolStats) ProtoMessage() {}
+
+func (x *PoolStats) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolStats.ProtoReflect.Descriptor instead.
+func (*PoolStats) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PoolStats) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *PoolStats) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *PoolStats) GetMaxOpenConns() int32 {
+	if x != nil {
+		return x.MaxOpenConns
+	}
+	return 0
+}
+
+func (x *PoolStats) GetMaxIdleConns() int32 {
+	if x != nil {
+		return x.MaxIdleConns
+	}
+	return 0
+}
+
+func (x *PoolStats) GetConnMaxLifetimeSeconds() int64 {
+	if x != nil {
+		return x.ConnMaxLifetimeSeconds
+	}
+	return 0
+}
+
+func (x *PoolStats) GetConnMaxIdleTimeSeconds() int64 {
+	if x != nil {
+		return x.ConnMaxIdleTimeSeconds
+	}
+	return 0
+}
+
+func (x *PoolStats) GetQueryTimeoutSeconds() int64 {
+	if x != nil {
+		return x.QueryTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *PoolStats) GetOpenConnections() int32 {
+	if x != nil {
+		return x.OpenConnections
+	}
+	return 0
+}
+
+func (x *PoolStats) GetInUse() int32 {
+	if x != nil {
+		return x.InUse
+	}
+	return 0
+}
+
+func (x *PoolStats) GetIdle() int32 {
+	if x != nil {
+		return x.Idle
+	}
+	return 0
+}
+
+func (x *PoolStats) GetWaitCount() int64 {
+	if x != nil {
+		return x.WaitCount
+	}
+	return 0
+}
+
+func (x *PoolStats) GetWaitDurationMs() int64 {
+	if x != nil {
+		return x.WaitDurationMs
+	}
+	return 0
+}
+
+func (x *PoolStats) GetMaxIdleClosed() int64 {
+	if x != nil {
+		return x.MaxIdleClosed
+	}
+	return 0
+}
+
+func (x *PoolStats) GetMaxIdleTimeClosed() int64 {
+	if x != nil {
+		return x.MaxIdleTimeClosed
+	}
+	return 0
+}
+
+func (x *PoolStats) GetMaxLifetimeClosed() int64 {
+	if x != nil {
+		return x.MaxLifetimeClosed
+	}
+	return 0
+}
+
+// 保存済みクエリ
+type SavedQuery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// クエリ名（一意）
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// SQL（パラメータはSQL Serverでは @p1, @p2...、MySQLでは ? でparamsの順に参照する）
+	Sql    string             `protobuf:"bytes,3,opt,name=sql,proto3" json:"sql,omitempty"`
+	Params []*SavedQueryParam `protobuf:"bytes,4,rep,name=params,proto3" json:"params,omitempty"`
+	// 実行に使う接続プロファイル（空の場合は選択中のプロファイル）
+	Profile string   `protobuf:"bytes,5,opt,name=profile,proto3" json:"profile,omitempty"`
+	Tags    []string `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	// 作成・更新時刻（Unix秒、応答のみ）
+	CreatedAt     int64 `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64 `protobuf:"varint,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SavedQuery) Reset() {
+	*x = SavedQuery{}
+	mi := &file_database_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavedQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedQuery) ProtoMessage() {}
+
+func (x *SavedQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedQuery.ProtoReflect.Descriptor instead.
+func (*SavedQuery) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SavedQuery) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SavedQuery) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SavedQuery) GetSql() string {
+	if x != nil {
+		return x.Sql
+	}
+	return ""
+}
+
+func (x *SavedQuery) GetParams() []*SavedQueryParam {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *SavedQuery) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *SavedQuery) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SavedQuery) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *SavedQuery) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// 保存済みクエリのパラメータ
+type SavedQueryParam struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// "string"（空の場合も）, "int", "double", "bool", "time"
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// 省略時の値（文字列表現、timeは "2006-01-02" または "2006-01-02 15:04:05"）。未設定の場合は必須
+	DefaultValue  *string `protobuf:"bytes,3,opt,name=default_value,json=defaultValue,proto3,oneof" json:"default_value,omitempty"`
+	Description   string  `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SavedQueryParam) Reset() {
+	*x = SavedQueryParam{}
+	mi := &file_database_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavedQueryParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedQueryParam) ProtoMessage() {}
+
+func (x *SavedQueryParam) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedQueryParam.ProtoReflect.Descriptor instead.
+func (*SavedQueryParam) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SavedQueryParam) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SavedQueryParam) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SavedQueryParam) GetDefaultValue() string {
+	if x != nil && x.DefaultValue != nil {
+		return *x.DefaultValue
+	}
+	return ""
+}
+
+func (x *SavedQueryParam) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// 保存済みクエリ一覧リクエスト
+type ListSavedQueriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 名前、説明、SQL、タグに含まれる文字列（大文字小文字を区別しない）
+	Search string `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"`
+	// タグ（指定した場合はそのタグを持つクエリのみ）
+	Tag           string `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavedQueriesRequest) Reset() {
+	*x = ListSavedQueriesRequest{}
+	mi := &file_database_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedQueriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedQueriesRequest) ProtoMessage() {}
+
+func (x *ListSavedQueriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedQueriesRequest.ProtoReflect.Descriptor instead.
+func (*ListSavedQueriesRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListSavedQueriesRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListSavedQueriesRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+// 保存済みクエリ一覧レスポンス（保存ファイル・共有ファイルの形式も兼ねる）
+type ListSavedQueriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Queries       []*SavedQuery          `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavedQueriesResponse) Reset() {
+	*x = ListSavedQueriesResponse{}
+	mi := &file_database_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedQueriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedQueriesResponse) ProtoMessage() {}
+
+func (x *ListSavedQueriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedQueriesResponse.ProtoReflect.Descriptor instead.
+func (*ListSavedQueriesResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListSavedQueriesResponse) GetQueries() []*SavedQuery {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+// クエリ保存リクエスト
+type SaveQueryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         *SavedQuery            `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveQueryRequest) Reset() {
+	*x = SaveQueryRequest{}
+	mi := &file_database_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveQueryRequest) ProtoMessage() {}
+
+func (x *SaveQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveQueryRequest.ProtoReflect.Descriptor instead.
+func (*SaveQueryRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SaveQueryRequest) GetQuery() *SavedQuery {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+// 保存済みクエリ削除リクエスト
+type DeleteSavedQueryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSavedQueryRequest) Reset() {
+	*x = DeleteSavedQueryRequest{}
+	mi := &file_database_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSavedQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSavedQueryRequest) ProtoMessage() {}
+
+func (x *DeleteSavedQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSavedQueryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSavedQueryRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DeleteSavedQueryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// 保存済みクエリ削除レスポンス
+type DeleteSavedQueryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSavedQueryResponse) Reset() {
+	*x = DeleteSavedQueryResponse{}
+	mi := &file_database_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSavedQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSavedQueryResponse) ProtoMessage() {}
+
+func (x *DeleteSavedQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSavedQueryResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSavedQueryResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{41}
+}
+
+// 保存済みクエリ実行リクエスト
+type RunSavedQueryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// パラメータ名 => 値（省略したパラメータは既定値）
+	Params map[string]*SqlValue `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 接続プロファイル（空の場合は保存済みクエリのプロファイル）
+	Profile string `protobuf:"bytes,3,opt,name=profile,proto3" json:"profile,omitempty"`
+	// 読み取りクエリの最大行数（0の場合は1000、上限10000）
+	MaxRows int32 `protobuf:"varint,4,opt,name=max_rows,json=maxRows,proto3" json:"max_rows,omitempty"`
+	// 実行時間の上限（秒、0の場合はプロファイルのクエリタイムアウト）
+	TimeoutSeconds int32 `protobuf:"varint,5,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	// trueの場合、結果をheaderとtyped_rows（型付きのセル）で返し、rowsは空にする
+	Typed         bool `protobuf:"varint,6,opt,name=typed,proto3" json:"typed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunSavedQueryRequest) Reset() {
+	*x = RunSavedQueryRequest{}
+	mi := &file_database_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunSavedQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunSavedQueryRequest) ProtoMessage() {}
+
+func (x *RunSavedQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunSavedQueryRequest.ProtoReflect.Descriptor instead.
+func (*RunSavedQueryRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RunSavedQueryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RunSavedQueryRequest) GetParams() map[string]*SqlValue {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *RunSavedQueryRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *RunSavedQueryRequest) GetMaxRows() int32 {
+	if x != nil {
+		return x.MaxRows
+	}
+	return 0
+}
+
+func (x *RunSavedQueryRequest) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *RunSavedQueryRequest) GetTyped() bool {
+	if x != nil {
+		return x.Typed
+	}
+	return false
+}
+
+// 保存済みクエリ実行レスポンス（読み取りクエリはresult、それ以外はexecute）
+type RunSavedQueryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *QueryResponse         `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	Execute       *ExecuteResponse       `protobuf:"bytes,2,opt,name=execute,proto3" json:"execute,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunSavedQueryResponse) Reset() {
+	*x = RunSavedQueryResponse{}
+	mi := &file_database_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunSavedQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunSavedQueryResponse) ProtoMessage() {}
+
+func (x *RunSavedQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunSavedQueryResponse.ProtoReflect.Descriptor instead.
+func (*RunSavedQueryResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *RunSavedQueryResponse) GetResult() *QueryResponse {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *RunSavedQueryResponse) GetExecute() *ExecuteResponse {
+	if x != nil {
+		return x.Execute
+	}
+	return nil
+}
+
+// 保存済みクエリ出力リクエスト
+type ExportSavedQueriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 出力するクエリ名（空の場合はすべて）
+	Names         []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportSavedQueriesRequest) Reset() {
+	*x = ExportSavedQueriesRequest{}
+	mi := &file_database_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportSavedQueriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSavedQueriesRequest) ProtoMessage() {}
+
+func (x *ExportSavedQueriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSavedQueriesRequest.ProtoReflect.Descriptor instead.
+func (*ExportSavedQueriesRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ExportSavedQueriesRequest) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+// 保存済みクエリ出力レスポンス
+type ExportSavedQueriesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ListSavedQueriesResponse形式のJSON
+	Data          []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Filename      string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Count         int32  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportSavedQueriesResponse) Reset() {
+	*x = ExportSavedQueriesResponse{}
+	mi := &file_database_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportSavedQueriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSavedQueriesResponse) ProtoMessage() {}
+
+func (x *ExportSavedQueriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSavedQueriesResponse.ProtoReflect.Descriptor instead.
+func (*ExportSavedQueriesResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ExportSavedQueriesResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExportSavedQueriesResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ExportSavedQueriesResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// 保存済みクエリ取り込みリクエスト
+type ImportSavedQueriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Data  []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// trueの場合は同名のクエリを置き換え、falseの場合はスキップ
+	Overwrite     bool `protobuf:"varint,2,opt,name=overwrite,proto3" json:"overwrite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportSavedQueriesRequest) Reset() {
+	*x = ImportSavedQueriesRequest{}
+	mi := &file_database_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportSavedQueriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportSavedQueriesRequest) ProtoMessage() {}
+
+func (x *ImportSavedQueriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportSavedQueriesRequest.ProtoReflect.Descriptor instead.
+func (*ImportSavedQueriesRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ImportSavedQueriesRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportSavedQueriesRequest) GetOverwrite() bool {
+	if x != nil {
+		return x.Overwrite
+	}
+	return false
+}
+
+// 保存済みクエリ取り込みレスポンス
+type ImportSavedQueriesResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Imported []string               `protobuf:"bytes,1,rep,name=imported,proto3" json:"imported,omitempty"`
+	// 同名のクエリがあるためスキップしたクエリ
+	Skipped       []string `protobuf:"bytes,2,rep,name=skipped,proto3" json:"skipped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportSavedQueriesResponse) Reset() {
+	*x = ImportSavedQueriesResponse{}
+	mi := &file_database_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportSavedQueriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportSavedQueriesResponse) ProtoMessage() {}
+
+func (x *ImportSavedQueriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportSavedQueriesResponse.ProtoReflect.Descriptor instead.
+func (*ImportSavedQueriesResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ImportSavedQueriesResponse) GetImported() []string {
+	if x != nil {
+		return x.Imported
+	}
+	return nil
+}
+
+func (x *ImportSavedQueriesResponse) GetSkipped() []string {
+	if x != nil {
+		return x.Skipped
+	}
+	return nil
+}
+
+// クエリ履歴
+type QueryHistoryEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// 接続プロファイル名
+	Profile string `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	Sql     string `protobuf:"bytes,3,opt,name=sql,proto3" json:"sql,omitempty"`
+	// "query", "stream", "execute", "export", "saved", "browse", "import"
+	Source string `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	// 保存済みクエリを実行した場合のクエリ名
+	SavedQuery string `protobuf:"bytes,5,opt,name=saved_query,json=savedQuery,proto3" json:"saved_query,omitempty"`
+	// 実行開始時刻（Unix秒）
+	StartedAt int64 `protobuf:"varint,6,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// 実行時間（ミリ秒）
+	DurationMs int64 `protobuf:"varint,7,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// 返した行数または更新された行数
+	Rows int64 `protobuf:"varint,8,opt,name=rows,proto3" json:"rows,omitempty"`
+	// 失敗した場合のエラー
+	Error         string `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryHistoryEntry) Reset() {
+	*x = QueryHistoryEntry{}
+	mi := &file_database_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryHistoryEntry) ProtoMessage() {}
+
+func (x *QueryHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryHistoryEntry.ProtoReflect.Descriptor instead.
+func (*QueryHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *QueryHistoryEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *QueryHistoryEntry) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *QueryHistoryEntry) GetSql() string {
+	if x != nil {
+		return x.Sql
+	}
+	return ""
+}
+
+func (x *QueryHistoryEntry) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *QueryHistoryEntry) GetSavedQuery() string {
+	if x != nil {
+		return x.SavedQuery
+	}
+	return ""
+}
+
+func (x *QueryHistoryEntry) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *QueryHistoryEntry) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *QueryHistoryEntry) GetRows() int64 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *QueryHistoryEntry) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// クエリ履歴リクエスト
+type ListQueryHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 接続プロファイル名（空の場合はすべて）
+	Profile string `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	// SQLまたはエラーに含まれる文字列（大文字小文字を区別しない）
+	Search string `protobuf:"bytes,2,opt,name=search,proto3" json:"search,omitempty"`
+	// trueの場合は失敗したクエリのみ
+	ErrorsOnly bool `protobuf:"varint,3,opt,name=errors_only,json=errorsOnly,proto3" json:"errors_only,omitempty"`
+	// 最大件数（0の場合は100）
+	Limit int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// 開始時刻の範囲（Unix秒、0の場合は指定なし）
+	StartedFrom   int64 `protobuf:"varint,5,opt,name=started_from,json=startedFrom,proto3" json:"started_from,omitempty"`
+	StartedTo     int64 `protobuf:"varint,6,opt,name=started_to,json=startedTo,proto3" json:"started_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQueryHistoryRequest) Reset() {
+	*x = ListQueryHistoryRequest{}
+	mi := &file_database_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQueryHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQueryHistoryRequest) ProtoMessage() {}
+
+func (x *ListQueryHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQueryHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListQueryHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListQueryHistoryRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *ListQueryHistoryRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListQueryHistoryRequest) GetErrorsOnly() bool {
+	if x != nil {
+		return x.ErrorsOnly
+	}
+	return false
+}
+
+func (x *ListQueryHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListQueryHistoryRequest) GetStartedFrom() int64 {
+	if x != nil {
+		return x.StartedFrom
+	}
+	return 0
+}
+
+func (x *ListQueryHistoryRequest) GetStartedTo() int64 {
+	if x != nil {
+		return x.StartedTo
+	}
+	return 0
+}
+
+// クエリ履歴レスポンス
+type ListQueryHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*QueryHistoryEntry   `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQueryHistoryResponse) Reset() {
+	*x = ListQueryHistoryResponse{}
+	mi := &file_database_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQueryHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQueryHistoryResponse) ProtoMessage() {}
+
+func (x *ListQueryHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQueryHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ListQueryHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListQueryHistoryResponse) GetEntries() []*QueryHistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// クエリ履歴削除リクエスト
+type ClearQueryHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 接続プロファイル名（空の場合はすべて）
+	Profile       string `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearQueryHistoryRequest) Reset() {
+	*x = ClearQueryHistoryRequest{}
+	mi := &file_database_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearQueryHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearQueryHistoryRequest) ProtoMessage() {}
+
+func (x *ClearQueryHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearQueryHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ClearQueryHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ClearQueryHistoryRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+// クエリ履歴削除レスポンス
+type ClearQueryHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 削除した件数
+	Removed       int32 `protobuf:"varint,1,opt,name=removed,proto3" json:"removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearQueryHistoryResponse) Reset() {
+	*x = ClearQueryHistoryResponse{}
+	mi := &file_database_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearQueryHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearQueryHistoryResponse) ProtoMessage() {}
+
+func (x *ClearQueryHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearQueryHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ClearQueryHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ClearQueryHistoryResponse) GetRemoved() int32 {
+	if x != nil {
+		return x.Removed
+	}
+	return 0
+}
+
+// 実行中のクエリ
+type RunningQuery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// KillQueryで指定するID（クエリ履歴のIDと同じ）
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// 接続プロファイル名
+	Profile string `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	Sql     string `protobuf:"bytes,3,opt,name=sql,proto3" json:"sql,omitempty"`
+	// "query", "stream", "execute", "export", "saved", "browse", "import"
+	Source string `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	// 保存済みクエリを実行している場合のクエリ名
+	SavedQuery string `protobuf:"bytes,5,opt,name=saved_query,json=savedQuery,proto3" json:"saved_query,omitempty"`
+	// 実行開始時刻（Unix秒）
+	StartedAt int64 `protobuf:"varint,6,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// 経過時間（ミリ秒）
+	ElapsedMs int64 `protobuf:"varint,7,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"`
+	// 実行時間の上限（Unix秒、0の場合は無制限）
+	Deadline      int64 `protobuf:"varint,8,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunningQuery) Reset() {
+	*x = RunningQuery{}
+	mi := &file_database_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunningQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunningQuery) ProtoMessage() {}
+
+func (x *RunningQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunningQuery.ProtoReflect.Descriptor instead.
+func (*RunningQuery) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *RunningQuery) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RunningQuery) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *RunningQuery) GetSql() string {
+	if x != nil {
+		return x.Sql
+	}
+	return ""
+}
+
+func (x *RunningQuery) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *RunningQuery) GetSavedQuery() string {
+	if x != nil {
+		return x.SavedQuery
+	}
+	return ""
+}
+
+func (x *RunningQuery) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *RunningQuery) GetElapsedMs() int64 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+func (x *RunningQuery) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+// 実行中クエリ一覧リクエスト
+type ListRunningQueriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 接続プロファイル名（空の場合はすべて）
+	Profile       string `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRunningQueriesRequest) Reset() {
+	*x = ListRunningQueriesRequest{}
+	mi := &file_database_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRunningQueriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRunningQueriesRequest) ProtoMessage() {}
+
+func (x *ListRunningQueriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRunningQueriesRequest.ProtoReflect.Descriptor instead.
+func (*ListRunningQueriesRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ListRunningQueriesRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+// 実行中クエリ一覧レスポンス（開始が古い順）
+type ListRunningQueriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Queries       []*RunningQuery        `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRunningQueriesResponse) Reset() {
+	*x = ListRunningQueriesResponse{}
+	mi := &file_database_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRunningQueriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRunningQueriesResponse) ProtoMessage() {}
+
+func (x *ListRunningQueriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRunningQueriesResponse.ProtoReflect.Descriptor instead.
+func (*ListRunningQueriesResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ListRunningQueriesResponse) GetQueries() []*RunningQuery {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+// クエリ中止リクエスト
+type KillQueryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KillQueryRequest) Reset() {
+	*x = KillQueryRequest{}
+	mi := &file_database_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KillQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KillQueryRequest) ProtoMessage() {}
+
+func (x *KillQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KillQueryRequest.ProtoReflect.Descriptor instead.
+func (*KillQueryRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *KillQueryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// クエリ中止レスポンス
+type KillQueryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KillQueryResponse) Reset() {
+	*x = KillQueryResponse{}
+	mi := &file_database_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KillQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KillQueryResponse) ProtoMessage() {}
+
+func (x *KillQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KillQueryResponse.ProtoReflect.Descriptor instead.
+func (*KillQueryResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{57}
+}
+
+// 列の絞り込み条件（複数指定した場合はAND）
+type BrowseFilter struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Column string                 `protobuf:"bytes,1,opt,name=column,proto3" json:"column,omitempty"`
+	Op     FilterOp               `protobuf:"varint,2,opt,name=op,proto3,enum=desktop_server.v1.FilterOp" json:"op,omitempty"`
+	Value  *SqlValue              `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	// FILTER_OP_INの値
+	Values        []*SqlValue `protobuf:"bytes,4,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BrowseFilter) Reset() {
+	*x = BrowseFilter{}
+	mi := &file_database_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BrowseFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrowseFilter) ProtoMessage() {}
+
+func (x *BrowseFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrowseFilter.ProtoReflect.Descriptor instead.
+func (*BrowseFilter) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *BrowseFilter) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *BrowseFilter) GetOp() FilterOp {
+	if x != nil {
+		return x.Op
+	}
+	return FilterOp_FILTER_OP_UNSPECIFIED
+}
+
+func (x *BrowseFilter) GetValue() *SqlValue {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *BrowseFilter) GetValues() []*SqlValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// テーブル閲覧リクエスト
+type BrowseTableRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 接続プロファイル名（空の場合は選択中のプロファイル）
+	Profile string `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	// スキーマ名（空の場合はデフォルトスキーマ）
+	Schema string `protobuf:"bytes,2,opt,name=schema,proto3" json:"schema,omitempty"`
+	Table  string `protobuf:"bytes,3,opt,name=table,proto3" json:"table,omitempty"`
+	// 取得する列（空の場合はすべて）
+	Columns []string `protobuf:"bytes,4,rep,name=columns,proto3" json:"columns,omitempty"`
+	// 並べ替える列（空の場合は主キー）。同じ値の行は主キー順
+	SortColumn string `protobuf:"bytes,5,opt,name=sort_column,json=sortColumn,proto3" json:"sort_column,omitempty"`
+	// 降順の場合true
+	Descending bool            `protobuf:"varint,6,opt,name=descending,proto3" json:"descending,omitempty"`
+	Filters    []*BrowseFilter `protobuf:"bytes,7,rep,name=filters,proto3" json:"filters,omitempty"`
+	// 1ページの行数（0の場合は100、最大10000）
+	PageSize int32 `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// 前のレスポンスのnext_cursor（空の場合は最初のページ）。他の条件は前のリクエストと同じにすること
+	Cursor string `protobuf:"bytes,9,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// 実行時間の上限（秒、0の場合はプロファイルのクエリタイムアウト）
+	TimeoutSeconds int32 `protobuf:"varint,10,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	// trueの場合、結果をheaderとtyped_rowsで返す
+	Typed         bool `protobuf:"varint,11,opt,name=typed,proto3" json:"typed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BrowseTableRequest) Reset() {
+	*x = BrowseTableRequest{}
+	mi := &file_database_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BrowseTableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrowseTableRequest) ProtoMessage() {}
+
+func (x *BrowseTableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrowseTableRequest.ProtoReflect.Descriptor instead.
+func (*BrowseTableRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *BrowseTableRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *BrowseTableRequest) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *BrowseTableRequest) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+func (x *BrowseTableRequest) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *BrowseTableRequest) GetSortColumn() string {
+	if x != nil {
+		return x.SortColumn
+	}
+	return ""
+}
+
+func (x *BrowseTableRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *BrowseTableRequest) GetFilters() []*BrowseFilter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *BrowseTableRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *BrowseTableRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *BrowseTableRequest) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *BrowseTableRequest) GetTyped() bool {
+	if x != nil {
+		return x.Typed
+	}
+	return false
+}
+
+// テーブル閲覧レスポンス
+type BrowseTableResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ページの行（truncatedは次のページがある場合true）
+	Result *QueryResponse `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// 次のページのカーソル（最後のページの場合は空）
+	NextCursor string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// 並び順に使った列（sort_columnと主キー）
+	KeyColumns    []string `protobuf:"bytes,3,rep,name=key_columns,json=keyColumns,proto3" json:"key_columns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BrowseTableResponse) Reset() {
+	*x = BrowseTableResponse{}
+	mi := &file_database_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BrowseTableResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrowseTableResponse) ProtoMessage() {}
+
+func (x *BrowseTableResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrowseTableResponse.ProtoReflect.Descriptor instead.
+func (*BrowseTableResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *BrowseTableResponse) GetResult() *QueryResponse {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *BrowseTableResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *BrowseTableResponse) GetKeyColumns() []string {
+	if x != nil {
+		return x.KeyColumns
+	}
+	return nil
+}
+
+// BrowseTableのカーソルの中身（クライアントは不透明な文字列として扱う）
+type BrowseCursor struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// リクエストの条件のハッシュ
+	Fingerprint string `protobuf:"bytes,1,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	// 前のページの最後の行のキー（key_columnsの順）
+	Key           []*SqlValue `protobuf:"bytes,2,rep,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BrowseCursor) Reset() {
+	*x = BrowseCursor{}
+	mi := &file_database_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BrowseCursor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrowseCursor) ProtoMessage() {}
+
+func (x *BrowseCursor) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrowseCursor.ProtoReflect.Descriptor instead.
+func (*BrowseCursor) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *BrowseCursor) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *BrowseCursor) GetKey() []*SqlValue {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+// CSV取込リクエスト（POST /import/csv のrequestフィールドにJSONで指定）
+type ImportCsvRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 接続プロファイル名（空の場合は選択中のプロファイル）
+	Profile string `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	// スキーマ名（空の場合はデフォルトスキーマ）
+	Schema string `protobuf:"bytes,2,opt,name=schema,proto3" json:"schema,omitempty"`
+	Table  string `protobuf:"bytes,3,opt,name=table,proto3" json:"table,omitempty"`
+	// CSVの文字コード: "utf-8", "shift_jis"（空の場合は自動判別）
+	Encoding string `protobuf:"bytes,4,opt,name=encoding,proto3" json:"encoding,omitempty"`
+	// CSVの列とテーブルの列の対応（空の場合はヘッダーと同じ名前の列に対応付け）
+	Mappings []*ImportColumnMapping `protobuf:"bytes,5,rep,name=mappings,proto3" json:"mappings,omitempty"`
+	// trueの場合、検証のみ行い取り込まない
+	DryRun bool `protobuf:"varint,6,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// 1回のINSERTの行数（0の場合は500）
+	BatchSize int32 `protobuf:"varint,7,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// エラー一覧の最大件数（0の場合は100）
+	MaxErrors int32 `protobuf:"varint,8,opt,name=max_errors,json=maxErrors,proto3" json:"max_errors,omitempty"`
+	// trueの場合、文字列の列の空欄もNULLにする（デフォルトは空文字）
+	EmptyAsNull bool `protobuf:"varint,9,opt,name=empty_as_null,json=emptyAsNull,proto3" json:"empty_as_null,omitempty"`
+	// 進捗を通知するジョブID（空の場合はサーバーが採番）
+	JobId         string `protobuf:"bytes,10,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportCsvRequest) Reset() {
+	*x = ImportCsvRequest{}
+	mi := &file_database_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportCsvRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportCsvRequest) ProtoMessage() {}
+
+func (x *ImportCsvRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportCsvRequest.ProtoReflect.Descriptor instead.
+func (*ImportCsvRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ImportCsvRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *ImportCsvRequest) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *ImportCsvRequest) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+func (x *ImportCsvRequest) GetEncoding() string {
+	if x != nil {
+		return x.Encoding
+	}
+	return ""
+}
+
+func (x *ImportCsvRequest) GetMappings() []*ImportColumnMapping {
+	if x != nil {
+		return x.Mappings
+	}
+	return nil
+}
+
+func (x *ImportCsvRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportCsvRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *ImportCsvRequest) GetMaxErrors() int32 {
+	if x != nil {
+		return x.MaxErrors
+	}
+	return 0
+}
+
+func (x *ImportCsvRequest) GetEmptyAsNull() bool {
+	if x != nil {
+		return x.EmptyAsNull
+	}
+	return false
+}
+
+func (x *ImportCsvRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// CSVの列とテーブルの列の対応
+type ImportColumnMapping struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CSVのヘッダー名
+	CsvColumn string `protobuf:"bytes,1,opt,name=csv_column,json=csvColumn,proto3" json:"csv_column,omitempty"`
+	// テーブルの列名
+	Column        string `protobuf:"bytes,2,opt,name=column,proto3" json:"column,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportColumnMapping) Reset() {
+	*x = ImportColumnMapping{}
+	mi := &file_database_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportColumnMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportColumnMapping) ProtoMessage() {}
+
+func (x *ImportColumnMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportColumnMapping.ProtoReflect.Descriptor instead.
+func (*ImportColumnMapping) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ImportColumnMapping) GetCsvColumn() string {
+	if x != nil {
+		return x.CsvColumn
+	}
+	return ""
+}
+
+func (x *ImportColumnMapping) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+// CSVの値のエラー
+type ImportError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CSVの行番号（ヘッダーが1行目）
+	Line          int64  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	CsvColumn     string `protobuf:"bytes,2,opt,name=csv_column,json=csvColumn,proto3" json:"csv_column,omitempty"`
+	Column        string `protobuf:"bytes,3,opt,name=column,proto3" json:"column,omitempty"`
+	Value         string `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Message       string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportError) Reset() {
+	*x = ImportError{}
+	mi := &file_database_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportError) ProtoMessage() {}
+
+func (x *ImportError) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportError.ProtoReflect.Descriptor instead.
+func (*ImportError) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ImportError) GetLine() int64 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportError) GetCsvColumn() string {
+	if x != nil {
+		return x.CsvColumn
+	}
+	return ""
+}
+
+func (x *ImportError) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *ImportError) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ImportError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// CSV取込レスポンス
+type ImportCsvResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	JobId  string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	DryRun bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// 判別した文字コード
+	Encoding string `protobuf:"bytes,3,opt,name=encoding,proto3" json:"encoding,omitempty"`
+	// CSVのヘッダー
+	CsvColumns []string `protobuf:"bytes,4,rep,name=csv_columns,json=csvColumns,proto3" json:"csv_columns,omitempty"`
+	// 使用した対応
+	Mappings []*ImportColumnMapping `protobuf:"bytes,5,rep,name=mappings,proto3" json:"mappings,omitempty"`
+	// 取り込まないCSVの列
+	UnmappedCsvColumns []string `protobuf:"bytes,6,rep,name=unmapped_csv_columns,json=unmappedCsvColumns,proto3" json:"unmapped_csv_columns,omitempty"`
+	// データ行数
+	Rows int64 `protobuf:"varint,7,opt,name=rows,proto3" json:"rows,omitempty"`
+	// 取り込んだ行数（dry_runまたはエラーがある場合は0）
+	Inserted int64 `protobuf:"varint,8,opt,name=inserted,proto3" json:"inserted,omitempty"`
+	// エラーのある行数（1件でもあれば取り込まない）
+	ErrorRows int64          `protobuf:"varint,9,opt,name=error_rows,json=errorRows,proto3" json:"error_rows,omitempty"`
+	Errors    []*ImportError `protobuf:"bytes,10,rep,name=errors,proto3" json:"errors,omitempty"`
+	// エラー一覧がmax_errorsで打ち切られた場合true
+	ErrorsTruncated bool `protobuf:"varint,11,opt,name=errors_truncated,json=errorsTruncated,proto3" json:"errors_truncated,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ImportCsvResponse) Reset() {
+	*x = ImportCsvResponse{}
+	mi := &file_database_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportCsvResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportCsvResponse) ProtoMessage() {}
+
+func (x *ImportCsvResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportCsvResponse.ProtoReflect.Descriptor instead.
+func (*ImportCsvResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ImportCsvResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *ImportCsvResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportCsvResponse) GetEncoding() string {
+	if x != nil {
+		return x.Encoding
+	}
+	return ""
+}
+
+func (x *ImportCsvResponse) GetCsvColumns() []string {
+	if x != nil {
+		return x.CsvColumns
+	}
+	return nil
+}
+
+func (x *ImportCsvResponse) GetMappings() []*ImportColumnMapping {
+	if x != nil {
+		return x.Mappings
+	}
+	return nil
+}
+
+func (x *ImportCsvResponse) GetUnmappedCsvColumns() []string {
+	if x != nil {
+		return x.UnmappedCsvColumns
+	}
+	return nil
+}
+
+func (x *ImportCsvResponse) GetRows() int64 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *ImportCsvResponse) GetInserted() int64 {
+	if x != nil {
+		return x.Inserted
+	}
+	return 0
+}
+
+func (x *ImportCsvResponse) GetErrorRows() int64 {
+	if x != nil {
+		return x.ErrorRows
+	}
+	return 0
+}
+
+func (x *ImportCsvResponse) GetErrors() []*ImportError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ImportCsvResponse) GetErrorsTruncated() bool {
+	if x != nil {
+		return x.ErrorsTruncated
+	}
+	return false
+}
+
+var File_database_proto protoreflect.FileDescriptor
+
+const file_database_proto_rawDesc = "" +
+	"\n" +
+	"\x0edatabase.proto\x12\x11desktop_server.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9d\x02\n" +
+	"\bSqlValue\x12\x1f\n" +
+	"\n" +
+	"null_value\x18\x01 \x01(\bH\x00R\tnullValue\x12#\n" +
+	"\fstring_value\x18\x02 \x01(\tH\x00R\vstringValue\x12\x1d\n" +
+	"\tint_value\x18\x03 \x01(\x03H\x00R\bintValue\x12#\n" +
+	"\fdouble_value\x18\x04 \x01(\x01H\x00R\vdoubleValue\x12\x1f\n" +
+	"\n" +
+	"bool_value\x18\x05 \x01(\bH\x00R\tboolValue\x12!\n" +
+	"\vbytes_value\x18\x06 \x01(\fH\x00R\n" +
+	"bytesValue\x12;\n" +
+	"\n" +
+	"time_value\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x00R\ttimeValueB\x06\n" +
+	"\x04kind\"\xc9\x01\n" +
+	"\fQueryRequest\x12\x10\n" +
+	"\x03sql\x18\x01 \x01(\tR\x03sql\x123\n" +
+	"\x06params\x18\x02 \x03(\v2\x1b.desktop_server.v1.SqlValueR\x06params\x12\x19\n" +
+	"\bmax_rows\x18\x03 \x01(\x05R\amaxRows\x12\x18\n" +
+	"\aprofile\x18\x04 \x01(\tR\aprofile\x12'\n" +
+	"\x0ftimeout_seconds\x18\x05 \x01(\x05R\x0etimeoutSeconds\x12\x14\n" +
+	"\x05typed\x18\x06 \x01(\bR\x05typed\"\xfc\x01\n" +
+	"\rQueryResponse\x12*\n" +
+	"\x04rows\x18\x01 \x03(\v2\x16.desktop_server.v1.RowR\x04rows\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x18\n" +
+	"\acolumns\x18\x03 \x03(\tR\acolumns\x12\x1c\n" +
+	"\ttruncated\x18\x04 \x01(\bR\ttruncated\x125\n" +
+	"\x06header\x18\x05 \x03(\v2\x1d.desktop_server.v1.ColumnMetaR\x06header\x12:\n" +
+	"\n" +
+	"typed_rows\x18\x06 \x03(\v2\x1b.desktop_server.v1.TypedRowR\ttypedRows\"\x80\x01\n" +
+	"\x03Row\x12=\n" +
+	"\acolumns\x18\x01 \x03(\v2#.desktop_server.v1.Row.ColumnsEntryR\acolumns\x1a:\n" +
+	"\fColumnsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa2\x02\n" +
+	"\n" +
+	"ColumnMeta\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
+	"\rdatabase_type\x18\x02 \x01(\tR\fdatabaseType\x12/\n" +
+	"\x04kind\x18\x03 \x01(\x0e2\x1b.desktop_server.v1.CellKindR\x04kind\x12\x1f\n" +
+	"\bnullable\x18\x04 \x01(\bH\x00R\bnullable\x88\x01\x01\x12\x1b\n" +
+	"\x06length\x18\x05 \x01(\x03H\x01R\x06length\x88\x01\x01\x12!\n" +
+	"\tprecision\x18\x06 \x01(\x03H\x02R\tprecision\x88\x01\x01\x12\x19\n" +
+	"\x05scale\x18\a \x01(\x03H\x03R\x05scale\x88\x01\x01B\v\n" +
+	"\t_nullableB\t\n" +
+	"\a_lengthB\f\n" +
+	"\n" +
+	"_precisionB\b\n" +
+	"\x06_scale\"9\n" +
+	"\bTypedRow\x12-\n" +
+	"\x05cells\x18\x01 \x03(\v2\x17.desktop_server.v1.CellR\x05cells\"\xd0\x02\n" +
+	"\x04Cell\x12\x1f\n" +
+	"\n" +
+	"null_value\x18\x01 \x01(\bH\x00R\tnullValue\x12\x1d\n" +
+	"\tint_value\x18\x02 \x01(\x03H\x00R\bintValue\x12%\n" +
+	"\rdecimal_value\x18\x03 \x01(\tH\x00R\fdecimalValue\x12!\n" +
+	"\vfloat_value\x18\x04 \x01(\x01H\x00R\n" +
+	"floatValue\x12\x1f\n" +
+	"\n" +
+	"bool_value\x18\x05 \x01(\bH\x00R\tboolValue\x12#\n" +
+	"\fstring_value\x18\x06 \x01(\tH\x00R\vstringValue\x12!\n" +
+	"\vbytes_value\x18\a \x01(\fH\x00R\n" +
+	"bytesValue\x12L\n" +
+	"\x0ftimestamp_value\x18\b \x01(\v2!.desktop_server.v1.ZonedTimestampH\x00R\x0etimestampValueB\a\n" +
+	"\x05value\"\x89\x01\n" +
+	"\x0eZonedTimestamp\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12,\n" +
+	"\x12utc_offset_seconds\x18\x02 \x01(\x05R\x10utcOffsetSeconds\x12\x19\n" +
+	"\bhas_zone\x18\x03 \x01(\bR\ahasZone\"\xcf\x01\n" +
+	"\x12StreamQueryRequest\x12\x10\n" +
+	"\x03sql\x18\x01 \x01(\tR\x03sql\x123\n" +
+	"\x06params\x18\x02 \x03(\v2\x1b.desktop_server.v1.SqlValueR\x06params\x12\x19\n" +
+	"\bmax_rows\x18\x03 \x01(\x05R\amaxRows\x12\x18\n" +
+	"\aprofile\x18\x04 \x01(\tR\aprofile\x12'\n" +
+	"\x0ftimeout_seconds\x18\x05 \x01(\x05R\x0etimeoutSeconds\x12\x14\n" +
+	"\x05typed\x18\x06 \x01(\bR\x05typed\"\x93\x02\n" +
+	"\bQueryRow\x12B\n" +
+	"\acolumns\x18\x01 \x03(\v2(.desktop_server.v1.QueryRow.ColumnsEntryR\acolumns\x12!\n" +
+	"\fcolumn_names\x18\x02 \x03(\tR\vcolumnNames\x12-\n" +
+	"\x05cells\x18\x03 \x03(\v2\x17.desktop_server.v1.CellR\x05cells\x125\n" +
+	"\x06header\x18\x04 \x03(\v2\x1d.desktop_server.v1.ColumnMetaR\x06header\x1a:\n" +
+	"\fColumnsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\",\n" +
+	"\x10GetTablesRequest\x12\x18\n" +
+	"\aprofile\x18\x01 \x01(\tR\aprofile\"+\n" +
+	"\x11GetTablesResponse\x12\x16\n" +
+	"\x06tables\x18\x01 \x03(\tR\x06tables\"\x9a\x01\n" +
+	"\x0eExecuteRequest\x12\x10\n" +
+	"\x03sql\x18\x01 \x01(\tR\x03sql\x123\n" +
+	"\x06params\x18\x02 \x03(\v2\x1b.desktop_server.v1.SqlValueR\x06params\x12\x18\n" +
+	"\aprofile\x18\x03 \x01(\tR\aprofile\x12'\n" +
+	"\x0ftimeout_seconds\x18\x04 \x01(\x05R\x0etimeoutSeconds\"\\\n" +
+	"\x0fExecuteResponse\x12#\n" +
+	"\raffected_rows\x18\x01 \x01(\x03R\faffectedRows\x12$\n" +
+	"\x0elast_insert_id\x18\x02 \x01(\x03R\flastInsertId\"D\n" +
+	"\x10GetSchemaRequest\x12\x16\n" +
+	"\x06schema\x18\x01 \x01(\tR\x06schema\x12\x18\n" +
+	"\aprofile\x18\x02 \x01(\tR\aprofile\"I\n" +
+	"\x11GetSchemaResponse\x124\n" +
+	"\x06tables\x18\x01 \x03(\v2\x1c.desktop_server.v1.TableInfoR\x06tables\"^\n" +
+	"\x14DescribeTableRequest\x12\x16\n" +
+	"\x06schema\x18\x01 \x01(\tR\x06schema\x12\x14\n" +
+	"\x05table\x18\x02 \x01(\tR\x05table\x12\x18\n" +
+	"\aprofile\x18\x03 \x01(\tR\aprofile\"s\n" +
+	"\tTableInfo\x12\x16\n" +
+	"\x06schema\x18\x01 \x01(\tR\x06schema\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x17\n" +
+	"\ais_view\x18\x03 \x01(\bR\x06isView\x12!\n" +
+	"\frow_estimate\x18\x04 \x01(\x03R\vrowEstimate\"\xe8\x02\n" +
+	"\n" +
+	"ColumnInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aordinal\x18\x02 \x01(\x05R\aordinal\x12\x1b\n" +
+	"\tdata_type\x18\x03 \x01(\tR\bdataType\x12\x1f\n" +
+	"\vcolumn_type\x18\x04 \x01(\tR\n" +
+	"columnType\x12\x1a\n" +
+	"\bnullable\x18\x05 \x01(\bR\bnullable\x12(\n" +
+	"\rdefault_value\x18\x06 \x01(\tH\x00R\fdefaultValue\x88\x01\x01\x12\x1c\n" +
+	"\tcollation\x18\a \x01(\tR\tcollation\x12\x1d\n" +
+	"\n" +
+	"max_length\x18\b \x01(\x03R\tmaxLength\x12\x1c\n" +
+	"\tprecision\x18\t \x01(\x05R\tprecision\x12\x14\n" +
+	"\x05scale\x18\n" +
+	" \x01(\x05R\x05scale\x12%\n" +
+	"\x0eauto_increment\x18\v \x01(\bR\rautoIncrementB\x10\n" +
+	"\x0e_default_value\"k\n" +
+	"\tIndexInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acolumns\x18\x02 \x03(\tR\acolumns\x12\x16\n" +
+	"\x06unique\x18\x03 \x01(\bR\x06unique\x12\x18\n" +
+	"\aprimary\x18\x04 \x01(\bR\aprimary\"\xff\x01\n" +
+	"\x0eForeignKeyInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acolumns\x18\x02 \x03(\tR\acolumns\x12+\n" +
+	"\x11referenced_schema\x18\x03 \x01(\tR\x10referencedSchema\x12)\n" +
+	"\x10referenced_table\x18\x04 \x01(\tR\x0freferencedTable\x12-\n" +
+	"\x12referenced_columns\x18\x05 \x03(\tR\x11referencedColumns\x12\x1b\n" +
+	"\ton_delete\x18\x06 \x01(\tR\bonDelete\x12\x1b\n" +
+	"\ton_update\x18\a \x01(\tR\bonUpdate\"\xc2\x02\n" +
+	"\vTableSchema\x122\n" +
+	"\x05table\x18\x01 \x01(\v2\x1c.desktop_server.v1.TableInfoR\x05table\x127\n" +
+	"\acolumns\x18\x02 \x03(\v2\x1d.desktop_server.v1.ColumnInfoR\acolumns\x12\x1f\n" +
+	"\vprimary_key\x18\x03 \x03(\tR\n" +
+	"primaryKey\x126\n" +
+	"\aindexes\x18\x04 \x03(\v2\x1c.desktop_server.v1.IndexInfoR\aindexes\x12D\n" +
+	"\fforeign_keys\x18\x05 \x03(\v2!.desktop_server.v1.ForeignKeyInfoR\vforeignKeys\x12'\n" +
+	"\x0fview_definition\x18\x06 \x01(\tR\x0eviewDefinition\"\xa0\x02\n" +
+	"\x12ExportQueryRequest\x12\x10\n" +
+	"\x03sql\x18\x01 \x01(\tR\x03sql\x123\n" +
+	"\x06params\x18\x02 \x03(\v2\x1b.desktop_server.v1.SqlValueR\x06params\x12\x16\n" +
+	"\x06format\x18\x03 \x01(\tR\x06format\x12\x1a\n" +
+	"\bencoding\x18\x04 \x01(\tR\bencoding\x12\x19\n" +
+	"\bmax_rows\x18\x05 \x01(\x03R\amaxRows\x12\x1a\n" +
+	"\bfilename\x18\x06 \x01(\tR\bfilename\x12\x15\n" +
+	"\x06job_id\x18\a \x01(\tR\x05jobId\x12\x18\n" +
+	"\aprofile\x18\b \x01(\tR\aprofile\x12'\n" +
+	"\x0ftimeout_seconds\x18\t \x01(\x05R\x0etimeoutSeconds\"\xe9\x06\n" +
+	"\x0fDatabaseProfile\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06driver\x18\x02 \x01(\tR\x06driver\x12\x12\n" +
+	"\x04host\x18\x03 \x01(\tR\x04host\x12\x12\n" +
+	"\x04port\x18\x04 \x01(\x05R\x04port\x12\x1a\n" +
+	"\bdatabase\x18\x05 \x01(\tR\bdatabase\x12\x12\n" +
+	"\x04user\x18\x06 \x01(\tR\x04user\x12\x1a\n" +
+	"\bpassword\x18\a \x01(\tR\bpassword\x12!\n" +
+	"\fhas_password\x18\b \x01(\bR\vhasPassword\x12F\n" +
+	"\x06params\x18\t \x03(\v2..desktop_server.v1.DatabaseProfile.ParamsEntryR\x06params\x12\x1b\n" +
+	"\tread_only\x18\n" +
+	" \x01(\bR\breadOnly\x12\x1b\n" +
+	"\tallow_ddl\x18\v \x01(\bR\ballowDdl\x12\x1f\n" +
+	"\vallow_other\x18\f \x01(\bR\n" +
+	"allowOther\x124\n" +
+	"\x16allow_multi_statements\x18\r \x01(\bR\x14allowMultiStatements\x12\x1a\n" +
+	"\bselected\x18\x0e \x01(\bR\bselected\x12\x19\n" +
+	"\bfrom_env\x18\x0f \x01(\bR\afromEnv\x12$\n" +
+	"\x0emax_open_conns\x18\x10 \x01(\x05R\fmaxOpenConns\x12$\n" +
+	"\x0emax_idle_conns\x18\x11 \x01(\x05R\fmaxIdleConns\x129\n" +
+	"\x19conn_max_lifetime_seconds\x18\x12 \x01(\x05R\x16connMaxLifetimeSeconds\x12:\n" +
+	"\x1aconn_max_idle_time_seconds\x18\x13 \x01(\x05R\x16connMaxIdleTimeSeconds\x126\n" +
+	"\x17connect_timeout_seconds\x18\x14 \x01(\x05R\x15connectTimeoutSeconds\x122\n" +
+	"\x15query_timeout_seconds\x18\x15 \x01(\x05R\x13queryTimeoutSeconds\x12\x19\n" +
+	"\bmax_rows\x18\x16 \x01(\x03R\amaxRows\x1a9\n" +
+	"\vParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x15\n" +
+	"\x13ListProfilesRequest\"r\n" +
+	"\x14ListProfilesResponse\x12>\n" +
+	"\bprofiles\x18\x01 \x03(\v2\".desktop_server.v1.DatabaseProfileR\bprofiles\x12\x1a\n" +
+	"\bselected\x18\x02 \x01(\tR\bselected\"y\n" +
+	"\x12SaveProfileRequest\x12<\n" +
+	"\aprofile\x18\x01 \x01(\v2\".desktop_server.v1.DatabaseProfileR\aprofile\x12%\n" +
+	"\x0eclear_password\x18\x02 \x01(\bR\rclearPassword\"*\n" +
+	"\x14RemoveProfileRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x17\n" +
+	"\x15RemoveProfileResponse\"f\n" +
+	"\x12TestProfileRequest\x12<\n" +
+	"\aprofile\x18\x01 \x01(\v2\".desktop_server.v1.DatabaseProfileR\aprofile\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\x81\x01\n" +
+	"\x13TestProfileResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12%\n" +
+	"\x0eserver_version\x18\x03 \x01(\tR\rserverVersion\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x04 \x01(\x03R\tlatencyMs\"*\n" +
+	"\x14SelectProfileRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x15\n" +
+	"\x13GetPoolStatsRequest\"J\n" +
+	"\x14GetPoolStatsResponse\x122\n" +
+	"\x05pools\x18\x01 \x03(\v2\x1c.desktop_server.v1.PoolStatsR\x05pools\"\xdc\x04\n" +
+	"\tPoolStats\x12\x18\n" +
+	"\aprofile\x18\x01 \x01(\tR\aprofile\x12\x16\n" +
+	"\x06driver\x18\x02 \x01(\tR\x06driver\x12$\n" +
+	"\x0emax_open_conns\x18\x03 \x01(\x05R\fmaxOpenConns\x12$\n" +
+	"\x0emax_idle_conns\x18\x04 \x01(\x05R\fmaxIdleConns\x129\n" +
+	"\x19conn_max_lifetime_seconds\x18\x05 \x01(\x03R\x16connMaxLifetimeSeconds\x12:\n" +
+	"\x1aconn_max_idle_time_seconds\x18\x06 \x01(\x03R\x16connMaxIdleTimeSeconds\x122\n" +
+	"\x15query_timeout_seconds\x18\a \x01(\x03R\x13queryTimeoutSeconds\x12)\n" +
+	"\x10open_connections\x18\b \x01(\x05R\x0fopenConnections\x12\x15\n" +
+	"\x06in_use\x18\t \x01(\x05R\x05inUse\x12\x12\n" +
+	"\x04idle\x18\n" +
+	" \x01(\x05R\x04idle\x12\x1d\n" +
+	"\n" +
+	"wait_count\x18\v \x01(\x03R\twaitCount\x12(\n" +
+	"\x10wait_duration_ms\x18\f \x01(\x03R\x0ewaitDurationMs\x12&\n" +
+	"\x0fmax_idle_closed\x18\r \x01(\x03R\rmaxIdleClosed\x12/\n" +
+	"\x14max_idle_time_closed\x18\x0e \x01(\x03R\x11maxIdleTimeClosed\x12.\n" +
+	"\x13max_lifetime_closed\x18\x0f \x01(\x03R\x11maxLifetimeClosed\"\xfc\x01\n" +
+	"\n" +
+	"SavedQuery\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x10\n" +
+	"\x03sql\x18\x03 \x01(\tR\x03sql\x12:\n" +
+	"\x06params\x18\x04 \x03(\v2\".desktop_server.v1.SavedQueryParamR\x06params\x12\x18\n" +
+	"\aprofile\x18\x05 \x01(\tR\aprofile\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\x03R\tupdatedAt\"\x97\x01\n" +
+	"\x0fSavedQueryParam\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12(\n" +
+	"\rdefault_value\x18\x03 \x01(\tH\x00R\fdefaultValue\x88\x01\x01\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescriptionB\x10\n" +
+	"\x0e_default_value\"C\n" +
+	"\x17ListSavedQueriesRequest\x12\x16\n" +
+	"\x06search\x18\x01 \x01(\tR\x06search\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\"S\n" +
+	"\x18ListSavedQueriesResponse\x127\n" +
+	"\aqueries\x18\x01 \x03(\v2\x1d.desktop_server.v1.SavedQueryR\aqueries\"G\n" +
+	"\x10SaveQueryRequest\x123\n" +
+	"\x05query\x18\x01 \x01(\v2\x1d.desktop_server.v1.SavedQueryR\x05query\"-\n" +
+	"\x17DeleteSavedQueryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x1a\n" +
+	"\x18DeleteSavedQueryResponse\"\xc3\x02\n" +
+	"\x14RunSavedQueryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12K\n" +
+	"\x06params\x18\x02 \x03(\v23.desktop_server.v1.RunSavedQueryRequest.ParamsEntryR\x06params\x12\x18\n" +
+	"\aprofile\x18\x03 \x01(\tR\aprofile\x12\x19\n" +
+	"\bmax_rows\x18\x04 \x01(\x05R\amaxRows\x12'\n" +
+	"\x0ftimeout_seconds\x18\x05 \x01(\x05R\x0etimeoutSeconds\x12\x14\n" +
+	"\x05typed\x18\x06 \x01(\bR\x05typed\x1aV\n" +
+	"\vParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x121\n" +
+	"\x05value\x18\x02 \x01(\v2\x1b.desktop_server.v1.SqlValueR\x05value:\x028\x01\"\x8f\x01\n" +
+	"\x15RunSavedQueryResponse\x128\n" +
+	"\x06result\x18\x01 \x01(\v2 .desktop_server.v1.QueryResponseR\x06result\x12<\n" +
+	"\aexecute\x18\x02 \x01(\v2\".desktop_server.v1.ExecuteResponseR\aexecute\"1\n" +
+	"\x19ExportSavedQueriesRequest\x12\x14\n" +
+	"\x05names\x18\x01 \x03(\tR\x05names\"b\n" +
+	"\x1aExportSavedQueriesResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"M\n" +
+	"\x19ImportSavedQueriesRequest\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x1c\n" +
+	"\toverwrite\x18\x02 \x01(\bR\toverwrite\"R\n" +
+	"\x1aImportSavedQueriesResponse\x12\x1a\n" +
+	"\bimported\x18\x01 \x03(\tR\bimported\x12\x18\n" +
+	"\askipped\x18\x02 \x03(\tR\askipped\"\xf2\x01\n" +
+	"\x11QueryHistoryEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aprofile\x18\x02 \x01(\tR\aprofile\x12\x10\n" +
+	"\x03sql\x18\x03 \x01(\tR\x03sql\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12\x1f\n" +
+	"\vsaved_query\x18\x05 \x01(\tR\n" +
+	"savedQuery\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x06 \x01(\x03R\tstartedAt\x12\x1f\n" +
+	"\vduration_ms\x18\a \x01(\x03R\n" +
+	"durationMs\x12\x12\n" +
+	"\x04rows\x18\b \x01(\x03R\x04rows\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\"\xc4\x01\n" +
+	"\x17ListQueryHistoryRequest\x12\x18\n" +
+	"\aprofile\x18\x01 \x01(\tR\aprofile\x12\x16\n" +
+	"\x06search\x18\x02 \x01(\tR\x06search\x12\x1f\n" +
+	"\verrors_only\x18\x03 \x01(\bR\n" +
+	"errorsOnly\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12!\n" +
+	"\fstarted_from\x18\x05 \x01(\x03R\vstartedFrom\x12\x1d\n" +
+	"\n" +
+	"started_to\x18\x06 \x01(\x03R\tstartedTo\"Z\n" +
+	"\x18ListQueryHistoryResponse\x12>\n" +
+	"\aentries\x18\x01 \x03(\v2$.desktop_server.v1.QueryHistoryEntryR\aentries\"4\n" +
+	"\x18ClearQueryHistoryRequest\x12\x18\n" +
+	"\aprofile\x18\x01 \x01(\tR\aprofile\"5\n" +
+	"\x19ClearQueryHistoryResponse\x12\x18\n" +
+	"\aremoved\x18\x01 \x01(\x05R\aremoved\"\xdd\x01\n" +
+	"\fRunningQuery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aprofile\x18\x02 \x01(\tR\aprofile\x12\x10\n" +
+	"\x03sql\x18\x03 \x01(\tR\x03sql\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12\x1f\n" +
+	"\vsaved_query\x18\x05 \x01(\tR\n" +
+	"savedQuery\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x06 \x01(\x03R\tstartedAt\x12\x1d\n" +
+	"\n" +
+	"elapsed_ms\x18\a \x01(\x03R\telapsedMs\x12\x1a\n" +
+	"\bdeadline\x18\b \x01(\x03R\bdeadline\"5\n" +
+	"\x19ListRunningQueriesRequest\x12\x18\n" +
+	"\aprofile\x18\x01 \x01(\tR\aprofile\"W\n" +
+	"\x1aListRunningQueriesResponse\x129\n" +
+	"\aqueries\x18\x01 \x03(\v2\x1f.desktop_server.v1.RunningQueryR\aqueries\"\"\n" +
+	"\x10KillQueryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x13\n" +
+	"\x11KillQueryResponse\"\xbb\x01\n" +
+	"\fBrowseFilter\x12\x16\n" +
+	"\x06column\x18\x01 \x01(\tR\x06column\x12+\n" +
+	"\x02op\x18\x02 \x01(\x0e2\x1b.desktop_server.v1.FilterOpR\x02op\x121\n" +
+	"\x05value\x18\x03 \x01(\v2\x1b.desktop_server.v1.SqlValueR\x05value\x123\n" +
+	"\x06values\x18\x04 \x03(\v2\x1b.desktop_server.v1.SqlValueR\x06values\"\xe6\x02\n" +
+	"\x12BrowseTableRequest\x12\x18\n" +
+	"\aprofile\x18\x01 \x01(\tR\aprofile\x12\x16\n" +
+	"\x06schema\x18\x02 \x01(\tR\x06schema\x12\x14\n" +
+	"\x05table\x18\x03 \x01(\tR\x05table\x12\x18\n" +
+	"\acolumns\x18\x04 \x03(\tR\acolumns\x12\x1f\n" +
+	"\vsort_column\x18\x05 \x01(\tR\n" +
+	"sortColumn\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x06 \x01(\bR\n" +
+	"descending\x129\n" +
+	"\afilters\x18\a \x03(\v2\x1f.desktop_server.v1.BrowseFilterR\afilters\x12\x1b\n" +
+	"\tpage_size\x18\b \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06cursor\x18\t \x01(\tR\x06cursor\x12'\n" +
+	"\x0ftimeout_seconds\x18\n" +
+	" \x01(\x05R\x0etimeoutSeconds\x12\x14\n" +
+	"\x05typed\x18\v \x01(\bR\x05typed\"\x91\x01\n" +
+	"\x13BrowseTableResponse\x128\n" +
+	"\x06result\x18\x01 \x01(\v2 .desktop_server.v1.QueryResponseR\x06result\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vkey_columns\x18\x03 \x03(\tR\n" +
+	"keyColumns\"_\n" +
+	"\fBrowseCursor\x12 \n" +
+	"\vfingerprint\x18\x01 \x01(\tR\vfingerprint\x12-\n" +
+	"\x03key\x18\x02 \x03(\v2\x1b.desktop_server.v1.SqlValueR\x03key\"\xcc\x02\n" +
+	"\x10ImportCsvRequest\x12\x18\n" +
+	"\aprofile\x18\x01 \x01(\tR\aprofile\x12\x16\n" +
+	"\x06schema\x18\x02 \x01(\tR\x06schema\x12\x14\n" +
+	"\x05table\x18\x03 \x01(\tR\x05table\x12\x1a\n" +
+	"\bencoding\x18\x04 \x01(\tR\bencoding\x12B\n" +
+	"\bmappings\x18\x05 \x03(\v2&.desktop_server.v1.ImportColumnMappingR\bmappings\x12\x17\n" +
+	"\adry_run\x18\x06 \x01(\bR\x06dryRun\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\a \x01(\x05R\tbatchSize\x12\x1d\n" +
+	"\n" +
+	"max_errors\x18\b \x01(\x05R\tmaxErrors\x12\"\n" +
+	"\rempty_as_null\x18\t \x01(\bR\vemptyAsNull\x12\x15\n" +
+	"\x06job_id\x18\n" +
+	" \x01(\tR\x05jobId\"L\n" +
+	"\x13ImportColumnMapping\x12\x1d\n" +
+	"\n" +
+	"csv_column\x18\x01 \x01(\tR\tcsvColumn\x12\x16\n" +
+	"\x06column\x18\x02 \x01(\tR\x06column\"\x88\x01\n" +
+	"\vImportError\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x03R\x04line\x12\x1d\n" +
+	"\n" +
+	"csv_column\x18\x02 \x01(\tR\tcsvColumn\x12\x16\n" +
+	"\x06column\x18\x03 \x01(\tR\x06column\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\"\xa8\x03\n" +
+	"\x11ImportCsvResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12\x1a\n" +
+	"\bencoding\x18\x03 \x01(\tR\bencoding\x12\x1f\n" +
+	"\vcsv_columns\x18\x04 \x03(\tR\n" +
+	"csvColumns\x12B\n" +
+	"\bmappings\x18\x05 \x03(\v2&.desktop_server.v1.ImportColumnMappingR\bmappings\x120\n" +
+	"\x14unmapped_csv_columns\x18\x06 \x03(\tR\x12unmappedCsvColumns\x12\x12\n" +
+	"\x04rows\x18\a \x01(\x03R\x04rows\x12\x1a\n" +
+	"\binserted\x18\b \x01(\x03R\binserted\x12\x1d\n" +
+	"\n" +
+	"error_rows\x18\t \x01(\x03R\terrorRows\x126\n" +
+	"\x06errors\x18\n" +
+	" \x03(\v2\x1e.desktop_server.v1.ImportErrorR\x06errors\x12)\n" +
+	"\x10errors_truncated\x18\v \x01(\bR\x0ferrorsTruncated*\xe4\x01\n" +
+	"\bCellKind\x12\x19\n" +
+	"\x15CELL_KIND_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rCELL_KIND_INT\x10\x01\x12\x15\n" +
+	"\x11CELL_KIND_DECIMAL\x10\x02\x12\x13\n" +
+	"\x0fCELL_KIND_FLOAT\x10\x03\x12\x12\n" +
+	"\x0eCELL_KIND_BOOL\x10\x04\x12\x14\n" +
+	"\x10CELL_KIND_STRING\x10\x05\x12\x13\n" +
+	"\x0fCELL_KIND_BYTES\x10\x06\x12\x17\n" +
+	"\x13CELL_KIND_TIMESTAMP\x10\a\x12\x12\n" +
+	"\x0eCELL_KIND_DATE\x10\b\x12\x12\n" +
+	"\x0eCELL_KIND_TIME\x10\t*\x88\x02\n" +
+	"\bFilterOp\x12\x19\n" +
+	"\x15FILTER_OP_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fFILTER_OP_EQ\x10\x01\x12\x10\n" +
+	"\fFILTER_OP_NE\x10\x02\x12\x10\n" +
+	"\fFILTER_OP_LT\x10\x03\x12\x10\n" +
+	"\fFILTER_OP_LE\x10\x04\x12\x10\n" +
+	"\fFILTER_OP_GT\x10\x05\x12\x10\n" +
+	"\fFILTER_OP_GE\x10\x06\x12\x16\n" +
+	"\x12FILTER_OP_CONTAINS\x10\a\x12\x19\n" +
+	"\x15FILTER_OP_STARTS_WITH\x10\b\x12\x15\n" +
+	"\x11FILTER_OP_IS_NULL\x10\t\x12\x19\n" +
+	"\x15FILTER_OP_IS_NOT_NULL\x10\n" +
+	"\x12\x10\n" +
+	"\fFILTER_OP_IN\x10\v2\xd0\x11\n" +
+	"\x0fDatabaseService\x12R\n" +
+	"\rQueryDatabase\x12\x1f.desktop_server.v1.QueryRequest\x1a .desktop_server.v1.QueryResponse\x12S\n" +
+	"\vStreamQuery\x12%.desktop_server.v1.StreamQueryRequest\x1a\x1b.desktop_server.v1.QueryRow0\x01\x12V\n" +
+	"\tGetTables\x12#.desktop_server.v1.GetTablesRequest\x1a$.desktop_server.v1.GetTablesResponse\x12S\n" +
+	"\n" +
+	"ExecuteSQL\x12!.desktop_server.v1.ExecuteRequest\x1a\".desktop_server.v1.ExecuteResponse\x12V\n" +
+	"\tGetSchema\x12#.desktop_server.v1.GetSchemaRequest\x1a$.desktop_server.v1.GetSchemaResponse\x12X\n" +
+	"\rDescribeTable\x12'.desktop_server.v1.DescribeTableRequest\x1a\x1e.desktop_server.v1.TableSchema\x12_\n" +
+	"\fListProfiles\x12&.desktop_server.v1.ListProfilesRequest\x1a'.desktop_server.v1.ListProfilesResponse\x12X\n" +
+	"\vSaveProfile\x12%.desktop_server.v1.SaveProfileRequest\x1a\".desktop_server.v1.DatabaseProfile\x12b\n" +
+	"\rRemoveProfile\x12'.desktop_server.v1.RemoveProfileRequest\x1a(.desktop_server.v1.RemoveProfileResponse\x12\\\n" +
+	"\vTestProfile\x12%.desktop_server.v1.TestProfileRequest\x1a&.desktop_server.v1.TestProfileResponse\x12\\\n" +
+	"\rSelectProfile\x12'.desktop_server.v1.SelectProfileRequest\x1a\".desktop_server.v1.DatabaseProfile\x12_\n" +
+	"\fGetPoolStats\x12&.desktop_server.v1.GetPoolStatsRequest\x1a'.desktop_server.v1.GetPoolStatsResponse\x12k\n" +
+	"\x10ListSavedQueries\x12*.desktop_server.v1.ListSavedQueriesRequest\x1a+.desktop_server.v1.ListSavedQueriesResponse\x12O\n" +
+	"\tSaveQuery\x12#.desktop_server.v1.SaveQueryRequest\x1a\x1d.desktop_server.v1.SavedQuery\x12k\n" +
+	"\x10DeleteSavedQuery\x12*.desktop_server.v1.DeleteSavedQueryRequest\x1a+.desktop_server.v1.DeleteSavedQueryResponse\x12b\n" +
+	"\rRunSavedQuery\x12'.desktop_server.v1.RunSavedQueryRequest\x1a(.desktop_server.v1.RunSavedQueryResponse\x12q\n" +
+	"\x12ExportSavedQueries\x12,.desktop_server.v1.ExportSavedQueriesRequest\x1a-.desktop_server.v1.ExportSavedQueriesResponse\x12q\n" +
+	"\x12ImportSavedQueries\x12,.desktop_server.v1.ImportSavedQueriesRequest\x1a-.desktop_server.v1.ImportSavedQueriesResponse\x12k\n" +
+	"\x10ListQueryHistory\x12*.desktop_server.v1.ListQueryHistoryRequest\x1a+.desktop_server.v1.ListQueryHistoryResponse\x12n\n" +
+	"\x11ClearQueryHistory\x12+.desktop_server.v1.ClearQueryHistoryRequest\x1a,.desktop_server.v1.ClearQueryHistoryResponse\x12q\n" +
+	"\x12ListRunningQueries\x12,.desktop_server.v1.ListRunningQueriesRequest\x1a-.desktop_server.v1.ListRunningQueriesResponse\x12V\n" +
+	"\tKillQuery\x12#.desktop_server.v1.KillQueryRequest\x1a$.desktop_server.v1.KillQueryResponse\x12\\\n" +
+	"\vBrowseTable\x12%.desktop_server.v1.BrowseTableRequest\x1a&.desktop_server.v1.BrowseTableResponseB=Z;github.com/yhonda-ohishi-pub-dev/desktop-server/proto;protob\x06proto3"
+
+var (
+	file_database_proto_rawDescOnce sync.Once
+	file_database_proto_rawDescData []byte
+)
+
+func file_database_proto_rawDescGZIP() []byte {
+	file_database_proto_rawDescOnce.Do(func() {
+		file_database_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_database_proto_rawDesc), len(file_database_proto_rawDesc)))
+	})
+	return file_database_proto_rawDescData
+}
+
+var file_database_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_database_proto_msgTypes = make([]protoimpl.MessageInfo, 70)
+var file_database_proto_goTypes = []any{
+	(CellKind)(0),                      // 0: desktop_server.v1.CellKind
+	(FilterOp)(0),                      // 1: desktop_server.v1.FilterOp
+	(*SqlValue)(nil),                   // 2: desktop_server.v1.SqlValue
+	(*QueryRequest)(nil),               // 3: desktop_server.v1.QueryRequest
+	(*QueryResponse)(nil),              // 4: desktop_server.v1.QueryResponse
+	(*Row)(nil),                        // 5: desktop_server.v1.Row
+	(*ColumnMeta)(nil),                 // 6: desktop_server.v1.ColumnMeta
+	(*TypedRow)(nil),                   // 7: desktop_server.v1.TypedRow
+	(*Cell)(nil),                       // 8: desktop_server.v1.Cell
+	(*ZonedTimestamp)(nil),             // 9: desktop_server.v1.ZonedTimestamp
+	(*StreamQueryRequest)(nil),         // 10: desktop_server.v1.StreamQueryRequest
+	(*QueryRow)(nil),                   // 11: desktop_server.v1.QueryRow
+	(*GetTablesRequest)(nil),           // 12: desktop_server.v1.GetTablesRequest
+	(*GetTablesResponse)(nil),          // 13: desktop_server.v1.GetTablesResponse
+	(*ExecuteRequest)(nil),             // 14: desktop_server.v1.ExecuteRequest
+	(*ExecuteResponse)(nil),            // 15: desktop_server.v1.ExecuteResponse
+	(*GetSchemaRequest)(nil),           // 16: desktop_server.v1.GetSchemaRequest
+	(*GetSchemaResponse)(nil),          // 17: desktop_server.v1.GetSchemaResponse
+	(*DescribeTableRequest)(nil),       // 18: desktop_server.v1.DescribeTableRequest
+	(*TableInfo)(nil),                  // 19: desktop_server.v1.TableInfo
+	(*ColumnInfo)(nil),                 // 20: desktop_server.v1.ColumnInfo
+	(*IndexInfo)(nil),                  // 21: desktop_server.v1.IndexInfo
+	(*ForeignKeyInfo)(nil),             // 22: desktop_server.v1.ForeignKeyInfo
+	(*TableSchema)(nil),                // 23: desktop_server.v1.TableSchema
+	(*ExportQueryRequest)(nil),         // 24: desktop_server.v1.ExportQueryRequest
+	(*DatabaseProfile)(nil),            // 25: desktop_server.v1.DatabaseProfile
+	(*ListProfilesRequest)(nil),        // 26: desktop_server.v1.ListProfilesRequest
+	(*ListProfilesResponse)(nil),       // 27: desktop_server.v1.ListProfilesResponse
+	(*SaveProfileRequest)(nil),         // 28: desktop_server.v1.SaveProfileRequest
+	(*RemoveProfileRequest)(nil),       // 29: desktop_server.v1.RemoveProfileRequest
+	(*RemoveProfileResponse)(nil),      // 30: desktop_server.v1.RemoveProfileResponse
+	(*TestProfileRequest)(nil),         // 31: desktop_server.v1.TestProfileRequest
+	(*TestProfileResponse)(nil),        // 32: desktop_server.v1.TestProfileResponse
+	(*SelectProfileRequest)(nil),       // 33: desktop_server.v1.SelectProfileRequest
+	(*GetPoolStatsRequest)(nil),        // 34: desktop_server.v1.GetPoolStatsRequest
+	(*GetPoolStatsResponse)(nil),       // 35: desktop_server.v1.GetPoolStatsResponse
+	(*PoolStats)(nil),                  // 36: desktop_server.v1.PoolStats
+	(*SavedQuery)(nil),                 // 37: desktop_server.v1.SavedQuery
+	(*SavedQueryParam)(nil),            // 38: desktop_server.v1.SavedQueryParam
+	(*ListSavedQueriesRequest)(nil),    // 39: desktop_server.v1.ListSavedQueriesRequest
+	(*ListSavedQueriesResponse)(nil),   // 40: desktop_server.v1.ListSavedQueriesResponse
+	(*SaveQueryRequest)(nil),           // 41: desktop_server.v1.SaveQueryRequest
+	(*DeleteSavedQueryRequest)(nil),    // 42: desktop_server.v1.DeleteSavedQueryRequest
+	(*DeleteSavedQueryResponse)(nil),   // 43: desktop_server.v1.DeleteSavedQueryResponse
+	(*RunSavedQueryRequest)(nil),       // 44: desktop_server.v1.RunSavedQueryRequest
+	(*RunSavedQueryResponse)(nil),      // 45: desktop_server.v1.RunSavedQueryResponse
+	(*ExportSavedQueriesRequest)(nil),  // 46: desktop_server.v1.ExportSavedQueriesRequest
+	(*ExportSavedQueriesResponse)(nil), // 47: desktop_server.v1.ExportSavedQueriesResponse
+	(*ImportSavedQueriesRequest)(nil),  // 48: desktop_server.v1.ImportSavedQueriesRequest
+	(*ImportSavedQueriesResponse)(nil), // 49: desktop_server.v1.ImportSavedQueriesResponse
+	(*QueryHistoryEntry)(nil),          // 50: desktop_server.v1.QueryHistoryEntry
+	(*ListQueryHistoryRequest)(nil),    // 51: desktop_server.v1.ListQueryHistoryRequest
+	(*ListQueryHistoryResponse)(nil),   // 52: desktop_server.v1.ListQueryHistoryResponse
+	(*ClearQueryHistoryRequest)(nil),   // 53: desktop_server.v1.ClearQueryHistoryRequest
+	(*ClearQueryHistoryResponse)(nil),  // 54: desktop_server.v1.ClearQueryHistoryResponse
+	(*RunningQuery)(nil),               // 55: desktop_server.v1.RunningQuery
+	(*ListRunningQueriesRequest)(nil),  // 56: desktop_server.v1.ListRunningQueriesRequest
+	(*ListRunningQueriesResponse)(nil), // 57: desktop_server.v1.ListRunningQueriesResponse
+	(*KillQueryRequest)(nil),           // 58: desktop_server.v1.KillQueryRequest
+	(*KillQueryResponse)(nil),          // 59: desktop_server.v1.KillQueryResponse
+	(*BrowseFilter)(nil),               // 60: desktop_server.v1.BrowseFilter
+	(*BrowseTableRequest)(nil),         // 61: desktop_server.v1.BrowseTableRequest
+	(*BrowseTableResponse)(nil),        // 62: desktop_server.v1.BrowseTableResponse
+	(*BrowseCursor)(nil),               // 63: desktop_server.v1.BrowseCursor
+	(*ImportCsvRequest)(nil),           // 64: desktop_server.v1.ImportCsvRequest
+	(*ImportColumnMapping)(nil),        // 65: desktop_server.v1.ImportColumnMapping
+	(*ImportError)(nil),                // 66: desktop_server.v1.ImportError
+	(*ImportCsvResponse)(nil),          // 67: desktop_server.v1.ImportCsvResponse
+	nil,                                // 68: desktop_server.v1.Row.ColumnsEntry
+	nil,                                // 69: desktop_server.v1.QueryRow.ColumnsEntry
+	nil,                                // 70: desktop_server.v1.DatabaseProfile.ParamsEntry
+	nil,                                // 71: desktop_server.v1.RunSavedQueryRequest.ParamsEntry
+	(*timestamppb.Timestamp)(nil),      // 72: google.protobuf.Timestamp
+}
+var file_database_proto_depIdxs = []int32{
+	72, // 0: desktop_server.v1.SqlValue.time_value:type_name -> google.protobuf.Timestamp
+	2,  // 1: desktop_server.v1.QueryRequest.params:type_name -> desktop_server.v1.SqlValue
+	5,  // 2: desktop_server.v1.QueryResponse.rows:type_name -> desktop_server.v1.Row
+	6,  // 3: desktop_server.v1.QueryResponse.header:type_name -> desktop_server.v1.ColumnMeta
+	7,  // 4: desktop_server.v1.QueryResponse.typed_rows:type_name -> desktop_server.v1.TypedRow
+	68, // 5: desktop_server.v1.Row.columns:type_name -> desktop_server.v1.Row.ColumnsEntry
+	0,  // 6: desktop_server.v1.ColumnMeta.kind:type_name -> desktop_server.v1.CellKind
+	8,  // 7: desktop_server.v1.TypedRow.cells:type_name -> desktop_server.v1.Cell
+	9,  // 8: desktop_server.v1.Cell.timestamp_value:type_name -> desktop_server.v1.ZonedTimestamp
+	72, // 9: desktop_server.v1.ZonedTimestamp.time:type_name -> google.protobuf.Timestamp
+	2,  // 10: desktop_server.v1.StreamQueryRequest.params:type_name -> desktop_server.v1.SqlValue
+	69, // 11: desktop_server.v1.QueryRow.columns:type_name -> desktop_server.v1.QueryRow.ColumnsEntry
+	8,  // 12: desktop_server.v1.QueryRow.cells:type_name -> desktop_server.v1.Cell
+	6,  // 13: desktop_server.v1.QueryRow.header:type_name -> desktop_server.v1.ColumnMeta
+	2,  // 14: desktop_server.v1.ExecuteRequest.params:type_name -> desktop_server.v1.SqlValue
+	19, // 15: desktop_server.v1.GetSchemaResponse.tables:type_name -> desktop_server.v1.TableInfo
+	19, // 16: desktop_server.v1.TableSchema.table:type_name -> desktop_server.v1.TableInfo
+	20, // 17: desktop_server.v1.TableSchema.columns:type_name -> desktop_server.v1.ColumnInfo
+	21, // 18: desktop_server.v1.TableSchema.indexes:type_name -> desktop_server.v1.IndexInfo
+	22, // 19: desktop_server.v1.TableSchema.foreign_keys:type_name -> desktop_server.v1.ForeignKeyInfo
+	2,  // 20: desktop_server.v1.ExportQueryRequest.params:type_name -> desktop_server.v1.SqlValue
+	70, // 21: desktop_server.v1.DatabaseProfile.params:type_name -> desktop_server.v1.DatabaseProfile.ParamsEntry
+	25, // 22: desktop_server.v1.ListProfilesResponse.profiles:type_name -> desktop_server.v1.DatabaseProfile
+	25, // 23: desktop_server.v1.SaveProfileRequest.profile:type_name -> desktop_server.v1.DatabaseProfile
+	25, // 24: desktop_server.v1.TestProfileRequest.profile:type_name -> desktop_server.v1.DatabaseProfile
+	36, // 25: desktop_server.v1.GetPoolStatsResponse.pools:type_name -> desktop_server.v1.PoolStats
+	38, // 26: desktop_server.v1.SavedQuery.params:type_name -> desktop_server.v1.SavedQueryParam
+	37, // 27: desktop_server.v1.ListSavedQueriesResponse.queries:type_name -> desktop_server.v1.SavedQuery
+	37, // 28: desktop_server.v1.SaveQueryRequest.query:type_name -> desktop_server.v1.SavedQuery
+	71, // 29: desktop_server.v1.RunSavedQueryRequest.params:type_name -> desktop_server.v1.RunSavedQueryRequest.ParamsEntry
+	4,  // 30: desktop_server.v1.RunSavedQueryResponse.result:type_name -> desktop_server.v1.QueryResponse
+	15, // 31: desktop_server.v1.RunSavedQueryResponse.execute:type_name -> desktop_server.v1.ExecuteResponse
+	50, // 32: desktop_server.v1.ListQueryHistoryResponse.entries:type_name -> desktop_server.v1.QueryHistoryEntry
+	55, // 33: desktop_server.v1.ListRunningQueriesResponse.queries:type_name -> desktop_server.v1.RunningQuery
+	1,  // 34: desktop_server.v1.BrowseFilter.op:type_name -> desktop_server.v1.FilterOp
+	2,  // 35: desktop_server.v1.BrowseFilter.value:type_name -> desktop_server.v1.SqlValue
+	2,  // 36: desktop_server.v1.BrowseFilter.values:type_name -> desktop_server.v1.SqlValue
+	60, // 37: desktop_server.v1.BrowseTableRequest.filters:type_name -> desktop_server.v1.BrowseFilter
+	4,  // 38: desktop_server.v1.BrowseTableResponse.result:type_name -> desktop_server.v1.QueryResponse
+	2,  // 39: desktop_server.v1.BrowseCursor.key:type_name -> desktop_server.v1.SqlValue
+	65, // 40: desktop_server.v1.ImportCsvRequest.mappings:type_name -> desktop_server.v1.ImportColumnMapping
+	65, // 41: desktop_server.v1.ImportCsvResponse.mappings:type_name -> desktop_server.v1.ImportColumnMapping
+	66, // 42: desktop_server.v1.ImportCsvResponse.errors:type_name -> desktop_server.v1.ImportError
+	2,  // 43: desktop_server.v1.RunSavedQueryRequest.ParamsEntry.value:type_name -> desktop_server.v1.SqlValue
+	3,  // 44: desktop_server.v1.DatabaseService.QueryDatabase:input_type -> desktop_server.v1.QueryRequest
+	10, // 45: desktop_server.v1.DatabaseService.StreamQuery:input_type -> desktop_server.v1.StreamQueryRequest
+	12, // 46: desktop_server.v1.DatabaseService.GetTables:input_type -> desktop_server.v1.GetTablesRequest
+	14, // 47: desktop_server.v1.DatabaseService.ExecuteSQL:input_type -> desktop_server.v1.ExecuteRequest
+	16, // 48: desktop_server.v1.DatabaseService.GetSchema:input_type -> desktop_server.v1.GetSchemaRequest
+	18, // 49: desktop_server.v1.DatabaseService.DescribeTable:input_type -> desktop_server.v1.DescribeTableRequest
+	26, // 50: desktop_server.v1.DatabaseService.ListProfiles:input_type -> desktop_server.v1.ListProfilesRequest
+	28, // 51: desktop_server.v1.DatabaseService.SaveProfile:input_type -> desktop_server.v1.SaveProfileRequest
+	29, // 52: desktop_server.v1.DatabaseService.RemoveProfile:input_type -> desktop_server.v1.RemoveProfileRequest
+	31, // 53: desktop_server.v1.DatabaseService.TestProfile:input_type -> desktop_server.v1.TestProfileRequest
+	33, // 54: desktop_server.v1.DatabaseService.SelectProfile:input_type -> desktop_server.v1.SelectProfileRequest
+	34, // 55: desktop_server.v1.DatabaseService.GetPoolStats:input_type -> desktop_server.v1.GetPoolStatsRequest
+	39, // 56: desktop_server.v1.DatabaseService.ListSavedQueries:input_type -> desktop_server.v1.ListSavedQueriesRequest
+	41, // 57: desktop_server.v1.DatabaseService.SaveQuery:input_type -> desktop_server.v1.SaveQueryRequest
+	42, // 58: desktop_server.v1.DatabaseService.DeleteSavedQuery:input_type -> desktop_server.v1.DeleteSavedQueryRequest
+	44, // 59: desktop_server.v1.DatabaseService.RunSavedQuery:input_type -> desktop_server.v1.RunSavedQueryRequest
+	46, // 60: desktop_server.v1.DatabaseService.ExportSavedQueries:input_type -> desktop_server.v1.ExportSavedQueriesRequest
+	48, // 61: desktop_server.v1.DatabaseService.ImportSavedQueries:input_type -> desktop_server.v1.ImportSavedQueriesRequest
+	51, // 62: desktop_server.v1.DatabaseService.ListQueryHistory:input_type -> desktop_server.v1.ListQueryHistoryRequest
+	53, // 63: desktop_server.v1.DatabaseService.ClearQueryHistory:input_type -> desktop_server.v1.ClearQueryHistoryRequest
+	56, // 64: desktop_server.v1.DatabaseService.ListRunningQueries:input_type -> desktop_server.v1.ListRunningQueriesRequest
+	58, // 65: desktop_server.v1.DatabaseService.KillQuery:input_type -> desktop_server.v1.KillQueryRequest
+	61, // 66: desktop_server.v1.DatabaseService.BrowseTable:input_type -> desktop_server.v1.BrowseTableRequest
+	4,  // 67: desktop_server.v1.DatabaseService.QueryDatabase:output_type -> desktop_server.v1.QueryResponse
+	11, // 68: desktop_server.v1.DatabaseService.StreamQuery:output_type -> desktop_server.v1.QueryRow
+	13, // 69: desktop_server.v1.DatabaseService.GetTables:output_type -> desktop_server.v1.GetTablesResponse
+	15, // 70: desktop_server.v1.DatabaseService.ExecuteSQL:output_type -> desktop_server.v1.ExecuteResponse
+	17, // 71: desktop_server.v1.DatabaseService.GetSchema:output_type -> desktop_server.v1.GetSchemaResponse
+	23, // 72: desktop_server.v1.DatabaseService.DescribeTable:output_type -> desktop_server.v1.TableSchema
+	27, // 73: desktop_server.v1.DatabaseService.ListProfiles:output_type -> desktop_server.v1.ListProfilesResponse
+	25, // 74: desktop_server.v1.DatabaseService.SaveProfile:output_type -> desktop_server.v1.DatabaseProfile
+	30, // 75: desktop_server.v1.DatabaseService.RemoveProfile:output_type -> desktop_server.v1.RemoveProfileResponse
+	32, // 76: desktop_server.v1.DatabaseService.TestProfile:output_type -> desktop_server.v1.TestProfileResponse
+	25, // 77: desktop_server.v1.DatabaseService.SelectProfile:output_type -> desktop_server.v1.DatabaseProfile
+	35, // 78: desktop_server.v1.DatabaseService.GetPoolStats:output_type -> desktop_server.v1.GetPoolStatsResponse
+	40, // 79: desktop_server.v1.DatabaseService.ListSavedQueries:output_type -> desktop_server.v1.ListSavedQueriesResponse
+	37, // 80: desktop_server.v1.DatabaseService.SaveQuery:output_type -> desktop_server.v1.SavedQuery
+	43, // 81: desktop_server.v1.DatabaseService.DeleteSavedQuery:output_type -> desktop_server.v1.DeleteSavedQueryResponse
+	45, // 82: desktop_server.v1.DatabaseService.RunSavedQuery:output_type -> desktop_server.v1.RunSavedQueryResponse
+	47, // 83: desktop_server.v1.DatabaseService.ExportSavedQueries:output_type -> desktop_server.v1.ExportSavedQueriesResponse
+	49, // 84: desktop_server.v1.DatabaseService.ImportSavedQueries:output_type -> desktop_server.v1.ImportSavedQueriesResponse
+	52, // 85: desktop_server.v1.DatabaseService.ListQueryHistory:output_type -> desktop_server.v1.ListQueryHistoryResponse
+	54, // 86: desktop_server.v1.DatabaseService.ClearQueryHistory:output_type -> desktop_server.v1.ClearQueryHistoryResponse
+	57, // 87: desktop_server.v1.DatabaseService.ListRunningQueries:output_type -> desktop_server.v1.ListRunningQueriesResponse
+	59, // 88: desktop_server.v1.DatabaseService.KillQuery:output_type -> desktop_server.v1.KillQueryResponse
+	62, // 89: desktop_server.v1.DatabaseService.BrowseTable:output_type -> desktop_server.v1.BrowseTableResponse
+	67, // [67:90] is the sub-list for method output_type
+	44, // [44:67] is the sub-list for method input_type
+	44, // [44:44] is the sub-list for extension type_name
+	44, // [44:44] is the sub-list for extension extendee
+	0,  // [0:44] is the sub-list for field type_name
+}
+
+func init() { file_database_proto_init() }
+func file_database_proto_init() {
+	if File_database_proto != nil {
+		return
+	}
+	file_database_proto_msgTypes[0].OneofWrappers = []any{
+		(*SqlValue_NullValue)(nil),
+		(*SqlValue_StringValue)(nil),
+		(*SqlValue_IntValue)(nil),
+		(*SqlValue_DoubleValue)(nil),
+		(*SqlValue_BoolValue)(nil),
+		(*SqlValue_BytesValue)(nil),
+		(*SqlValue_TimeValue)(nil),
+	}
+	file_database_proto_msgTypes[4].OneofWrappers = []any{}
+	file_database_proto_msgTypes[6].OneofWrappers = []any{
+		(*Cell_NullValue)(nil),
+		(*Cell_IntValue)(nil),
+		(*Cell_DecimalValue)(nil),
+		(*Cell_FloatValue)(nil),
+		(*Cell_BoolValue)(nil),
+		(*Cell_StringValue)(nil),
+		(*Cell_BytesValue)(nil),
+		(*Cell_TimestampValue)(nil),
+	}
+	file_database_proto_msgTypes[18].OneofWrappers = []any{}
+	file_database_proto_msgTypes[36].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_database_proto_rawDesc), len(file_database_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   70,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_database_proto_goTypes,
+		DependencyIndexes: file_database_proto_depIdxs,
+		EnumInfos:         file_database_proto_enumTypes,
+		MessageInfos:      file_database_proto_msgTypes,
+	}.Build()
+	File_database_proto = out.File
+	file_database_proto_goTypes = nil
+	file_database_proto_depIdxs = nil
+}
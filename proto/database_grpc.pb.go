@@ -0,0 +1,1011 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: database.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DatabaseService_QueryDatabase_FullMethodName      = "/desktop_server.v1.DatabaseService/QueryDatabase"
+	DatabaseService_StreamQuery_FullMethodName        = "/desktop_server.v1.DatabaseService/StreamQuery"
+	DatabaseService_GetTables_FullMethodName          = "/desktop_server.v1.DatabaseService/GetTables"
+	DatabaseService_ExecuteSQL_FullMethodName         = "/desktop_server.v1.DatabaseService/ExecuteSQL"
+	DatabaseService_GetSchema_FullMethodName          = "/desktop_server.v1.DatabaseService/GetSchema"
+	DatabaseService_DescribeTable_FullMethodName      = "/desktop_server.v1.DatabaseService/DescribeTable"
+	DatabaseService_ListProfiles_FullMethodName       = "/desktop_server.v1.DatabaseService/ListProfiles"
+	DatabaseService_SaveProfile_FullMethodName        = "/desktop_server.v1.DatabaseService/SaveProfile"
+	DatabaseService_RemoveProfile_FullMethodName      = "/desktop_server.v1.DatabaseService/RemoveProfile"
+	DatabaseService_TestProfile_FullMethodName        = "/desktop_server.v1.DatabaseService/TestProfile"
+	DatabaseService_SelectProfile_FullMethodName      = "/desktop_server.v1.DatabaseService/SelectProfile"
+	DatabaseService_GetPoolStats_FullMethodName       = "/desktop_server.v1.DatabaseService/GetPoolStats"
+	DatabaseService_ListSavedQueries_FullMethodName   = "/desktop_server.v1.DatabaseService/ListSavedQueries"
+	DatabaseService_SaveQuery_FullMethodName          = "/desktop_server.v1.DatabaseService/SaveQuery"
+	DatabaseService_DeleteSavedQuery_FullMethodName   = "/desktop_server.v1.DatabaseService/DeleteSavedQuery"
+	DatabaseService_RunSavedQuery_FullMethodName      = "/desktop_server.v1.DatabaseService/RunSavedQuery"
+	DatabaseService_ExportSavedQueries_FullMethodName = "/desktop_server.v1.DatabaseService/ExportSavedQueries"
+	DatabaseService_ImportSavedQueries_FullMethodName = "/desktop_server.v1.DatabaseService/ImportSavedQueries"
+	DatabaseService_ListQueryHistory_FullMethodName   = "/desktop_server.v1.DatabaseService/ListQueryHistory"
+	DatabaseService_ClearQueryHistory_FullMethodName  = "/desktop_server.v1.DatabaseService/ClearQueryHistory"
+	DatabaseService_ListRunningQueries_FullMethodName = "/desktop_server.v1.DatabaseService/ListRunningQueries"
+	DatabaseService_KillQuery_FullMethodName          = "/desktop_server.v1.DatabaseService/KillQuery"
+	DatabaseService_BrowseTable_FullMethodName        = "/desktop_server.v1.DatabaseService/BrowseTable"
+)
+
+// DatabaseServiceClient is the client API for DatabaseService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// データベースサービス（ローカルDBへのクエリ実行）
+type DatabaseServiceClient interface {
+	// クエリを実行して結果をまとめて取得（max_rowsで件数を制限）
+	QueryDatabase(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error)
+	// クエリを実行して結果を1行ずつストリーミング（大きな結果向け）
+	StreamQuery(ctx context.Context, in *StreamQueryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[QueryRow], error)
+	// テーブル一覧を取得
+	GetTables(ctx context.Context, in *GetTablesRequest, opts ...grpc.CallOption) (*GetTablesResponse, error)
+	// INSERT/UPDATE/DELETEなどを実行
+	ExecuteSQL(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (*ExecuteResponse, error)
+	// テーブル・ビューの一覧を推定行数つきで取得
+	GetSchema(ctx context.Context, in *GetSchemaRequest, opts ...grpc.CallOption) (*GetSchemaResponse, error)
+	// テーブル・ビューの列、主キー、インデックス、外部キーを取得
+	DescribeTable(ctx context.Context, in *DescribeTableRequest, opts ...grpc.CallOption) (*TableSchema, error)
+	// 接続プロファイル一覧を取得（パスワードは返さない）
+	ListProfiles(ctx context.Context, in *ListProfilesRequest, opts ...grpc.CallOption) (*ListProfilesResponse, error)
+	// 接続プロファイルを追加・更新（同名のプロファイルは置き換え）
+	SaveProfile(ctx context.Context, in *SaveProfileRequest, opts ...grpc.CallOption) (*DatabaseProfile, error)
+	// 接続プロファイルを削除
+	RemoveProfile(ctx context.Context, in *RemoveProfileRequest, opts ...grpc.CallOption) (*RemoveProfileResponse, error)
+	// 接続テスト（保存前のプロファイルも可）
+	TestProfile(ctx context.Context, in *TestProfileRequest, opts ...grpc.CallOption) (*TestProfileResponse, error)
+	// 既定で使う接続プロファイルを選択
+	SelectProfile(ctx context.Context, in *SelectProfileRequest, opts ...grpc.CallOption) (*DatabaseProfile, error)
+	// 接続中のプロファイルごとの接続プールの状態（sql.DBStats）を取得
+	GetPoolStats(ctx context.Context, in *GetPoolStatsRequest, opts ...grpc.CallOption) (*GetPoolStatsResponse, error)
+	// 保存済みクエリの一覧を取得（検索・タグで絞り込み）
+	ListSavedQueries(ctx context.Context, in *ListSavedQueriesRequest, opts ...grpc.CallOption) (*ListSavedQueriesResponse, error)
+	// クエリを保存（同名のクエリは置き換え）
+	SaveQuery(ctx context.Context, in *SaveQueryRequest, opts ...grpc.CallOption) (*SavedQuery, error)
+	// 保存済みクエリを削除
+	DeleteSavedQuery(ctx context.Context, in *DeleteSavedQueryRequest, opts ...grpc.CallOption) (*DeleteSavedQueryResponse, error)
+	// 保存済みクエリを名前とパラメータを指定して実行
+	RunSavedQuery(ctx context.Context, in *RunSavedQueryRequest, opts ...grpc.CallOption) (*RunSavedQueryResponse, error)
+	// 保存済みクエリを共有用のJSONファイルとして出力
+	ExportSavedQueries(ctx context.Context, in *ExportSavedQueriesRequest, opts ...grpc.CallOption) (*ExportSavedQueriesResponse, error)
+	// ExportSavedQueriesで出力したファイルを取り込み
+	ImportSavedQueries(ctx context.Context, in *ImportSavedQueriesRequest, opts ...grpc.CallOption) (*ImportSavedQueriesResponse, error)
+	// クエリ履歴を取得（新しい順）
+	ListQueryHistory(ctx context.Context, in *ListQueryHistoryRequest, opts ...grpc.CallOption) (*ListQueryHistoryResponse, error)
+	// クエリ履歴を削除
+	ClearQueryHistory(ctx context.Context, in *ClearQueryHistoryRequest, opts ...grpc.CallOption) (*ClearQueryHistoryResponse, error)
+	// 実行中のクエリ一覧を取得
+	ListRunningQueries(ctx context.Context, in *ListRunningQueriesRequest, opts ...grpc.CallOption) (*ListRunningQueriesResponse, error)
+	// 実行中のクエリを中止
+	KillQuery(ctx context.Context, in *KillQueryRequest, opts ...grpc.CallOption) (*KillQueryResponse, error)
+	// テーブルをキー順にページ単位で取得（キーセットページング）
+	BrowseTable(ctx context.Context, in *BrowseTableRequest, opts ...grpc.CallOption) (*BrowseTableResponse, error)
+}
+
+type databaseServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDatabaseServiceClient(cc grpc.ClientConnInterface) DatabaseServiceClient {
+	return &databaseServiceClient{cc}
+}
+
+func (c *databaseServiceClient) QueryDatabase(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryResponse)
+	err := c.cc.Invoke(ctx, DatabaseService_QueryDatabase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) StreamQuery(ctx context.Context, in *StreamQueryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[QueryRow], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DatabaseService_ServiceDesc.Streams[0], DatabaseService_StreamQuery_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamQueryRequest, QueryRow]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DatabaseService_StreamQueryClient = grpc.ServerStreamingClient[QueryRow]
+
+func (c *databaseServiceClient) GetTables(ctx context.Context, in *GetTablesRequest, opts ...grpc.CallOption) (*GetTablesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTablesResponse)
+	err := c.cc.Invoke(ctx, DatabaseService_GetTables_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) ExecuteSQL(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (*ExecuteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExecuteResponse)
+	err := c.cc.Invoke(ctx, DatabaseService_ExecuteSQL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) GetSchema(ctx context.Context, in *GetSchemaRequest, opts ...grpc.CallOption) (*GetSchemaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSchemaResponse)
+	err := c.cc.Invoke(ctx, DatabaseService_GetSchema_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) DescribeTable(ctx context.Context, in *DescribeTableRequest, opts ...grpc.CallOption) (*TableSchema, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TableSchema)
+	err := c.cc.Invoke(ctx, DatabaseService_DescribeTable_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) ListProfiles(ctx context.Context, in *ListProfilesRequest, opts ...grpc.CallOption) (*ListProfilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProfilesResponse)
+	err := c.cc.Invoke(ctx, DatabaseService_ListProfiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) SaveProfile(ctx context.Context, in *SaveProfileRequest, opts ...grpc.CallOption) (*DatabaseProfile, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DatabaseProfile)
+	err := c.cc.Invoke(ctx, DatabaseService_SaveProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) RemoveProfile(ctx context.Context, in *RemoveProfileRequest, opts ...grpc.CallOption) (*RemoveProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveProfileResponse)
+	err := c.cc.Invoke(ctx, DatabaseService_RemoveProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) TestProfile(ctx context.Context, in *TestProfileRequest, opts ...grpc.CallOption) (*TestProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestProfileResponse)
+	err := c.cc.Invoke(ctx, DatabaseService_TestProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) SelectProfile(ctx context.Context, in *SelectProfileRequest, opts ...grpc.CallOption) (*DatabaseProfile, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DatabaseProfile)
+	err := c.cc.Invoke(ctx, DatabaseService_SelectProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) GetPoolStats(ctx context.Context, in *GetPoolStatsRequest, opts ...grpc.CallOption) (*GetPoolStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPoolStatsResponse)
+	err := c.cc.Invoke(ctx, DatabaseService_GetPoolStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) ListSavedQueries(ctx context.Context, in *ListSavedQueriesRequest, opts ...grpc.CallOption) (*ListSavedQueriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSavedQueriesResponse)
+	err := c.cc.Invoke(ctx, DatabaseService_ListSavedQueries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) SaveQuery(ctx context.Context, in *SaveQueryRequest, opts ...grpc.CallOption) (*SavedQuery, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SavedQuery)
+	err := c.cc.Invoke(ctx, DatabaseService_SaveQuery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) DeleteSavedQuery(ctx context.Context, in *DeleteSavedQueryRequest, opts ...grpc.CallOption) (*DeleteSavedQueryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSavedQueryResponse)
+	err := c.cc.Invoke(ctx, DatabaseService_DeleteSavedQuery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) RunSavedQuery(ctx context.Context, in *RunSavedQueryRequest, opts ...grpc.CallOption) (*RunSavedQueryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunSavedQueryResponse)
+	err := c.cc.Invoke(ctx, DatabaseService_RunSavedQuery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) ExportSavedQueries(ctx context.Context, in *ExportSavedQueriesRequest, opts ...grpc.CallOption) (*ExportSavedQueriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportSavedQueriesResponse)
+	err := c.cc.Invoke(ctx, DatabaseService_ExportSavedQueries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) ImportSavedQueries(ctx context.Context, in *ImportSavedQueriesRequest, opts ...grpc.CallOption) (*ImportSavedQueriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportSavedQueriesResponse)
+	err := c.cc.Invoke(ctx, DatabaseService_ImportSavedQueries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) ListQueryHistory(ctx context.Context, in *ListQueryHistoryRequest, opts ...grpc.CallOption) (*ListQueryHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListQueryHistoryResponse)
+	err := c.cc.Invoke(ctx, DatabaseService_ListQueryHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) ClearQueryHistory(ctx context.Context, in *ClearQueryHistoryRequest, opts ...grpc.CallOption) (*ClearQueryHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClearQueryHistoryResponse)
+	err := c.cc.Invoke(ctx, DatabaseService_ClearQueryHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) ListRunningQueries(ctx context.Context, in *ListRunningQueriesRequest, opts ...grpc.CallOption) (*ListRunningQueriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRunningQueriesResponse)
+	err := c.cc.Invoke(ctx, DatabaseService_ListRunningQueries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) KillQuery(ctx context.Context, in *KillQueryRequest, opts ...grpc.CallOption) (*KillQueryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KillQueryResponse)
+	err := c.cc.Invoke(ctx, DatabaseService_KillQuery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) BrowseTable(ctx context.Context, in *BrowseTableRequest, opts ...grpc.CallOption) (*BrowseTableResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BrowseTableResponse)
+	err := c.cc.Invoke(ctx, DatabaseService_BrowseTable_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DatabaseServiceServer is the server API for DatabaseService service.
+// All implementations must embed UnimplementedDatabaseServiceServer
+// for forward compatibility.
+//
+// データベースサービス（ローカルDBへのクエリ実行）
+type DatabaseServiceServer interface {
+	// クエリを実行して結果をまとめて取得（max_rowsで件数を制限）
+	QueryDatabase(context.Context, *QueryRequest) (*QueryResponse, error)
+	// クエリを実行して結果を1行ずつストリーミング（大きな結果向け）
+	StreamQuery(*StreamQueryRequest, grpc.ServerStreamingServer[QueryRow]) error
+	// テーブル一覧を取得
+	GetTables(context.Context, *GetTablesRequest) (*GetTablesResponse, error)
+	// INSERT/UPDATE/DELETEなどを実行
+	ExecuteSQL(context.Context, *ExecuteRequest) (*ExecuteResponse, error)
+	// テーブル・ビューの一覧を推定行数つきで取得
+	GetSchema(context.Context, *GetSchemaRequest) (*GetSchemaResponse, error)
+	// テーブル・ビューの列、主キー、インデックス、外部キーを取得
+	DescribeTable(context.Context, *DescribeTableRequest) (*TableSchema, error)
+	// 接続プロファイル一覧を取得（パスワードは返さない）
+	ListProfiles(context.Context, *ListProfilesRequest) (*ListProfilesResponse, error)
+	// 接続プロファイルを追加・更新（同名のプロファイルは置き換え）
+	SaveProfile(context.Context, *SaveProfileRequest) (*DatabaseProfile, error)
+	// 接続プロファイルを削除
+	RemoveProfile(context.Context, *RemoveProfileRequest) (*RemoveProfileResponse, error)
+	// 接続テスト（保存前のプロファイルも可）
+	TestProfile(context.Context, *TestProfileRequest) (*TestProfileResponse, error)
+	// 既定で使う接続プロファイルを選択
+	SelectProfile(context.Context, *SelectProfileRequest) (*DatabaseProfile, error)
+	// 接続中のプロファイルごとの接続プールの状態（sql.DBStats）を取得
+	GetPoolStats(context.Context, *GetPoolStatsRequest) (*GetPoolStatsResponse, error)
+	// 保存済みクエリの一覧を取得（検索・タグで絞り込み）
+	ListSavedQueries(context.Context, *ListSavedQueriesRequest) (*ListSavedQueriesResponse, error)
+	// クエリを保存（同名のクエリは置き換え）
+	SaveQuery(context.Context, *SaveQueryRequest) (*SavedQuery, error)
+	// 保存済みクエリを削除
+	DeleteSavedQuery(context.Context, *DeleteSavedQueryRequest) (*DeleteSavedQueryResponse, error)
+	// 保存済みクエリを名前とパラメータを指定して実行
+	RunSavedQuery(context.Context, *RunSavedQueryRequest) (*RunSavedQueryResponse, error)
+	// 保存済みクエリを共有用のJSONファイルとして出力
+	ExportSavedQueries(context.Context, *ExportSavedQueriesRequest) (*ExportSavedQueriesResponse, error)
+	// ExportSavedQueriesで出力したファイルを取り込み
+	ImportSavedQueries(context.Context, *ImportSavedQueriesRequest) (*ImportSavedQueriesResponse, error)
+	// クエリ履歴を取得（新しい順）
+	ListQueryHistory(context.Context, *ListQueryHistoryRequest) (*ListQueryHistoryResponse, error)
+	// クエリ履歴を削除
+	ClearQueryHistory(context.Context, *ClearQueryHistoryRequest) (*ClearQueryHistoryResponse, error)
+	// 実行中のクエリ一覧を取得
+	ListRunningQueries(context.Context, *ListRunningQueriesRequest) (*ListRunningQueriesResponse, error)
+	// 実行中のクエリを中止
+	KillQuery(context.Context, *KillQueryRequest) (*KillQueryResponse, error)
+	// テーブルをキー順にページ単位で取得（キーセットページング）
+	BrowseTable(context.Context, *BrowseTableRequest) (*BrowseTableResponse, error)
+	mustEmbedUnimplementedDatabaseServiceServer()
+}
+
+// UnimplementedDatabaseServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDatabaseServiceServer struct{}
+
+func (UnimplementedDatabaseServiceServer) QueryDatabase(context.Context, *QueryRequest) (*QueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryDatabase not implemented")
+}
+func (UnimplementedDatabaseServiceServer) StreamQuery(*StreamQueryRequest, grpc.ServerStreamingServer[QueryRow]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamQuery not implemented")
+}
+func (UnimplementedDatabaseServiceServer) GetTables(context.Context, *GetTablesRequest) (*GetTablesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTables not implemented")
+}
+func (UnimplementedDatabaseServiceServer) ExecuteSQL(context.Context, *ExecuteRequest) (*ExecuteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteSQL not implemented")
+}
+func (UnimplementedDatabaseServiceServer) GetSchema(context.Context, *GetSchemaRequest) (*GetSchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchema not implemented")
+}
+func (UnimplementedDatabaseServiceServer) DescribeTable(context.Context, *DescribeTableRequest) (*TableSchema, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeTable not implemented")
+}
+func (UnimplementedDatabaseServiceServer) ListProfiles(context.Context, *ListProfilesRequest) (*ListProfilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProfiles not implemented")
+}
+func (UnimplementedDatabaseServiceServer) SaveProfile(context.Context, *SaveProfileRequest) (*DatabaseProfile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveProfile not implemented")
+}
+func (UnimplementedDatabaseServiceServer) RemoveProfile(context.Context, *RemoveProfileRequest) (*RemoveProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveProfile not implemented")
+}
+func (UnimplementedDatabaseServiceServer) TestProfile(context.Context, *TestProfileRequest) (*TestProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestProfile not implemented")
+}
+func (UnimplementedDatabaseServiceServer) SelectProfile(context.Context, *SelectProfileRequest) (*DatabaseProfile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SelectProfile not implemented")
+}
+func (UnimplementedDatabaseServiceServer) GetPoolStats(context.Context, *GetPoolStatsRequest) (*GetPoolStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPoolStats not implemented")
+}
+func (UnimplementedDatabaseServiceServer) ListSavedQueries(context.Context, *ListSavedQueriesRequest) (*ListSavedQueriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSavedQueries not implemented")
+}
+func (UnimplementedDatabaseServiceServer) SaveQuery(context.Context, *SaveQueryRequest) (*SavedQuery, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveQuery not implemented")
+}
+func (UnimplementedDatabaseServiceServer) DeleteSavedQuery(context.Context, *DeleteSavedQueryRequest) (*DeleteSavedQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSavedQuery not implemented")
+}
+func (UnimplementedDatabaseServiceServer) RunSavedQuery(context.Context, *RunSavedQueryRequest) (*RunSavedQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunSavedQuery not implemented")
+}
+func (UnimplementedDatabaseServiceServer) ExportSavedQueries(context.Context, *ExportSavedQueriesRequest) (*ExportSavedQueriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportSavedQueries not implemented")
+}
+func (UnimplementedDatabaseServiceServer) ImportSavedQueries(context.Context, *ImportSavedQueriesRequest) (*ImportSavedQueriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportSavedQueries not implemented")
+}
+func (UnimplementedDatabaseServiceServer) ListQueryHistory(context.Context, *ListQueryHistoryRequest) (*ListQueryHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListQueryHistory not implemented")
+}
+func (UnimplementedDatabaseServiceServer) ClearQueryHistory(context.Context, *ClearQueryHistoryRequest) (*ClearQueryHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearQueryHistory not implemented")
+}
+func (UnimplementedDatabaseServiceServer) ListRunningQueries(context.Context, *ListRunningQueriesRequest) (*ListRunningQueriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRunningQueries not implemented")
+}
+func (UnimplementedDatabaseServiceServer) KillQuery(context.Context, *KillQueryRequest) (*KillQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KillQuery not implemented")
+}
+func (UnimplementedDatabaseServiceServer) BrowseTable(context.Context, *BrowseTableRequest) (*BrowseTableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BrowseTable not implemented")
+}
+func (UnimplementedDatabaseServiceServer) mustEmbedUnimplementedDatabaseServiceServer() {}
+func (UnimplementedDatabaseServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeDatabaseServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DatabaseServiceServer will
+// result in compilation errors.
+type UnsafeDatabaseServiceServer interface {
+	mustEmbedUnimplementedDatabaseServiceServer()
+}
+
+func RegisterDatabaseServiceServer(s grpc.ServiceRegistrar, srv DatabaseServiceServer) {
+	// If the following call pancis, it indicates UnimplementedDatabaseServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DatabaseService_ServiceDesc, srv)
+}
+
+func _DatabaseService_QueryDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).QueryDatabase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_QueryDatabase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).QueryDatabase(ctx, req.(*QueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_StreamQuery_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamQueryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DatabaseServiceServer).StreamQuery(m, &grpc.GenericServerStream[StreamQueryRequest, QueryRow]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DatabaseService_StreamQueryServer = grpc.ServerStreamingServer[QueryRow]
+
+func _DatabaseService_GetTables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTablesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).GetTables(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_GetTables_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).GetTables(ctx, req.(*GetTablesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_ExecuteSQL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).ExecuteSQL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_ExecuteSQL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).ExecuteSQL(ctx, req.(*ExecuteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_GetSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).GetSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_GetSchema_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).GetSchema(ctx, req.(*GetSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_DescribeTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).DescribeTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_DescribeTable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).DescribeTable(ctx, req.(*DescribeTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_ListProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProfilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).ListProfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_ListProfiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).ListProfiles(ctx, req.(*ListProfilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_SaveProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).SaveProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_SaveProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).SaveProfile(ctx, req.(*SaveProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_RemoveProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).RemoveProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_RemoveProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).RemoveProfile(ctx, req.(*RemoveProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_TestProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).TestProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_TestProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).TestProfile(ctx, req.(*TestProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_SelectProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelectProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).SelectProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_SelectProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).SelectProfile(ctx, req.(*SelectProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_GetPoolStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPoolStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).GetPoolStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_GetPoolStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).GetPoolStats(ctx, req.(*GetPoolStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_ListSavedQueries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSavedQueriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).ListSavedQueries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_ListSavedQueries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).ListSavedQueries(ctx, req.(*ListSavedQueriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_SaveQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).SaveQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_SaveQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).SaveQuery(ctx, req.(*SaveQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_DeleteSavedQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSavedQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).DeleteSavedQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_DeleteSavedQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).DeleteSavedQuery(ctx, req.(*DeleteSavedQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_RunSavedQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunSavedQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).RunSavedQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_RunSavedQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).RunSavedQuery(ctx, req.(*RunSavedQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_ExportSavedQueries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportSavedQueriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).ExportSavedQueries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_ExportSavedQueries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).ExportSavedQueries(ctx, req.(*ExportSavedQueriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_ImportSavedQueries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportSavedQueriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).ImportSavedQueries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_ImportSavedQueries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).ImportSavedQueries(ctx, req.(*ImportSavedQueriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_ListQueryHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQueryHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).ListQueryHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_ListQueryHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).ListQueryHistory(ctx, req.(*ListQueryHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_ClearQueryHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearQueryHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).ClearQueryHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_ClearQueryHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).ClearQueryHistory(ctx, req.(*ClearQueryHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_ListRunningQueries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRunningQueriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).ListRunningQueries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_ListRunningQueries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).ListRunningQueries(ctx, req.(*ListRunningQueriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_KillQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KillQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).KillQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_KillQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).KillQuery(ctx, req.(*KillQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_BrowseTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BrowseTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).BrowseTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_BrowseTable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).BrowseTable(ctx, req.(*BrowseTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DatabaseService_ServiceDesc is the grpc.ServiceDesc for DatabaseService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DatabaseService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "desktop_server.v1.DatabaseService",
+	HandlerType: (*DatabaseServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "QueryDatabase",
+			Handler:    _DatabaseService_QueryDatabase_Handler,
+		},
+		{
+			MethodName: "GetTables",
+			Handler:    _DatabaseService_GetTables_Handler,
+		},
+		{
+			MethodName: "ExecuteSQL",
+			Handler:    _DatabaseService_ExecuteSQL_Handler,
+		},
+		{
+			MethodName: "GetSchema",
+			Handler:    _DatabaseService_GetSchema_Handler,
+		},
+		{
+			MethodName: "DescribeTable",
+			Handler:    _DatabaseService_DescribeTable_Handler,
+		},
+		{
+			MethodName: "ListProfiles",
+			Handler:    _DatabaseService_ListProfiles_Handler,
+		},
+		{
+			MethodName: "SaveProfile",
+			Handler:    _DatabaseService_SaveProfile_Handler,
+		},
+		{
+			MethodName: "RemoveProfile",
+			Handler:    _DatabaseService_RemoveProfile_Handler,
+		},
+		{
+			MethodName: "TestProfile",
+			Handler:    _DatabaseService_TestProfile_Handler,
+		},
+		{
+			MethodName: "SelectProfile",
+			Handler:    _DatabaseService_SelectProfile_Handler,
+		},
+		{
+			MethodName: "GetPoolStats",
+			Handler:    _DatabaseService_GetPoolStats_Handler,
+		},
+		{
+			MethodName: "ListSavedQueries",
+			Handler:    _DatabaseService_ListSavedQueries_Handler,
+		},
+		{
+			MethodName: "SaveQuery",
+			Handler:    _DatabaseService_SaveQuery_Handler,
+		},
+		{
+			MethodName: "DeleteSavedQuery",
+			Handler:    _DatabaseService_DeleteSavedQuery_Handler,
+		},
+		{
+			MethodName: "RunSavedQuery",
+			Handler:    _DatabaseService_RunSavedQuery_Handler,
+		},
+		{
+			MethodName: "ExportSavedQueries",
+			Handler:    _DatabaseService_ExportSavedQueries_Handler,
+		},
+		{
+			MethodName: "ImportSavedQueries",
+			Handler:    _DatabaseService_ImportSavedQueries_Handler,
+		},
+		{
+			MethodName: "ListQueryHistory",
+			Handler:    _DatabaseService_ListQueryHistory_Handler,
+		},
+		{
+			MethodName: "ClearQueryHistory",
+			Handler:    _DatabaseService_ClearQueryHistory_Handler,
+		},
+		{
+			MethodName: "ListRunningQueries",
+			Handler:    _DatabaseService_ListRunningQueries_Handler,
+		},
+		{
+			MethodName: "KillQuery",
+			Handler:    _DatabaseService_KillQuery_Handler,
+		},
+		{
+			MethodName: "BrowseTable",
+			Handler:    _DatabaseService_BrowseTable_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamQuery",
+			Handler:       _DatabaseService_StreamQuery_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "database.proto",
+}
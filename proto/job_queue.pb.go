@@ -0,0 +1,621 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: job_queue.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ジョブ登録リクエスト
+type SubmitJobRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ジョブの種類（例: "etc_download"）
+	JobType string `protobuf:"bytes,1,opt,name=job_type,json=jobType,proto3" json:"job_type,omitempty"`
+	// 優先度（大きいほど先に実行）
+	Priority int32 `protobuf:"varint,2,opt,name=priority,proto3" json:"priority,omitempty"`
+	// 同時実行グループ（空の場合はジョブの種類の既定グループ）
+	ConcurrencyGroup string `protobuf:"bytes,3,opt,name=concurrency_group,json=concurrencyGroup,proto3" json:"concurrency_group,omitempty"`
+	// ジョブのパラメータ
+	Params map[string]string `protobuf:"bytes,4,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// ジョブID（空の場合は自動採番）
+	JobId         string `protobuf:"bytes,5,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitJobRequest) Reset() {
+	*x = SubmitJobRequest{}
+	mi := &file_job_queue_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitJobRequest) ProtoMessage() {}
+
+func (x *SubmitJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_job_queue_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitJobRequest.ProtoReflect.Descriptor instead.
+func (*SubmitJobRequest) Descriptor() ([]byte, []int) {
+	return file_job_queue_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubmitJobRequest) GetJobType() string {
+	if x != nil {
+		return x.JobType
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *SubmitJobRequest) GetConcurrencyGroup() string {
+	if x != nil {
+		return x.ConcurrencyGroup
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *SubmitJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// キュー上のジョブ
+type QueuedJob struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	JobId            string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	JobType          string                 `protobuf:"bytes,2,opt,name=job_type,json=jobType,proto3" json:"job_type,omitempty"`
+	ConcurrencyGroup string                 `protobuf:"bytes,3,opt,name=concurrency_group,json=concurrencyGroup,proto3" json:"concurrency_group,omitempty"`
+	Priority         int32                  `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	// 状態（JOB_STATE_QUEUEDまたは実行中の状態）
+	State JobState `protobuf:"varint,5,opt,name=state,proto3,enum=desktop_server.v1.JobState" json:"state,omitempty"`
+	// 実行待ちの順番（1始まり、実行中の場合は0）
+	Position int32 `protobuf:"varint,6,opt,name=position,proto3" json:"position,omitempty"`
+	// 登録時刻（Unix秒）
+	SubmittedAt int64             `protobuf:"varint,7,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"`
+	Params      map[string]string `protobuf:"bytes,8,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// これまでの試行回数（各試行は parent_job_id が job_id のサブジョブとして通知）
+	Attempts int32 `protobuf:"varint,9,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// 1回の実行での最大試行回数（再試行ポリシーによる）
+	MaxAttempts int32 `protobuf:"varint,10,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	// 最後の試行のエラー
+	LastError string `protobuf:"bytes,11,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// 最後のエラーの分類（"timeout"、"network"、"process"、"permanent"、"unknown"）
+	LastErrorClass string `protobuf:"bytes,12,opt,name=last_error_class,json=lastErrorClass,proto3" json:"last_error_class,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *QueuedJob) Reset() {
+	*x = QueuedJob{}
+	mi := &file_job_queue_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueuedJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueuedJob) ProtoMessage() {}
+
+func (x *QueuedJob) ProtoReflect() protoreflect.Message {
+	mi := &file_job_queue_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueuedJob.ProtoReflect.Descriptor instead.
+func (*QueuedJob) Descriptor() ([]byte, []int) {
+	return file_job_queue_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *QueuedJob) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *QueuedJob) GetJobType() string {
+	if x != nil {
+		return x.JobType
+	}
+	return ""
+}
+
+func (x *QueuedJob) GetConcurrencyGroup() string {
+	if x != nil {
+		return x.ConcurrencyGroup
+	}
+	return ""
+}
+
+func (x *QueuedJob) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *QueuedJob) GetState() JobState {
+	if x != nil {
+		return x.State
+	}
+	return JobState_JOB_STATE_UNSPECIFIED
+}
+
+func (x *QueuedJob) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *QueuedJob) GetSubmittedAt() int64 {
+	if x != nil {
+		return x.SubmittedAt
+	}
+	return 0
+}
+
+func (x *QueuedJob) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *QueuedJob) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *QueuedJob) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *QueuedJob) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *QueuedJob) GetLastErrorClass() string {
+	if x != nil {
+		return x.LastErrorClass
+	}
+	return ""
+}
+
+// キュー一覧リクエスト
+type ListQueueRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 同時実行グループで絞り込み（空の場合はすべて）
+	ConcurrencyGroup string `protobuf:"bytes,1,opt,name=concurrency_group,json=concurrencyGroup,proto3" json:"concurrency_group,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListQueueRequest) Reset() {
+	*x = ListQueueRequest{}
+	mi := &file_job_queue_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQueueRequest) ProtoMessage() {}
+
+func (x *ListQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_job_queue_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQueueRequest.ProtoReflect.Descriptor instead.
+func (*ListQueueRequest) Descriptor() ([]byte, []int) {
+	return file_job_queue_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListQueueRequest) GetConcurrencyGroup() string {
+	if x != nil {
+		return x.ConcurrencyGroup
+	}
+	return ""
+}
+
+// キュー一覧レスポンス
+type ListQueueResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// グループごとに実行中、実行待ちの順
+	Jobs          []*QueuedJob `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQueueResponse) Reset() {
+	*x = ListQueueResponse{}
+	mi := &file_job_queue_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQueueResponse) ProtoMessage() {}
+
+func (x *ListQueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_job_queue_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQueueResponse.ProtoReflect.Descriptor instead.
+func (*ListQueueResponse) Descriptor() ([]byte, []int) {
+	return file_job_queue_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListQueueResponse) GetJobs() []*QueuedJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+// 順番変更リクエスト
+type ReorderJobRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	JobId string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// 新しい優先度（省略時は変更しない）
+	Priority *int32 `protobuf:"varint,2,opt,name=priority,proto3,oneof" json:"priority,omitempty"`
+	// 新しい順番（1始まり、0の場合は優先度で決める）
+	Position      int32 `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderJobRequest) Reset() {
+	*x = ReorderJobRequest{}
+	mi := &file_job_queue_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderJobRequest) ProtoMessage() {}
+
+func (x *ReorderJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_job_queue_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderJobRequest.ProtoReflect.Descriptor instead.
+func (*ReorderJobRequest) Descriptor() ([]byte, []int) {
+	return file_job_queue_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReorderJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *ReorderJobRequest) GetPriority() int32 {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return 0
+}
+
+func (x *ReorderJobRequest) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+// デッドレター一覧リクエスト
+type ListDeadLettersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ジョブの種類で絞り込み（空の場合はすべて）
+	JobType       string `protobuf:"bytes,1,opt,name=job_type,json=jobType,proto3" json:"job_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersRequest) Reset() {
+	*x = ListDeadLettersRequest{}
+	mi := &file_job_queue_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersRequest) ProtoMessage() {}
+
+func (x *ListDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_job_queue_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_job_queue_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListDeadLettersRequest) GetJobType() string {
+	if x != nil {
+		return x.JobType
+	}
+	return ""
+}
+
+// 再実行リクエスト
+type RetryJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryJobRequest) Reset() {
+	*x = RetryJobRequest{}
+	mi := &file_job_queue_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryJobRequest) ProtoMessage() {}
+
+func (x *RetryJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_job_queue_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryJobRequest.ProtoReflect.Descriptor instead.
+func (*RetryJobRequest) Descriptor() ([]byte, []int) {
+	return file_job_queue_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RetryJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+var File_job_queue_proto protoreflect.FileDescriptor
+
+const file_job_queue_proto_rawDesc = "" +
+	"\n" +
+	"\x0fjob_queue.proto\x12\x11desktop_server.v1\x1a\x0eprogress.proto\"\x91\x02\n" +
+	"\x10SubmitJobRequest\x12\x19\n" +
+	"\bjob_type\x18\x01 \x01(\tR\ajobType\x12\x1a\n" +
+	"\bpriority\x18\x02 \x01(\x05R\bpriority\x12+\n" +
+	"\x11concurrency_group\x18\x03 \x01(\tR\x10concurrencyGroup\x12G\n" +
+	"\x06params\x18\x04 \x03(\v2/.desktop_server.v1.SubmitJobRequest.ParamsEntryR\x06params\x12\x15\n" +
+	"\x06job_id\x18\x05 \x01(\tR\x05jobId\x1a9\n" +
+	"\vParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xfd\x03\n" +
+	"\tQueuedJob\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x19\n" +
+	"\bjob_type\x18\x02 \x01(\tR\ajobType\x12+\n" +
+	"\x11concurrency_group\x18\x03 \x01(\tR\x10concurrencyGroup\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\x121\n" +
+	"\x05state\x18\x05 \x01(\x0e2\x1b.desktop_server.v1.JobStateR\x05state\x12\x1a\n" +
+	"\bposition\x18\x06 \x01(\x05R\bposition\x12!\n" +
+	"\fsubmitted_at\x18\a \x01(\x03R\vsubmittedAt\x12@\n" +
+	"\x06params\x18\b \x03(\v2(.desktop_server.v1.QueuedJob.ParamsEntryR\x06params\x12\x1a\n" +
+	"\battempts\x18\t \x01(\x05R\battempts\x12!\n" +
+	"\fmax_attempts\x18\n" +
+	" \x01(\x05R\vmaxAttempts\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\v \x01(\tR\tlastError\x12(\n" +
+	"\x10last_error_class\x18\f \x01(\tR\x0elastErrorClass\x1a9\n" +
+	"\vParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"?\n" +
+	"\x10ListQueueRequest\x12+\n" +
+	"\x11concurrency_group\x18\x01 \x01(\tR\x10concurrencyGroup\"E\n" +
+	"\x11ListQueueResponse\x120\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x1c.desktop_server.v1.QueuedJobR\x04jobs\"t\n" +
+	"\x11ReorderJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x1f\n" +
+	"\bpriority\x18\x02 \x01(\x05H\x00R\bpriority\x88\x01\x01\x12\x1a\n" +
+	"\bposition\x18\x03 \x01(\x05R\bpositionB\v\n" +
+	"\t_priority\"3\n" +
+	"\x16ListDeadLettersRequest\x12\x19\n" +
+	"\bjob_type\x18\x01 \x01(\tR\ajobType\"(\n" +
+	"\x0fRetryJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId2\x87\x04\n" +
+	"\x0fJobQueueService\x12N\n" +
+	"\tSubmitJob\x12#.desktop_server.v1.SubmitJobRequest\x1a\x1c.desktop_server.v1.QueuedJob\x12V\n" +
+	"\tListQueue\x12#.desktop_server.v1.ListQueueRequest\x1a$.desktop_server.v1.ListQueueResponse\x12P\n" +
+	"\n" +
+	"ReorderJob\x12$.desktop_server.v1.ReorderJobRequest\x1a\x1c.desktop_server.v1.QueuedJob\x12H\n" +
+	"\tCancelJob\x12#.desktop_server.v1.CancelJobRequest\x1a\x16.desktop_server.v1.Job\x12b\n" +
+	"\x0fListDeadLetters\x12).desktop_server.v1.ListDeadLettersRequest\x1a$.desktop_server.v1.ListQueueResponse\x12L\n" +
+	"\bRetryJob\x12\".desktop_server.v1.RetryJobRequest\x1a\x1c.desktop_server.v1.QueuedJobB=Z;github.com/yhonda-ohishi-pub-dev/desktop-server/proto;protob\x06proto3"
+
+var (
+	file_job_queue_proto_rawDescOnce sync.Once
+	file_job_queue_proto_rawDescData []byte
+)
+
+func file_job_queue_proto_rawDescGZIP() []byte {
+	file_job_queue_proto_rawDescOnce.Do(func() {
+		file_job_queue_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_job_queue_proto_rawDesc), len(file_job_queue_proto_rawDesc)))
+	})
+	return file_job_queue_proto_rawDescData
+}
+
+var file_job_queue_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_job_queue_proto_goTypes = []any{
+	(*SubmitJobRequest)(nil),       // 0: desktop_server.v1.SubmitJobRequest
+	(*QueuedJob)(nil),              // 1: desktop_server.v1.QueuedJob
+	(*ListQueueRequest)(nil),       // 2: desktop_server.v1.ListQueueRequest
+	(*ListQueueResponse)(nil),      // 3: desktop_server.v1.ListQueueResponse
+	(*ReorderJobRequest)(nil),      // 4: desktop_server.v1.ReorderJobRequest
+	(*ListDeadLettersRequest)(nil), // 5: desktop_server.v1.ListDeadLettersRequest
+	(*RetryJobRequest)(nil),        // 6: desktop_server.v1.RetryJobRequest
+	nil,                            // 7: desktop_server.v1.SubmitJobRequest.ParamsEntry
+	nil,                            // 8: desktop_server.v1.QueuedJob.ParamsEntry
+	(JobState)(0),                  // 9: desktop_server.v1.JobState
+	(*CancelJobRequest)(nil),       // 10: desktop_server.v1.CancelJobRequest
+	(*Job)(nil),                    // 11: desktop_server.v1.Job
+}
+var file_job_queue_proto_depIdxs = []int32{
+	7,  // 0: desktop_server.v1.SubmitJobRequest.params:type_name -> desktop_server.v1.SubmitJobRequest.ParamsEntry
+	9,  // 1: desktop_server.v1.QueuedJob.state:type_name -> desktop_server.v1.JobState
+	8,  // 2: desktop_server.v1.QueuedJob.params:type_name -> desktop_server.v1.QueuedJob.ParamsEntry
+	1,  // 3: desktop_server.v1.ListQueueResponse.jobs:type_name -> desktop_server.v1.QueuedJob
+	0,  // 4: desktop_server.v1.JobQueueService.SubmitJob:input_type -> desktop_server.v1.SubmitJobRequest
+	2,  // 5: desktop_server.v1.JobQueueService.ListQueue:input_type -> desktop_server.v1.ListQueueRequest
+	4,  // 6: desktop_server.v1.JobQueueService.ReorderJob:input_type -> desktop_server.v1.ReorderJobRequest
+	10, // 7: desktop_server.v1.JobQueueService.CancelJob:input_type -> desktop_server.v1.CancelJobRequest
+	5,  // 8: desktop_server.v1.JobQueueService.ListDeadLetters:input_type -> desktop_server.v1.ListDeadLettersRequest
+	6,  // 9: desktop_server.v1.JobQueueService.RetryJob:input_type -> desktop_server.v1.RetryJobRequest
+	1,  // 10: desktop_server.v1.JobQueueService.SubmitJob:output_type -> desktop_server.v1.QueuedJob
+	3,  // 11: desktop_server.v1.JobQueueService.ListQueue:output_type -> desktop_server.v1.ListQueueResponse
+	1,  // 12: desktop_server.v1.JobQueueService.ReorderJob:output_type -> desktop_server.v1.QueuedJob
+	11, // 13: desktop_server.v1.JobQueueService.CancelJob:output_type -> desktop_server.v1.Job
+	3,  // 14: desktop_server.v1.JobQueueService.ListDeadLetters:output_type -> desktop_server.v1.ListQueueResponse
+	1,  // 15: desktop_server.v1.JobQueueService.RetryJob:output_type -> desktop_server.v1.QueuedJob
+	10, // [10:16] is the sub-list for method output_type
+	4,  // [4:10] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_job_queue_proto_init() }
+func file_job_queue_proto_init() {
+	if File_job_queue_proto != nil {
+		return
+	}
+	file_progress_proto_init()
+	file_job_queue_proto_msgTypes[4].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_job_queue_proto_rawDesc), len(file_job_queue_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_job_queue_proto_goTypes,
+		DependencyIndexes: file_job_queue_proto_depIdxs,
+		MessageInfos:      file_job_queue_proto_msgTypes,
+	}.Build()
+	File_job_queue_proto = out.File
+	file_job_queue_proto_goTypes = nil
+	file_job_queue_proto_depIdxs = nil
+}
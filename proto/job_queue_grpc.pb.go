@@ -0,0 +1,327 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: job_queue.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	JobQueueService_SubmitJob_FullMethodName       = "/desktop_server.v1.JobQueueService/SubmitJob"
+	JobQueueService_ListQueue_FullMethodName       = "/desktop_server.v1.JobQueueService/ListQueue"
+	JobQueueService_ReorderJob_FullMethodName      = "/desktop_server.v1.JobQueueService/ReorderJob"
+	JobQueueService_CancelJob_FullMethodName       = "/desktop_server.v1.JobQueueService/CancelJob"
+	JobQueueService_ListDeadLetters_FullMethodName = "/desktop_server.v1.JobQueueService/ListDeadLetters"
+	JobQueueService_RetryJob_FullMethodName        = "/desktop_server.v1.JobQueueService/RetryJob"
+)
+
+// JobQueueServiceClient is the client API for JobQueueService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ジョブキューサービス（同時実行数の制限と優先度付き実行）
+type JobQueueServiceClient interface {
+	// ジョブをキューに登録
+	SubmitJob(ctx context.Context, in *SubmitJobRequest, opts ...grpc.CallOption) (*QueuedJob, error)
+	// 実行中・実行待ちのジョブ一覧を取得
+	ListQueue(ctx context.Context, in *ListQueueRequest, opts ...grpc.CallOption) (*ListQueueResponse, error)
+	// 実行待ちのジョブの優先度・順番を変更
+	ReorderJob(ctx context.Context, in *ReorderJobRequest, opts ...grpc.CallOption) (*QueuedJob, error)
+	// 実行待ちまたは実行中のジョブをキャンセル
+	CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*Job, error)
+	// 失敗したジョブ（デッドレター）の一覧を取得
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListQueueResponse, error)
+	// 失敗したジョブを再実行（同じジョブIDで再登録）
+	RetryJob(ctx context.Context, in *RetryJobRequest, opts ...grpc.CallOption) (*QueuedJob, error)
+}
+
+type jobQueueServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewJobQueueServiceClient(cc grpc.ClientConnInterface) JobQueueServiceClient {
+	return &jobQueueServiceClient{cc}
+}
+
+func (c *jobQueueServiceClient) SubmitJob(ctx context.Context, in *SubmitJobRequest, opts ...grpc.CallOption) (*QueuedJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueuedJob)
+	err := c.cc.Invoke(ctx, JobQueueService_SubmitJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobQueueServiceClient) ListQueue(ctx context.Context, in *ListQueueRequest, opts ...grpc.CallOption) (*ListQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListQueueResponse)
+	err := c.cc.Invoke(ctx, JobQueueService_ListQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobQueueServiceClient) ReorderJob(ctx context.Context, in *ReorderJobRequest, opts ...grpc.CallOption) (*QueuedJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueuedJob)
+	err := c.cc.Invoke(ctx, JobQueueService_ReorderJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobQueueServiceClient) CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Job)
+	err := c.cc.Invoke(ctx, JobQueueService_CancelJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobQueueServiceClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListQueueResponse)
+	err := c.cc.Invoke(ctx, JobQueueService_ListDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobQueueServiceClient) RetryJob(ctx context.Context, in *RetryJobRequest, opts ...grpc.CallOption) (*QueuedJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueuedJob)
+	err := c.cc.Invoke(ctx, JobQueueService_RetryJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// JobQueueServiceServer is the server API for JobQueueService service.
+// All implementations must embed UnimplementedJobQueueServiceServer
+// for forward compatibility.
+//
+// ジョブキューサービス（同時実行数の制限と優先度付き実行）
+type JobQueueServiceServer interface {
+	// ジョブをキューに登録
+	SubmitJob(context.Context, *SubmitJobRequest) (*QueuedJob, error)
+	// 実行中・実行待ちのジョブ一覧を取得
+	ListQueue(context.Context, *ListQueueRequest) (*ListQueueResponse, error)
+	// 実行待ちのジョブの優先度・順番を変更
+	ReorderJob(context.Context, *ReorderJobRequest) (*QueuedJob, error)
+	// 実行待ちまたは実行中のジョブをキャンセル
+	CancelJob(context.Context, *CancelJobRequest) (*Job, error)
+	// 失敗したジョブ（デッドレター）の一覧を取得
+	ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListQueueResponse, error)
+	// 失敗したジョブを再実行（同じジョブIDで再登録）
+	RetryJob(context.Context, *RetryJobRequest) (*QueuedJob, error)
+	mustEmbedUnimplementedJobQueueServiceServer()
+}
+
+// UnimplementedJobQueueServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedJobQueueServiceServer struct{}
+
+func (UnimplementedJobQueueServiceServer) SubmitJob(context.Context, *SubmitJobRequest) (*QueuedJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitJob not implemented")
+}
+func (UnimplementedJobQueueServiceServer) ListQueue(context.Context, *ListQueueRequest) (*ListQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListQueue not implemented")
+}
+func (UnimplementedJobQueueServiceServer) ReorderJob(context.Context, *ReorderJobRequest) (*QueuedJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderJob not implemented")
+}
+func (UnimplementedJobQueueServiceServer) CancelJob(context.Context, *CancelJobRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelJob not implemented")
+}
+func (UnimplementedJobQueueServiceServer) ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+func (UnimplementedJobQueueServiceServer) RetryJob(context.Context, *RetryJobRequest) (*QueuedJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryJob not implemented")
+}
+func (UnimplementedJobQueueServiceServer) mustEmbedUnimplementedJobQueueServiceServer() {}
+func (UnimplementedJobQueueServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeJobQueueServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to JobQueueServiceServer will
+// result in compilation errors.
+type UnsafeJobQueueServiceServer interface {
+	mustEmbedUnimplementedJobQueueServiceServer()
+}
+
+func RegisterJobQueueServiceServer(s grpc.ServiceRegistrar, srv JobQueueServiceServer) {
+	// If the following call pancis, it indicates UnimplementedJobQueueServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&JobQueueService_ServiceDesc, srv)
+}
+
+func _JobQueueService_SubmitJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobQueueServiceServer).SubmitJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobQueueService_SubmitJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobQueueServiceServer).SubmitJob(ctx, req.(*SubmitJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobQueueService_ListQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobQueueServiceServer).ListQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobQueueService_ListQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobQueueServiceServer).ListQueue(ctx, req.(*ListQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobQueueService_ReorderJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobQueueServiceServer).ReorderJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobQueueService_ReorderJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobQueueServiceServer).ReorderJob(ctx, req.(*ReorderJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobQueueService_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobQueueServiceServer).CancelJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobQueueService_CancelJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobQueueServiceServer).CancelJob(ctx, req.(*CancelJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobQueueService_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobQueueServiceServer).ListDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobQueueService_ListDeadLetters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobQueueServiceServer).ListDeadLetters(ctx, req.(*ListDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobQueueService_RetryJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobQueueServiceServer).RetryJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobQueueService_RetryJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobQueueServiceServer).RetryJob(ctx, req.(*RetryJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// JobQueueService_ServiceDesc is the grpc.ServiceDesc for JobQueueService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var JobQueueService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "desktop_server.v1.JobQueueService",
+	HandlerType: (*JobQueueServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitJob",
+			Handler:    _JobQueueService_SubmitJob_Handler,
+		},
+		{
+			MethodName: "ListQueue",
+			Handler:    _JobQueueService_ListQueue_Handler,
+		},
+		{
+			MethodName: "ReorderJob",
+			Handler:    _JobQueueService_ReorderJob_Handler,
+		},
+		{
+			MethodName: "CancelJob",
+			Handler:    _JobQueueService_CancelJob_Handler,
+		},
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _JobQueueService_ListDeadLetters_Handler,
+		},
+		{
+			MethodName: "RetryJob",
+			Handler:    _JobQueueService_RetryJob_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "job_queue.proto",
+}
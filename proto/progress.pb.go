@@ -21,6 +21,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// 購読モード
+type SubscriptionMode int32
+
+const (
+	// AUTO_CLOSEと同じ（既存クライアントとの互換性のため）
+	SubscriptionMode_SUBSCRIPTION_MODE_UNSPECIFIED SubscriptionMode = 0
+	// 対象ジョブが終了したらストリームを閉じる（ジョブ未指定の場合は最初に終了したジョブで閉じる）
+	SubscriptionMode_SUBSCRIPTION_MODE_AUTO_CLOSE SubscriptionMode = 1
+	// ジョブが終了してもストリームを閉じない
+	SubscriptionMode_SUBSCRIPTION_MODE_PERSISTENT SubscriptionMode = 2
+)
+
+// Enum value maps for SubscriptionMode.
+var (
+	SubscriptionMode_name = map[int32]string{
+		0: "SUBSCRIPTION_MODE_UNSPECIFIED",
+		1: "SUBSCRIPTION_MODE_AUTO_CLOSE",
+		2: "SUBSCRIPTION_MODE_PERSISTENT",
+	}
+	SubscriptionMode_value = map[string]int32{
+		"SUBSCRIPTION_MODE_UNSPECIFIED": 0,
+		"SUBSCRIPTION_MODE_AUTO_CLOSE":  1,
+		"SUBSCRIPTION_MODE_PERSISTENT":  2,
+	}
+)
+
+func (x SubscriptionMode) Enum() *SubscriptionMode {
+	p := new(SubscriptionMode)
+	*p = x
+	return p
+}
+
+func (x SubscriptionMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SubscriptionMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_progress_proto_enumTypes[0].Descriptor()
+}
+
+func (SubscriptionMode) Type() protoreflect.EnumType {
+	return &file_progress_proto_enumTypes[0]
+}
+
+func (x SubscriptionMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SubscriptionMode.Descriptor instead.
+func (SubscriptionMode) EnumDescriptor() ([]byte, []int) {
+	return file_progress_proto_rawDescGZIP(), []int{0}
+}
+
 // 進捗タイプ
 type ProgressType int32
 
@@ -30,6 +83,10 @@ const (
 	ProgressType_PROGRESS_TYPE_PROGRESS    ProgressType = 2 // 進行中
 	ProgressType_PROGRESS_TYPE_COMPLETE    ProgressType = 3 // 完了
 	ProgressType_PROGRESS_TYPE_ERROR       ProgressType = 4 // エラー
+	ProgressType_PROGRESS_TYPE_CANCELLED   ProgressType = 5 // キャンセル
+	ProgressType_PROGRESS_TYPE_HEARTBEAT   ProgressType = 6 // ハートビート（ストリーム生存確認、sequenceは最後に送信した更新の番号）
+	ProgressType_PROGRESS_TYPE_QUEUED      ProgressType = 7 // 実行待ち（queue_positionに待ち順を設定）
+	ProgressType_PROGRESS_TYPE_DEAD_LETTER ProgressType = 8 // 再試行を使い切って失敗（現在は送信しない。デッドレターはERRORのdetails["dead_letter"]="true"で通知）
 )
 
 // Enum value maps for ProgressType.
@@ -40,6 +97,10 @@ var (
 		2: "PROGRESS_TYPE_PROGRESS",
 		3: "PROGRESS_TYPE_COMPLETE",
 		4: "PROGRESS_TYPE_ERROR",
+		5: "PROGRESS_TYPE_CANCELLED",
+		6: "PROGRESS_TYPE_HEARTBEAT",
+		7: "PROGRESS_TYPE_QUEUED",
+		8: "PROGRESS_TYPE_DEAD_LETTER",
 	}
 	ProgressType_value = map[string]int32{
 		"PROGRESS_TYPE_UNSPECIFIED": 0,
@@ -47,6 +108,10 @@ var (
 		"PROGRESS_TYPE_PROGRESS":    2,
 		"PROGRESS_TYPE_COMPLETE":    3,
 		"PROGRESS_TYPE_ERROR":       4,
+		"PROGRESS_TYPE_CANCELLED":   5,
+		"PROGRESS_TYPE_HEARTBEAT":   6,
+		"PROGRESS_TYPE_QUEUED":      7,
+		"PROGRESS_TYPE_DEAD_LETTER": 8,
 	}
 )
 
@@ -61,11 +126,11 @@ func (x ProgressType) String() string {
 }
 
 func (ProgressType) Descriptor() protoreflect.EnumDescriptor {
-	return file_progress_proto_enumTypes[0].Descriptor()
+	return file_progress_proto_enumTypes[1].Descriptor()
 }
 
 func (ProgressType) Type() protoreflect.EnumType {
-	return &file_progress_proto_enumTypes[0]
+	return &file_progress_proto_enumTypes[1]
 }
 
 func (x ProgressType) Number() protoreflect.EnumNumber {
@@ -74,14 +139,91 @@ func (x ProgressType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ProgressType.Descriptor instead.
 func (ProgressType) EnumDescriptor() ([]byte, []int) {
-	return file_progress_proto_rawDescGZIP(), []int{0}
+	return file_progress_proto_rawDescGZIP(), []int{1}
+}
+
+// ジョブ状態
+type JobState int32
+
+const (
+	JobState_JOB_STATE_UNSPECIFIED JobState = 0
+	JobState_JOB_STATE_STARTED     JobState = 1 // 開始
+	JobState_JOB_STATE_RUNNING     JobState = 2 // 実行中
+	JobState_JOB_STATE_COMPLETE    JobState = 3 // 完了
+	JobState_JOB_STATE_ERROR       JobState = 4 // エラー
+	JobState_JOB_STATE_CANCELLED   JobState = 5 // キャンセル
+	JobState_JOB_STATE_QUEUED      JobState = 6 // 実行待ち
+	JobState_JOB_STATE_DEAD_LETTER JobState = 7 // 再試行を使い切って失敗（JobQueueService.RetryJobで再実行可能）
+)
+
+// Enum value maps for JobState.
+var (
+	JobState_name = map[int32]string{
+		0: "JOB_STATE_UNSPECIFIED",
+		1: "JOB_STATE_STARTED",
+		2: "JOB_STATE_RUNNING",
+		3: "JOB_STATE_COMPLETE",
+		4: "JOB_STATE_ERROR",
+		5: "JOB_STATE_CANCELLED",
+		6: "JOB_STATE_QUEUED",
+		7: "JOB_STATE_DEAD_LETTER",
+	}
+	JobState_value = map[string]int32{
+		"JOB_STATE_UNSPECIFIED": 0,
+		"JOB_STATE_STARTED":     1,
+		"JOB_STATE_RUNNING":     2,
+		"JOB_STATE_COMPLETE":    3,
+		"JOB_STATE_ERROR":       4,
+		"JOB_STATE_CANCELLED":   5,
+		"JOB_STATE_QUEUED":      6,
+		"JOB_STATE_DEAD_LETTER": 7,
+	}
+)
+
+func (x JobState) Enum() *JobState {
+	p := new(JobState)
+	*p = x
+	return p
+}
+
+func (x JobState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobState) Descriptor() protoreflect.EnumDescriptor {
+	return file_progress_proto_enumTypes[2].Descriptor()
+}
+
+func (JobState) Type() protoreflect.EnumType {
+	return &file_progress_proto_enumTypes[2]
+}
+
+func (x JobState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobState.Descriptor instead.
+func (JobState) EnumDescriptor() ([]byte, []int) {
+	return file_progress_proto_rawDescGZIP(), []int{2}
 }
 
 // 進捗ストリームリクエスト
 type StreamProgressRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// 特定のジョブIDを指定（空の場合はすべての進捗を受信）
-	JobId         string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// 特定のジョブIDを指定（空の場合はすべての進捗を受信、サブジョブの進捗も含む）
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// このシーケンス番号より後の更新から再送する（0の場合はバッファ内のすべて）
+	SinceSequence int64 `protobuf:"varint,2,opt,name=since_sequence,json=sinceSequence,proto3" json:"since_sequence,omitempty"`
+	// このタイムスタンプ（Unix秒）以降の更新から再送する（0の場合は指定なし）
+	SinceTimestamp int64 `protobuf:"varint,3,opt,name=since_timestamp,json=sinceTimestamp,proto3" json:"since_timestamp,omitempty"`
+	// ハートビートの送信間隔（秒、0の場合は送信しない）
+	HeartbeatIntervalSeconds int32 `protobuf:"varint,4,opt,name=heartbeat_interval_seconds,json=heartbeatIntervalSeconds,proto3" json:"heartbeat_interval_seconds,omitempty"`
+	// 購読モード
+	Mode SubscriptionMode `protobuf:"varint,5,opt,name=mode,proto3,enum=desktop_server.v1.SubscriptionMode" json:"mode,omitempty"`
+	// 複数のジョブIDを指定（job_idと併用可、サブジョブの進捗も含む）
+	JobIds []string `protobuf:"bytes,6,rep,name=job_ids,json=jobIds,proto3" json:"job_ids,omitempty"`
+	// ジョブの種類で絞り込み（空の場合はすべて）
+	JobTypes      []string `protobuf:"bytes,7,rep,name=job_types,json=jobTypes,proto3" json:"job_types,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -123,6 +265,48 @@ func (x *StreamProgressRequest) GetJobId() string {
 	return ""
 }
 
+func (x *StreamProgressRequest) GetSinceSequence() int64 {
+	if x != nil {
+		return x.SinceSequence
+	}
+	return 0
+}
+
+func (x *StreamProgressRequest) GetSinceTimestamp() int64 {
+	if x != nil {
+		return x.SinceTimestamp
+	}
+	return 0
+}
+
+func (x *StreamProgressRequest) GetHeartbeatIntervalSeconds() int32 {
+	if x != nil {
+		return x.HeartbeatIntervalSeconds
+	}
+	return 0
+}
+
+func (x *StreamProgressRequest) GetMode() SubscriptionMode {
+	if x != nil {
+		return x.Mode
+	}
+	return SubscriptionMode_SUBSCRIPTION_MODE_UNSPECIFIED
+}
+
+func (x *StreamProgressRequest) GetJobIds() []string {
+	if x != nil {
+		return x.JobIds
+	}
+	return nil
+}
+
+func (x *StreamProgressRequest) GetJobTypes() []string {
+	if x != nil {
+		return x.JobTypes
+	}
+	return nil
+}
+
 // 進捗更新メッセージ
 type ProgressUpdate struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -139,7 +323,25 @@ type ProgressUpdate struct {
 	// 関連するジョブID（ある場合）
 	JobId string `protobuf:"bytes,6,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
 	// タイムスタンプ
-	Timestamp     int64 `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Timestamp int64 `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// サーバーが付与する単調増加のシーケンス番号（再送・再接続用）
+	Sequence int64 `protobuf:"varint,8,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// ジョブの種類（例: "etc_download"）
+	JobType string `protobuf:"bytes,9,opt,name=job_type,json=jobType,proto3" json:"job_type,omitempty"`
+	// 転送済みバイト数
+	BytesTransferred int64 `protobuf:"varint,10,opt,name=bytes_transferred,json=bytesTransferred,proto3" json:"bytes_transferred,omitempty"`
+	// 総バイト数（不明の場合は0）
+	BytesTotal int64 `protobuf:"varint,11,opt,name=bytes_total,json=bytesTotal,proto3" json:"bytes_total,omitempty"`
+	// 転送速度（バイト/秒、サーバーが算出）
+	BytesPerSecond float64 `protobuf:"fixed64,12,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"`
+	// 残り時間の見込み（秒、サーバーが算出、不明の場合は0）
+	EtaSeconds int64 `protobuf:"varint,13,opt,name=eta_seconds,json=etaSeconds,proto3" json:"eta_seconds,omitempty"`
+	// 親ジョブID（サブジョブの場合）
+	ParentJobId string `protobuf:"bytes,14,opt,name=parent_job_id,json=parentJobId,proto3" json:"parent_job_id,omitempty"`
+	// 任意の詳細情報（例: "account" => "1234"）
+	Details map[string]string `protobuf:"bytes,15,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 実行待ちの順番（1始まり、PROGRESS_TYPE_QUEUEDの場合のみ）
+	QueuePosition int32 `protobuf:"varint,16,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -223,13 +425,673 @@ func (x *ProgressUpdate) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *ProgressUpdate) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *ProgressUpdate) GetJobType() string {
+	if x != nil {
+		return x.JobType
+	}
+	return ""
+}
+
+func (x *ProgressUpdate) GetBytesTransferred() int64 {
+	if x != nil {
+		return x.BytesTransferred
+	}
+	return 0
+}
+
+func (x *ProgressUpdate) GetBytesTotal() int64 {
+	if x != nil {
+		return x.BytesTotal
+	}
+	return 0
+}
+
+func (x *ProgressUpdate) GetBytesPerSecond() float64 {
+	if x != nil {
+		return x.BytesPerSecond
+	}
+	return 0
+}
+
+func (x *ProgressUpdate) GetEtaSeconds() int64 {
+	if x != nil {
+		return x.EtaSeconds
+	}
+	return 0
+}
+
+func (x *ProgressUpdate) GetParentJobId() string {
+	if x != nil {
+		return x.ParentJobId
+	}
+	return ""
+}
+
+func (x *ProgressUpdate) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *ProgressUpdate) GetQueuePosition() int32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+// ジョブ（進捗更新から導出される）
+type Job struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ジョブID
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// ジョブの種類
+	JobType string `protobuf:"bytes,2,opt,name=job_type,json=jobType,proto3" json:"job_type,omitempty"`
+	// 状態
+	State JobState `protobuf:"varint,3,opt,name=state,proto3,enum=desktop_server.v1.JobState" json:"state,omitempty"`
+	// 最新のメッセージ
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// 現在のステップ
+	CurrentStep int32 `protobuf:"varint,5,opt,name=current_step,json=currentStep,proto3" json:"current_step,omitempty"`
+	// 総ステップ数
+	TotalSteps int32 `protobuf:"varint,6,opt,name=total_steps,json=totalSteps,proto3" json:"total_steps,omitempty"`
+	// 進捗率（0-100）
+	Percentage int32 `protobuf:"varint,7,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	// 開始時刻（Unix秒）
+	StartedAt int64 `protobuf:"varint,8,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// 最終更新時刻（Unix秒）
+	UpdatedAt int64 `protobuf:"varint,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// 終了時刻（Unix秒、未終了の場合は0）
+	FinishedAt int64 `protobuf:"varint,10,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	// 最後に反映した進捗更新のシーケンス番号
+	LastSequence int64 `protobuf:"varint,11,opt,name=last_sequence,json=lastSequence,proto3" json:"last_sequence,omitempty"`
+	// 転送済みバイト数
+	BytesTransferred int64 `protobuf:"varint,12,opt,name=bytes_transferred,json=bytesTransferred,proto3" json:"bytes_transferred,omitempty"`
+	// 総バイト数（不明の場合は0）
+	BytesTotal int64 `protobuf:"varint,13,opt,name=bytes_total,json=bytesTotal,proto3" json:"bytes_total,omitempty"`
+	// 転送速度（バイト/秒）
+	BytesPerSecond float64 `protobuf:"fixed64,14,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"`
+	// 残り時間の見込み（秒、不明の場合は0）
+	EtaSeconds int64 `protobuf:"varint,15,opt,name=eta_seconds,json=etaSeconds,proto3" json:"eta_seconds,omitempty"`
+	// 親ジョブID（サブジョブの場合）
+	ParentJobId string `protobuf:"bytes,16,opt,name=parent_job_id,json=parentJobId,proto3" json:"parent_job_id,omitempty"`
+	// 最新の詳細情報
+	Details map[string]string `protobuf:"bytes,17,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 実行待ちの順番（1始まり、JOB_STATE_QUEUEDの場合のみ）
+	QueuePosition int32 `protobuf:"varint,18,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	mi := &file_progress_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_progress_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_progress_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Job) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *Job) GetJobType() string {
+	if x != nil {
+		return x.JobType
+	}
+	return ""
+}
+
+func (x *Job) GetState() JobState {
+	if x != nil {
+		return x.State
+	}
+	return JobState_JOB_STATE_UNSPECIFIED
+}
+
+func (x *Job) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Job) GetCurrentStep() int32 {
+	if x != nil {
+		return x.CurrentStep
+	}
+	return 0
+}
+
+func (x *Job) GetTotalSteps() int32 {
+	if x != nil {
+		return x.TotalSteps
+	}
+	return 0
+}
+
+func (x *Job) GetPercentage() int32 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (x *Job) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *Job) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Job) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+func (x *Job) GetLastSequence() int64 {
+	if x != nil {
+		return x.LastSequence
+	}
+	return 0
+}
+
+func (x *Job) GetBytesTransferred() int64 {
+	if x != nil {
+		return x.BytesTransferred
+	}
+	return 0
+}
+
+func (x *Job) GetBytesTotal() int64 {
+	if x != nil {
+		return x.BytesTotal
+	}
+	return 0
+}
+
+func (x *Job) GetBytesPerSecond() float64 {
+	if x != nil {
+		return x.BytesPerSecond
+	}
+	return 0
+}
+
+func (x *Job) GetEtaSeconds() int64 {
+	if x != nil {
+		return x.EtaSeconds
+	}
+	return 0
+}
+
+func (x *Job) GetParentJobId() string {
+	if x != nil {
+		return x.ParentJobId
+	}
+	return ""
+}
+
+func (x *Job) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *Job) GetQueuePosition() int32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+// ジョブ一覧リクエスト
+type ListJobsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 状態で絞り込み（空の場合はすべて）
+	States []JobState `protobuf:"varint,1,rep,packed,name=states,proto3,enum=desktop_server.v1.JobState" json:"states,omitempty"`
+	// ジョブの種類で絞り込み（空の場合はすべて）
+	JobType string `protobuf:"bytes,2,opt,name=job_type,json=jobType,proto3" json:"job_type,omitempty"`
+	// 親ジョブIDで絞り込み（指定したジョブのサブジョブのみ）
+	ParentJobId   string `protobuf:"bytes,3,opt,name=parent_job_id,json=parentJobId,proto3" json:"parent_job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsRequest) Reset() {
+	*x = ListJobsRequest{}
+	mi := &file_progress_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsRequest) ProtoMessage() {}
+
+func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_progress_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return file_progress_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListJobsRequest) GetStates() []JobState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *ListJobsRequest) GetJobType() string {
+	if x != nil {
+		return x.JobType
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetParentJobId() string {
+	if x != nil {
+		return x.ParentJobId
+	}
+	return ""
+}
+
+// ジョブ一覧レスポンス
+type ListJobsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 開始時刻の新しい順
+	Jobs          []*Job `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsResponse) Reset() {
+	*x = ListJobsResponse{}
+	mi := &file_progress_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsResponse) ProtoMessage() {}
+
+func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_progress_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return file_progress_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListJobsResponse) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+// ジョブ取得リクエスト
+type GetJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobRequest) Reset() {
+	*x = GetJobRequest{}
+	mi := &file_progress_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobRequest) ProtoMessage() {}
+
+func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_progress_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return file_progress_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// ジョブ監視リクエスト
+type WatchJobsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ジョブの種類で絞り込み（空の場合はすべて）
+	JobType       string `protobuf:"bytes,1,opt,name=job_type,json=jobType,proto3" json:"job_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchJobsRequest) Reset() {
+	*x = WatchJobsRequest{}
+	mi := &file_progress_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchJobsRequest) ProtoMessage() {}
+
+func (x *WatchJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_progress_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchJobsRequest.ProtoReflect.Descriptor instead.
+func (*WatchJobsRequest) Descriptor() ([]byte, []int) {
+	return file_progress_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WatchJobsRequest) GetJobType() string {
+	if x != nil {
+		return x.JobType
+	}
+	return ""
+}
+
+// ジョブキャンセルリクエスト
+type CancelJobRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	JobId string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// キャンセル理由（進捗メッセージとして通知される）
+	Reason        string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelJobRequest) Reset() {
+	*x = CancelJobRequest{}
+	mi := &file_progress_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelJobRequest) ProtoMessage() {}
+
+func (x *CancelJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_progress_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelJobRequest) Descriptor() ([]byte, []int) {
+	return file_progress_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CancelJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *CancelJobRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// 進捗送信レスポンス
+type PublishProgressResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 配信された進捗更新の数
+	Accepted int32 `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	// 送信中にキャンセルされたジョブID（以降の更新は破棄された）
+	CancelledJobIds []string `protobuf:"bytes,2,rep,name=cancelled_job_ids,json=cancelledJobIds,proto3" json:"cancelled_job_ids,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PublishProgressResponse) Reset() {
+	*x = PublishProgressResponse{}
+	mi := &file_progress_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishProgressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishProgressResponse) ProtoMessage() {}
+
+func (x *PublishProgressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_progress_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishProgressResponse.ProtoReflect.Descriptor instead.
+func (*PublishProgressResponse) Descriptor() ([]byte, []int) {
+	return file_progress_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PublishProgressResponse) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *PublishProgressResponse) GetCancelledJobIds() []string {
+	if x != nil {
+		return x.CancelledJobIds
+	}
+	return nil
+}
+
+// ジョブ履歴検索リクエスト
+type ListJobHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 開始時刻の下限（Unix秒、0の場合は指定なし）
+	StartedFrom int64 `protobuf:"varint,1,opt,name=started_from,json=startedFrom,proto3" json:"started_from,omitempty"`
+	// 開始時刻の上限（Unix秒、0の場合は指定なし）
+	StartedTo int64 `protobuf:"varint,2,opt,name=started_to,json=startedTo,proto3" json:"started_to,omitempty"`
+	// 状態で絞り込み（空の場合はすべて）
+	States []JobState `protobuf:"varint,3,rep,packed,name=states,proto3,enum=desktop_server.v1.JobState" json:"states,omitempty"`
+	// ジョブの種類で絞り込み（空の場合はすべて）
+	JobType string `protobuf:"bytes,4,opt,name=job_type,json=jobType,proto3" json:"job_type,omitempty"`
+	// 最大件数（0の場合は100件）
+	Limit         int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobHistoryRequest) Reset() {
+	*x = ListJobHistoryRequest{}
+	mi := &file_progress_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobHistoryRequest) ProtoMessage() {}
+
+func (x *ListJobHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_progress_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListJobHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_progress_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListJobHistoryRequest) GetStartedFrom() int64 {
+	if x != nil {
+		return x.StartedFrom
+	}
+	return 0
+}
+
+func (x *ListJobHistoryRequest) GetStartedTo() int64 {
+	if x != nil {
+		return x.StartedTo
+	}
+	return 0
+}
+
+func (x *ListJobHistoryRequest) GetStates() []JobState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *ListJobHistoryRequest) GetJobType() string {
+	if x != nil {
+		return x.JobType
+	}
+	return ""
+}
+
+func (x *ListJobHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
 var File_progress_proto protoreflect.FileDescriptor
 
 const file_progress_proto_rawDesc = "" +
 	"\n" +
-	"\x0eprogress.proto\x12\x11desktop_server.v1\".\n" +
+	"\x0eprogress.proto\x12\x11desktop_server.v1\"\xab\x02\n" +
 	"\x15StreamProgressRequest\x12\x15\n" +
-	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"\xf8\x01\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12%\n" +
+	"\x0esince_sequence\x18\x02 \x01(\x03R\rsinceSequence\x12'\n" +
+	"\x0fsince_timestamp\x18\x03 \x01(\x03R\x0esinceTimestamp\x12<\n" +
+	"\x1aheartbeat_interval_seconds\x18\x04 \x01(\x05R\x18heartbeatIntervalSeconds\x127\n" +
+	"\x04mode\x18\x05 \x01(\x0e2#.desktop_server.v1.SubscriptionModeR\x04mode\x12\x17\n" +
+	"\ajob_ids\x18\x06 \x03(\tR\x06jobIds\x12\x1b\n" +
+	"\tjob_types\x18\a \x03(\tR\bjobTypes\"\x99\x05\n" +
 	"\x0eProgressUpdate\x123\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x1f.desktop_server.v1.ProgressTypeR\x04type\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12!\n" +
@@ -240,15 +1102,107 @@ const file_progress_proto_rawDesc = "" +
 	"percentage\x18\x05 \x01(\x05R\n" +
 	"percentage\x12\x15\n" +
 	"\x06job_id\x18\x06 \x01(\tR\x05jobId\x12\x1c\n" +
-	"\ttimestamp\x18\a \x01(\x03R\ttimestamp*\x99\x01\n" +
+	"\ttimestamp\x18\a \x01(\x03R\ttimestamp\x12\x1a\n" +
+	"\bsequence\x18\b \x01(\x03R\bsequence\x12\x19\n" +
+	"\bjob_type\x18\t \x01(\tR\ajobType\x12+\n" +
+	"\x11bytes_transferred\x18\n" +
+	" \x01(\x03R\x10bytesTransferred\x12\x1f\n" +
+	"\vbytes_total\x18\v \x01(\x03R\n" +
+	"bytesTotal\x12(\n" +
+	"\x10bytes_per_second\x18\f \x01(\x01R\x0ebytesPerSecond\x12\x1f\n" +
+	"\veta_seconds\x18\r \x01(\x03R\n" +
+	"etaSeconds\x12\"\n" +
+	"\rparent_job_id\x18\x0e \x01(\tR\vparentJobId\x12H\n" +
+	"\adetails\x18\x0f \x03(\v2..desktop_server.v1.ProgressUpdate.DetailsEntryR\adetails\x12%\n" +
+	"\x0equeue_position\x18\x10 \x01(\x05R\rqueuePosition\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xcb\x05\n" +
+	"\x03Job\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x19\n" +
+	"\bjob_type\x18\x02 \x01(\tR\ajobType\x121\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x1b.desktop_server.v1.JobStateR\x05state\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12!\n" +
+	"\fcurrent_step\x18\x05 \x01(\x05R\vcurrentStep\x12\x1f\n" +
+	"\vtotal_steps\x18\x06 \x01(\x05R\n" +
+	"totalSteps\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\a \x01(\x05R\n" +
+	"percentage\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\b \x01(\x03R\tstartedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\x03R\tupdatedAt\x12\x1f\n" +
+	"\vfinished_at\x18\n" +
+	" \x01(\x03R\n" +
+	"finishedAt\x12#\n" +
+	"\rlast_sequence\x18\v \x01(\x03R\flastSequence\x12+\n" +
+	"\x11bytes_transferred\x18\f \x01(\x03R\x10bytesTransferred\x12\x1f\n" +
+	"\vbytes_total\x18\r \x01(\x03R\n" +
+	"bytesTotal\x12(\n" +
+	"\x10bytes_per_second\x18\x0e \x01(\x01R\x0ebytesPerSecond\x12\x1f\n" +
+	"\veta_seconds\x18\x0f \x01(\x03R\n" +
+	"etaSeconds\x12\"\n" +
+	"\rparent_job_id\x18\x10 \x01(\tR\vparentJobId\x12=\n" +
+	"\adetails\x18\x11 \x03(\v2#.desktop_server.v1.Job.DetailsEntryR\adetails\x12%\n" +
+	"\x0equeue_position\x18\x12 \x01(\x05R\rqueuePosition\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x85\x01\n" +
+	"\x0fListJobsRequest\x123\n" +
+	"\x06states\x18\x01 \x03(\x0e2\x1b.desktop_server.v1.JobStateR\x06states\x12\x19\n" +
+	"\bjob_type\x18\x02 \x01(\tR\ajobType\x12\"\n" +
+	"\rparent_job_id\x18\x03 \x01(\tR\vparentJobId\">\n" +
+	"\x10ListJobsResponse\x12*\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x16.desktop_server.v1.JobR\x04jobs\"&\n" +
+	"\rGetJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"-\n" +
+	"\x10WatchJobsRequest\x12\x19\n" +
+	"\bjob_type\x18\x01 \x01(\tR\ajobType\"A\n" +
+	"\x10CancelJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"a\n" +
+	"\x17PublishProgressResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\x05R\baccepted\x12*\n" +
+	"\x11cancelled_job_ids\x18\x02 \x03(\tR\x0fcancelledJobIds\"\xbf\x01\n" +
+	"\x15ListJobHistoryRequest\x12!\n" +
+	"\fstarted_from\x18\x01 \x01(\x03R\vstartedFrom\x12\x1d\n" +
+	"\n" +
+	"started_to\x18\x02 \x01(\x03R\tstartedTo\x123\n" +
+	"\x06states\x18\x03 \x03(\x0e2\x1b.desktop_server.v1.JobStateR\x06states\x12\x19\n" +
+	"\bjob_type\x18\x04 \x01(\tR\ajobType\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit*y\n" +
+	"\x10SubscriptionMode\x12!\n" +
+	"\x1dSUBSCRIPTION_MODE_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cSUBSCRIPTION_MODE_AUTO_CLOSE\x10\x01\x12 \n" +
+	"\x1cSUBSCRIPTION_MODE_PERSISTENT\x10\x02*\x8c\x02\n" +
 	"\fProgressType\x12\x1d\n" +
 	"\x19PROGRESS_TYPE_UNSPECIFIED\x10\x00\x12\x19\n" +
 	"\x15PROGRESS_TYPE_STARTED\x10\x01\x12\x1a\n" +
 	"\x16PROGRESS_TYPE_PROGRESS\x10\x02\x12\x1a\n" +
 	"\x16PROGRESS_TYPE_COMPLETE\x10\x03\x12\x17\n" +
-	"\x13PROGRESS_TYPE_ERROR\x10\x042z\n" +
+	"\x13PROGRESS_TYPE_ERROR\x10\x04\x12\x1b\n" +
+	"\x17PROGRESS_TYPE_CANCELLED\x10\x05\x12\x1b\n" +
+	"\x17PROGRESS_TYPE_HEARTBEAT\x10\x06\x12\x18\n" +
+	"\x14PROGRESS_TYPE_QUEUED\x10\a\x12\x1d\n" +
+	"\x19PROGRESS_TYPE_DEAD_LETTER\x10\b*\xca\x01\n" +
+	"\bJobState\x12\x19\n" +
+	"\x15JOB_STATE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11JOB_STATE_STARTED\x10\x01\x12\x15\n" +
+	"\x11JOB_STATE_RUNNING\x10\x02\x12\x16\n" +
+	"\x12JOB_STATE_COMPLETE\x10\x03\x12\x13\n" +
+	"\x0fJOB_STATE_ERROR\x10\x04\x12\x17\n" +
+	"\x13JOB_STATE_CANCELLED\x10\x05\x12\x14\n" +
+	"\x10JOB_STATE_QUEUED\x10\x06\x12\x19\n" +
+	"\x15JOB_STATE_DEAD_LETTER\x10\a2\xee\x04\n" +
 	"\x0fProgressService\x12g\n" +
-	"\x16StreamDownloadProgress\x12(.desktop_server.v1.StreamProgressRequest\x1a!.desktop_server.v1.ProgressUpdate0\x01B=Z;github.com/yhonda-ohishi-pub-dev/desktop-server/proto;protob\x06proto3"
+	"\x16StreamDownloadProgress\x12(.desktop_server.v1.StreamProgressRequest\x1a!.desktop_server.v1.ProgressUpdate0\x01\x12S\n" +
+	"\bListJobs\x12\".desktop_server.v1.ListJobsRequest\x1a#.desktop_server.v1.ListJobsResponse\x12B\n" +
+	"\x06GetJob\x12 .desktop_server.v1.GetJobRequest\x1a\x16.desktop_server.v1.Job\x12J\n" +
+	"\tWatchJobs\x12#.desktop_server.v1.WatchJobsRequest\x1a\x16.desktop_server.v1.Job0\x01\x12H\n" +
+	"\tCancelJob\x12#.desktop_server.v1.CancelJobRequest\x1a\x16.desktop_server.v1.Job\x12b\n" +
+	"\x0fPublishProgress\x12!.desktop_server.v1.ProgressUpdate\x1a*.desktop_server.v1.PublishProgressResponse(\x01\x12_\n" +
+	"\x0eListJobHistory\x12(.desktop_server.v1.ListJobHistoryRequest\x1a#.desktop_server.v1.ListJobsResponseB=Z;github.com/yhonda-ohishi-pub-dev/desktop-server/proto;protob\x06proto3"
 
 var (
 	file_progress_proto_rawDescOnce sync.Once
@@ -262,22 +1216,53 @@ func file_progress_proto_rawDescGZIP() []byte {
 	return file_progress_proto_rawDescData
 }
 
-var file_progress_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_progress_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_progress_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_progress_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_progress_proto_goTypes = []any{
-	(ProgressType)(0),             // 0: desktop_server.v1.ProgressType
-	(*StreamProgressRequest)(nil), // 1: desktop_server.v1.StreamProgressRequest
-	(*ProgressUpdate)(nil),        // 2: desktop_server.v1.ProgressUpdate
+	(SubscriptionMode)(0),           // 0: desktop_server.v1.SubscriptionMode
+	(ProgressType)(0),               // 1: desktop_server.v1.ProgressType
+	(JobState)(0),                   // 2: desktop_server.v1.JobState
+	(*StreamProgressRequest)(nil),   // 3: desktop_server.v1.StreamProgressRequest
+	(*ProgressUpdate)(nil),          // 4: desktop_server.v1.ProgressUpdate
+	(*Job)(nil),                     // 5: desktop_server.v1.Job
+	(*ListJobsRequest)(nil),         // 6: desktop_server.v1.ListJobsRequest
+	(*ListJobsResponse)(nil),        // 7: desktop_server.v1.ListJobsResponse
+	(*GetJobRequest)(nil),           // 8: desktop_server.v1.GetJobRequest
+	(*WatchJobsRequest)(nil),        // 9: desktop_server.v1.WatchJobsRequest
+	(*CancelJobRequest)(nil),        // 10: desktop_server.v1.CancelJobRequest
+	(*PublishProgressResponse)(nil), // 11: desktop_server.v1.PublishProgressResponse
+	(*ListJobHistoryRequest)(nil),   // 12: desktop_server.v1.ListJobHistoryRequest
+	nil,                             // 13: desktop_server.v1.ProgressUpdate.DetailsEntry
+	nil,                             // 14: desktop_server.v1.Job.DetailsEntry
 }
 var file_progress_proto_depIdxs = []int32{
-	0, // 0: desktop_server.v1.ProgressUpdate.type:type_name -> desktop_server.v1.ProgressType
-	1, // 1: desktop_server.v1.ProgressService.StreamDownloadProgress:input_type -> desktop_server.v1.StreamProgressRequest
-	2, // 2: desktop_server.v1.ProgressService.StreamDownloadProgress:output_type -> desktop_server.v1.ProgressUpdate
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0,  // 0: desktop_server.v1.StreamProgressRequest.mode:type_name -> desktop_server.v1.SubscriptionMode
+	1,  // 1: desktop_server.v1.ProgressUpdate.type:type_name -> desktop_server.v1.ProgressType
+	13, // 2: desktop_server.v1.ProgressUpdate.details:type_name -> desktop_server.v1.ProgressUpdate.DetailsEntry
+	2,  // 3: desktop_server.v1.Job.state:type_name -> desktop_server.v1.JobState
+	14, // 4: desktop_server.v1.Job.details:type_name -> desktop_server.v1.Job.DetailsEntry
+	2,  // 5: desktop_server.v1.ListJobsRequest.states:type_name -> desktop_server.v1.JobState
+	5,  // 6: desktop_server.v1.ListJobsResponse.jobs:type_name -> desktop_server.v1.Job
+	2,  // 7: desktop_server.v1.ListJobHistoryRequest.states:type_name -> desktop_server.v1.JobState
+	3,  // 8: desktop_server.v1.ProgressService.StreamDownloadProgress:input_type -> desktop_server.v1.StreamProgressRequest
+	6,  // 9: desktop_server.v1.ProgressService.ListJobs:input_type -> desktop_server.v1.ListJobsRequest
+	8,  // 10: desktop_server.v1.ProgressService.GetJob:input_type -> desktop_server.v1.GetJobRequest
+	9,  // 11: desktop_server.v1.ProgressService.WatchJobs:input_type -> desktop_server.v1.WatchJobsRequest
+	10, // 12: desktop_server.v1.ProgressService.CancelJob:input_type -> desktop_server.v1.CancelJobRequest
+	4,  // 13: desktop_server.v1.ProgressService.PublishProgress:input_type -> desktop_server.v1.ProgressUpdate
+	12, // 14: desktop_server.v1.ProgressService.ListJobHistory:input_type -> desktop_server.v1.ListJobHistoryRequest
+	4,  // 15: desktop_server.v1.ProgressService.StreamDownloadProgress:output_type -> desktop_server.v1.ProgressUpdate
+	7,  // 16: desktop_server.v1.ProgressService.ListJobs:output_type -> desktop_server.v1.ListJobsResponse
+	5,  // 17: desktop_server.v1.ProgressService.GetJob:output_type -> desktop_server.v1.Job
+	5,  // 18: desktop_server.v1.ProgressService.WatchJobs:output_type -> desktop_server.v1.Job
+	5,  // 19: desktop_server.v1.ProgressService.CancelJob:output_type -> desktop_server.v1.Job
+	11, // 20: desktop_server.v1.ProgressService.PublishProgress:output_type -> desktop_server.v1.PublishProgressResponse
+	7,  // 21: desktop_server.v1.ProgressService.ListJobHistory:output_type -> desktop_server.v1.ListJobsResponse
+	15, // [15:22] is the sub-list for method output_type
+	8,  // [8:15] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_progress_proto_init() }
@@ -290,8 +1275,8 @@ func file_progress_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_progress_proto_rawDesc), len(file_progress_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   2,
+			NumEnums:      3,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
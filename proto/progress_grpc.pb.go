@@ -20,6 +20,12 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	ProgressService_StreamDownloadProgress_FullMethodName = "/desktop_server.v1.ProgressService/StreamDownloadProgress"
+	ProgressService_ListJobs_FullMethodName               = "/desktop_server.v1.ProgressService/ListJobs"
+	ProgressService_GetJob_FullMethodName                 = "/desktop_server.v1.ProgressService/GetJob"
+	ProgressService_WatchJobs_FullMethodName              = "/desktop_server.v1.ProgressService/WatchJobs"
+	ProgressService_CancelJob_FullMethodName              = "/desktop_server.v1.ProgressService/CancelJob"
+	ProgressService_PublishProgress_FullMethodName        = "/desktop_server.v1.ProgressService/PublishProgress"
+	ProgressService_ListJobHistory_FullMethodName         = "/desktop_server.v1.ProgressService/ListJobHistory"
 )
 
 // ProgressServiceClient is the client API for ProgressService service.
@@ -30,6 +36,19 @@ const (
 type ProgressServiceClient interface {
 	// ダウンロード進捗をストリーミング
 	StreamDownloadProgress(ctx context.Context, in *StreamProgressRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProgressUpdate], error)
+	// ジョブ一覧を取得
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
+	// ジョブを取得
+	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error)
+	// ジョブの状態変化をストリーミング（接続時に現在の全ジョブを送信）
+	WatchJobs(ctx context.Context, in *WatchJobsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Job], error)
+	// 実行中のジョブをキャンセル
+	CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*Job, error)
+	// 外部プロセスから進捗を送信（メタデータ x-publisher-id で送信元を識別）
+	// 送信できる種類はSTARTED、PROGRESS、COMPLETE、ERRORのみ。x-publisher-idはクライアントが指定するため認証には使えない
+	PublishProgress(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ProgressUpdate, PublishProgressResponse], error)
+	// 再起動をまたいで保存されたジョブ履歴を検索
+	ListJobHistory(ctx context.Context, in *ListJobHistoryRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
 }
 
 type progressServiceClient struct {
@@ -59,6 +78,78 @@ func (c *progressServiceClient) StreamDownloadProgress(ctx context.Context, in *
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ProgressService_StreamDownloadProgressClient = grpc.ServerStreamingClient[ProgressUpdate]
 
+func (c *progressServiceClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListJobsResponse)
+	err := c.cc.Invoke(ctx, ProgressService_ListJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *progressServiceClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Job)
+	err := c.cc.Invoke(ctx, ProgressService_GetJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *progressServiceClient) WatchJobs(ctx context.Context, in *WatchJobsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Job], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProgressService_ServiceDesc.Streams[1], ProgressService_WatchJobs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchJobsRequest, Job]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProgressService_WatchJobsClient = grpc.ServerStreamingClient[Job]
+
+func (c *progressServiceClient) CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Job)
+	err := c.cc.Invoke(ctx, ProgressService_CancelJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *progressServiceClient) PublishProgress(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ProgressUpdate, PublishProgressResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProgressService_ServiceDesc.Streams[2], ProgressService_PublishProgress_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ProgressUpdate, PublishProgressResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProgressService_PublishProgressClient = grpc.ClientStreamingClient[ProgressUpdate, PublishProgressResponse]
+
+func (c *progressServiceClient) ListJobHistory(ctx context.Context, in *ListJobHistoryRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListJobsResponse)
+	err := c.cc.Invoke(ctx, ProgressService_ListJobHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProgressServiceServer is the server API for ProgressService service.
 // All implementations must embed UnimplementedProgressServiceServer
 // for forward compatibility.
@@ -67,6 +158,19 @@ type ProgressService_StreamDownloadProgressClient = grpc.ServerStreamingClient[P
 type ProgressServiceServer interface {
 	// ダウンロード進捗をストリーミング
 	StreamDownloadProgress(*StreamProgressRequest, grpc.ServerStreamingServer[ProgressUpdate]) error
+	// ジョブ一覧を取得
+	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
+	// ジョブを取得
+	GetJob(context.Context, *GetJobRequest) (*Job, error)
+	// ジョブの状態変化をストリーミング（接続時に現在の全ジョブを送信）
+	WatchJobs(*WatchJobsRequest, grpc.ServerStreamingServer[Job]) error
+	// 実行中のジョブをキャンセル
+	CancelJob(context.Context, *CancelJobRequest) (*Job, error)
+	// 外部プロセスから進捗を送信（メタデータ x-publisher-id で送信元を識別）
+	// 送信できる種類はSTARTED、PROGRESS、COMPLETE、ERRORのみ。x-publisher-idはクライアントが指定するため認証には使えない
+	PublishProgress(grpc.ClientStreamingServer[ProgressUpdate, PublishProgressResponse]) error
+	// 再起動をまたいで保存されたジョブ履歴を検索
+	ListJobHistory(context.Context, *ListJobHistoryRequest) (*ListJobsResponse, error)
 	mustEmbedUnimplementedProgressServiceServer()
 }
 
@@ -80,6 +184,24 @@ type UnimplementedProgressServiceServer struct{}
 func (UnimplementedProgressServiceServer) StreamDownloadProgress(*StreamProgressRequest, grpc.ServerStreamingServer[ProgressUpdate]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamDownloadProgress not implemented")
 }
+func (UnimplementedProgressServiceServer) ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (UnimplementedProgressServiceServer) GetJob(context.Context, *GetJobRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (UnimplementedProgressServiceServer) WatchJobs(*WatchJobsRequest, grpc.ServerStreamingServer[Job]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchJobs not implemented")
+}
+func (UnimplementedProgressServiceServer) CancelJob(context.Context, *CancelJobRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelJob not implemented")
+}
+func (UnimplementedProgressServiceServer) PublishProgress(grpc.ClientStreamingServer[ProgressUpdate, PublishProgressResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method PublishProgress not implemented")
+}
+func (UnimplementedProgressServiceServer) ListJobHistory(context.Context, *ListJobHistoryRequest) (*ListJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobHistory not implemented")
+}
 func (UnimplementedProgressServiceServer) mustEmbedUnimplementedProgressServiceServer() {}
 func (UnimplementedProgressServiceServer) testEmbeddedByValue()                         {}
 
@@ -112,19 +234,136 @@ func _ProgressService_StreamDownloadProgress_Handler(srv interface{}, stream grp
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ProgressService_StreamDownloadProgressServer = grpc.ServerStreamingServer[ProgressUpdate]
 
+func _ProgressService_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProgressServiceServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProgressService_ListJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProgressServiceServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProgressService_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProgressServiceServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProgressService_GetJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProgressServiceServer).GetJob(ctx, req.(*GetJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProgressService_WatchJobs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchJobsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProgressServiceServer).WatchJobs(m, &grpc.GenericServerStream[WatchJobsRequest, Job]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProgressService_WatchJobsServer = grpc.ServerStreamingServer[Job]
+
+func _ProgressService_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProgressServiceServer).CancelJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProgressService_CancelJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProgressServiceServer).CancelJob(ctx, req.(*CancelJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProgressService_PublishProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProgressServiceServer).PublishProgress(&grpc.GenericServerStream[ProgressUpdate, PublishProgressResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProgressService_PublishProgressServer = grpc.ClientStreamingServer[ProgressUpdate, PublishProgressResponse]
+
+func _ProgressService_ListJobHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProgressServiceServer).ListJobHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProgressService_ListJobHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProgressServiceServer).ListJobHistory(ctx, req.(*ListJobHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProgressService_ServiceDesc is the grpc.ServiceDesc for ProgressService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var ProgressService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "desktop_server.v1.ProgressService",
 	HandlerType: (*ProgressServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListJobs",
+			Handler:    _ProgressService_ListJobs_Handler,
+		},
+		{
+			MethodName: "GetJob",
+			Handler:    _ProgressService_GetJob_Handler,
+		},
+		{
+			MethodName: "CancelJob",
+			Handler:    _ProgressService_CancelJob_Handler,
+		},
+		{
+			MethodName: "ListJobHistory",
+			Handler:    _ProgressService_ListJobHistory_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "StreamDownloadProgress",
 			Handler:       _ProgressService_StreamDownloadProgress_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "WatchJobs",
+			Handler:       _ProgressService_WatchJobs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PublishProgress",
+			Handler:       _ProgressService_PublishProgress_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "progress.proto",
 }
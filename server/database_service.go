@@ -0,0 +1,554 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultQueryRows is the row limit of QueryDatabase when none is given
+	defaultQueryRows = 1000
+	// maxQueryRows caps QueryDatabase; larger results should use StreamQuery
+	maxQueryRows = 10000
+)
+
+// DatabaseService exposes DatabaseConnection over gRPC
+type DatabaseService struct {
+	pb.UnimplementedDatabaseServiceServer
+
+	profiles *DatabaseProfiles
+	queries  *SavedQueries
+	history  *QueryHistory
+	running  runningQueries
+}
+
+// DatabaseOption configures a DatabaseService
+type DatabaseOption func(*DatabaseService)
+
+// WithSavedQueries enables the saved query RPCs
+func WithSavedQueries(q *SavedQueries) DatabaseOption {
+	return func(s *DatabaseService) {
+		s.queries = q
+	}
+}
+
+// WithQueryHistory records every statement run through the service in h
+func WithQueryHistory(h *QueryHistory) DatabaseOption {
+	return func(s *DatabaseService) {
+		s.history = h
+	}
+}
+
+// NewDatabaseService creates the service. Requests run on the profile they
+// name, or on the selected profile.
+func NewDatabaseService(profiles *DatabaseProfiles, opts ...DatabaseOption) *DatabaseService {
+	s := &DatabaseService{
+		profiles: profiles,
+		running:  runningQueries{queries: make(map[string]*runningQuery)},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// QueryDatabase runs a query and returns up to max_rows rows
+func (s *DatabaseService) QueryDatabase(ctx context.Context, req *pb.QueryRequest) (*pb.QueryResponse, error) {
+	if strings.TrimSpace(req.Sql) == "" {
+		return nil, status.Error(codes.InvalidArgument, "sql is required")
+	}
+	conn, err := s.profiles.Conn(ctx, req.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := sqlArgs(req.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := conn.StatementTimeout(time.Duration(req.TimeoutSeconds) * time.Second)
+	ctx, q := s.startQuery(ctx, conn, "query", "", req.Sql, timeout)
+	resp, err := queryRows(ctx, conn, req.Sql, args, int(req.MaxRows), req.Typed)
+	q.finish(int64(resp.GetCount()), err)
+	return resp, err
+}
+
+// queryRows runs a query and reads up to limit rows (0 = defaultQueryRows,
+// at most maxQueryRows and the row limit of the connection). Typed results
+// are returned as header and typed_rows instead of rows.
+func queryRows(ctx context.Context, conn *DatabaseConnection, query string, args []any, limit int, typed bool) (*pb.QueryResponse, error) {
+	if limit <= 0 {
+		limit = defaultQueryRows
+	}
+	limit = int(conn.RowLimit(int64(min(limit, maxQueryRows))))
+
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, statementError(ctx, "query failed", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read columns: %v", err)
+	}
+
+	resp := &pb.QueryResponse{Columns: columns}
+	var typedColumns []typedColumn
+	if typed {
+		types, err := rows.ColumnTypes()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to read column types: %v", err)
+		}
+		resp.Header, typedColumns = columnMeta(conn.Driver, types)
+	}
+
+	count := 0
+	for rows.Next() {
+		if count >= limit {
+			resp.Truncated = true
+			break
+		}
+		values, err := scanRow(rows, len(columns))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to read row: %v", err)
+		}
+		if typed {
+			resp.TypedRows = append(resp.TypedRows, &pb.TypedRow{Cells: typedCells(typedColumns, values)})
+		} else {
+			resp.Rows = append(resp.Rows, &pb.Row{Columns: rowMap(columns, values)})
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, statementError(ctx, "query failed", err)
+	}
+
+	resp.Count = int32(count)
+	return resp, nil
+}
+
+// StreamQuery runs a query and sends the rows one by one. Typed streams
+// start with a message holding only the column names and header.
+func (s *DatabaseService) StreamQuery(req *pb.StreamQueryRequest, stream pb.DatabaseService_StreamQueryServer) (err error) {
+	if strings.TrimSpace(req.Sql) == "" {
+		return status.Error(codes.InvalidArgument, "sql is required")
+	}
+	ctx := stream.Context()
+	conn, err := s.profiles.Conn(ctx, req.Profile)
+	if err != nil {
+		return err
+	}
+
+	args, err := sqlArgs(req.Params)
+	if err != nil {
+		return err
+	}
+
+	// The query also stops when the client goes away
+	timeout := conn.StatementTimeout(time.Duration(req.TimeoutSeconds) * time.Second)
+	ctx, q := s.startQuery(ctx, conn, "stream", "", req.Sql, timeout)
+	limit := conn.RowLimit(int64(req.MaxRows))
+	var sent int64
+	defer func() {
+		q.finish(sent, err)
+	}()
+
+	rows, err := conn.Query(ctx, req.Sql, args...)
+	if err != nil {
+		return statementError(ctx, "query failed", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to read columns: %v", err)
+	}
+	var header []*pb.ColumnMeta
+	var typedColumns []typedColumn
+	if req.Typed {
+		types, err := rows.ColumnTypes()
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to read column types: %v", err)
+		}
+		header, typedColumns = columnMeta(conn.Driver, types)
+		// The columns are known even when no row follows
+		if err := stream.Send(&pb.QueryRow{ColumnNames: columns, Header: header}); err != nil {
+			return err
+		}
+	}
+
+	for rows.Next() {
+		if limit > 0 && sent >= limit {
+			break
+		}
+		values, err := scanRow(rows, len(columns))
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to read row: %v", err)
+		}
+
+		row := &pb.QueryRow{}
+		if req.Typed {
+			row.Cells = typedCells(typedColumns, values)
+		} else {
+			row.Columns = rowMap(columns, values)
+			if sent == 0 {
+				row.ColumnNames = columns
+			}
+		}
+		if err := stream.Send(row); err != nil {
+			return err
+		}
+		sent++
+	}
+	if err := rows.Err(); err != nil {
+		return statementError(ctx, "query failed", err)
+	}
+
+	log.Printf("StreamQuery sent %d rows", sent)
+	return nil
+}
+
+// GetTables returns the base tables of the database
+func (s *DatabaseService) GetTables(ctx context.Context, req *pb.GetTablesRequest) (*pb.GetTablesResponse, error) {
+	conn, err := s.profiles.Conn(ctx, req.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := conn.WithQueryTimeout(ctx)
+	defer cancel()
+
+	tables, err := conn.GetTables(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get tables: %v", err)
+	}
+	return &pb.GetTablesResponse{Tables: tables}, nil
+}
+
+// ExecuteSQL runs a statement that returns no rows
+func (s *DatabaseService) ExecuteSQL(ctx context.Context, req *pb.ExecuteRequest) (*pb.ExecuteResponse, error) {
+	if strings.TrimSpace(req.Sql) == "" {
+		return nil, status.Error(codes.InvalidArgument, "sql is required")
+	}
+	conn, err := s.profiles.Conn(ctx, req.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := sqlArgs(req.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := conn.StatementTimeout(time.Duration(req.TimeoutSeconds) * time.Second)
+	ctx, q := s.startQuery(ctx, conn, "execute", "", req.Sql, timeout)
+	resp, err := execStatement(ctx, conn, req.Sql, args)
+	q.finish(resp.GetAffectedRows(), err)
+	return resp, err
+}
+
+// execStatement runs a statement and reports the affected rows
+func execStatement(ctx context.Context, conn *DatabaseConnection, query string, args []any) (*pb.ExecuteResponse, error) {
+	result, err := conn.Exec(ctx, query, args...)
+	if err != nil {
+		return nil, statementError(ctx, "execute failed", err)
+	}
+
+	resp := &pb.ExecuteResponse{}
+	if n, err := result.RowsAffected(); err == nil {
+		resp.AffectedRows = n
+	}
+	// SQL Server doesn't support LastInsertId
+	if id, err := result.LastInsertId(); err == nil {
+		resp.LastInsertId = id
+	}
+	return resp, nil
+}
+
+// GetSchema returns the tables and views of a schema with row estimates
+func (s *DatabaseService) GetSchema(ctx context.Context, req *pb.GetSchemaRequest) (*pb.GetSchemaResponse, error) {
+	conn, err := s.profiles.Conn(ctx, req.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := conn.WithQueryTimeout(ctx)
+	defer cancel()
+
+	tables, err := conn.ListTableInfo(ctx, req.Schema)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get schema: %v", err)
+	}
+
+	resp := &pb.GetSchemaResponse{}
+	for _, t := range tables {
+		resp.Tables = append(resp.Tables, tableInfoToProto(t))
+	}
+	return resp, nil
+}
+
+// DescribeTable returns the columns, keys and indexes of a table or view
+func (s *DatabaseService) DescribeTable(ctx context.Context, req *pb.DescribeTableRequest) (*pb.TableSchema, error) {
+	if req.Table == "" {
+		return nil, status.Error(codes.InvalidArgument, "table is required")
+	}
+	conn, err := s.profiles.Conn(ctx, req.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := conn.WithQueryTimeout(ctx)
+	defer cancel()
+
+	ts, err := conn.DescribeTable(ctx, req.Schema, req.Table)
+	if errors.Is(err, ErrTableNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to describe table: %v", err)
+	}
+
+	resp := &pb.TableSchema{
+		Table:          tableInfoToProto(ts.Table),
+		PrimaryKey:     ts.PrimaryKey,
+		ViewDefinition: ts.ViewDefinition,
+	}
+	for _, c := range ts.Columns {
+		resp.Columns = append(resp.Columns, &pb.ColumnInfo{
+			Name:          c.Name,
+			Ordinal:       c.Ordinal,
+			DataType:      c.DataType,
+			ColumnType:    c.ColumnType,
+			Nullable:      c.Nullable,
+			DefaultValue:  c.Default,
+			Collation:     c.Collation,
+			MaxLength:     c.MaxLength,
+			Precision:     c.Precision,
+			Scale:         c.Scale,
+			AutoIncrement: c.AutoIncrement,
+		})
+	}
+	for _, idx := range ts.Indexes {
+		resp.Indexes = append(resp.Indexes, &pb.IndexInfo{
+			Name:    idx.Name,
+			Columns: idx.Columns,
+			Unique:  idx.Unique,
+			Primary: idx.Primary,
+		})
+	}
+	for _, fk := range ts.ForeignKeys {
+		resp.ForeignKeys = append(resp.ForeignKeys, &pb.ForeignKeyInfo{
+			Name:              fk.Name,
+			Columns:           fk.Columns,
+			ReferencedSchema:  fk.ReferencedSchema,
+			ReferencedTable:   fk.ReferencedTable,
+			ReferencedColumns: fk.ReferencedColumns,
+			OnDelete:          fk.OnDelete,
+			OnUpdate:          fk.OnUpdate,
+		})
+	}
+	return resp, nil
+}
+
+// BrowseTable reads a page of a table in key order; next_cursor reads the
+// page after it
+func (s *DatabaseService) BrowseTable(ctx context.Context, req *pb.BrowseTableRequest) (*pb.BrowseTableResponse, error) {
+	if req.Table == "" {
+		return nil, status.Error(codes.InvalidArgument, "table is required")
+	}
+	conn, err := s.profiles.Conn(ctx, req.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := BrowseOptions{
+		Schema:     req.Schema,
+		Table:      req.Table,
+		Columns:    req.Columns,
+		SortColumn: req.SortColumn,
+		Descending: req.Descending,
+		Cursor:     req.Cursor,
+	}
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultBrowsePageSize
+	}
+	opts.PageSize = int(conn.RowLimit(int64(min(pageSize, maxQueryRows))))
+	for _, f := range req.Filters {
+		values := f.Values
+		if f.Value != nil {
+			values = append([]*pb.SqlValue{f.Value}, values...)
+		}
+		args, err := sqlArgs(values)
+		if err != nil {
+			return nil, err
+		}
+		opts.Filters = append(opts.Filters, BrowseFilter{Column: f.Column, Op: f.Op, Values: args})
+	}
+
+	describeCtx, cancel := conn.WithQueryTimeout(ctx)
+	query, err := conn.PrepareBrowse(describeCtx, opts)
+	cancel()
+	switch {
+	case errors.Is(err, ErrTableNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrInvalidBrowse):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrNoBrowseKey):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to describe table: %v", err)
+	}
+
+	timeout := conn.StatementTimeout(time.Duration(req.TimeoutSeconds) * time.Second)
+	ctx, q := s.startQuery(ctx, conn, "browse", "", query.SQL, timeout)
+	page, err := conn.Browse(ctx, query)
+	if err != nil {
+		err = statementError(ctx, "browse failed", err)
+		q.finish(0, err)
+		return nil, err
+	}
+	q.finish(int64(len(page.Rows)), nil)
+
+	result := &pb.QueryResponse{
+		Columns:   page.Columns,
+		Count:     int32(len(page.Rows)),
+		Truncated: page.NextCursor != "",
+	}
+	if req.Typed {
+		var columns []typedColumn
+		result.Header, columns = columnMeta(conn.Driver, page.ColumnTypes)
+		for _, values := range page.Rows {
+			result.TypedRows = append(result.TypedRows, &pb.TypedRow{Cells: typedCells(columns, values)})
+		}
+	} else {
+		for _, values := range page.Rows {
+			result.Rows = append(result.Rows, &pb.Row{Columns: rowMap(page.Columns, values)})
+		}
+	}
+	return &pb.BrowseTableResponse{
+		Result:     result,
+		NextCursor: page.NextCursor,
+		KeyColumns: query.KeyColumns,
+	}, nil
+}
+
+func tableInfoToProto(t TableInfo) *pb.TableInfo {
+	return &pb.TableInfo{
+		Schema:      t.Schema,
+		Name:        t.Name,
+		IsView:      t.IsView,
+		RowEstimate: t.RowEstimate,
+	}
+}
+
+// statementError maps an error of Query or Exec to a gRPC status; statements
+// blocked by the policy are PermissionDenied with the reason, statements
+// stopped by a time limit or by the client DeadlineExceeded or Canceled, and
+// statements stopped by KillQuery Aborted
+func statementError(ctx context.Context, msg string, err error) error {
+	var denied *StatementDeniedError
+	if errors.As(err, &denied) {
+		return status.Error(codes.PermissionDenied, denied.Error())
+	}
+	if ctx.Err() != nil {
+		cause := context.Cause(ctx)
+		code := status.FromContextError(ctx.Err()).Code()
+		if errors.Is(cause, ErrQueryKilled) {
+			code = codes.Aborted
+		}
+		return status.Errorf(code, "%s: %v", msg, cause)
+	}
+	return status.Errorf(codes.InvalidArgument, "%s: %v", msg, err)
+}
+
+// sqlArgs converts typed parameters to driver arguments
+func sqlArgs(params []*pb.SqlValue) ([]any, error) {
+	args := make([]any, len(params))
+	for i, p := range params {
+		arg, err := sqlArg(p)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported parameter %d: %v", i+1, err)
+		}
+		args[i] = arg
+	}
+	return args, nil
+}
+
+// sqlArg converts a typed value to a driver argument
+func sqlArg(p *pb.SqlValue) (any, error) {
+	switch v := p.GetKind().(type) {
+	case nil, *pb.SqlValue_NullValue:
+		return nil, nil
+	case *pb.SqlValue_StringValue:
+		return v.StringValue, nil
+	case *pb.SqlValue_IntValue:
+		return v.IntValue, nil
+	case *pb.SqlValue_DoubleValue:
+		return v.DoubleValue, nil
+	case *pb.SqlValue_BoolValue:
+		return v.BoolValue, nil
+	case *pb.SqlValue_BytesValue:
+		return v.BytesValue, nil
+	case *pb.SqlValue_TimeValue:
+		return v.TimeValue.AsTime(), nil
+	default:
+		return nil, fmt.Errorf("%T", v)
+	}
+}
+
+// scanRow reads the current row into driver values
+func scanRow(rows *sql.Rows, n int) ([]any, error) {
+	values := make([]any, n)
+	ptrs := make([]any, n)
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	if err := rows.Scan(ptrs...); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// rowMap formats a row as column name => text
+func rowMap(columns []string, values []any) map[string]string {
+	m := make(map[string]string, len(columns))
+	for i, col := range columns {
+		m[col] = formatValue(values[i])
+	}
+	return m
+}
+
+// formatValue renders a driver value as text; NULL becomes ""
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05.999999999")
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -1,89 +1,297 @@
 package server
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"log"
+	"maps"
+	"net"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	_ "github.com/microsoft/go-mssqldb"
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
 )
 
 type DatabaseConnection struct {
 	DB     *sql.DB
 	Driver string
+	// Profile is the name of the profile the connection was opened for
+	Profile string
+	// Policy decides which statements Query and Exec accept
+	Policy StatementPolicy
+	// Pool is the pool configuration the connection was opened with
+	Pool PoolConfig
 }
 
-func NewDatabaseConnection() (*DatabaseConnection, error) {
+// EnvProfileName is the profile built from the DB_* environment variables
+const EnvProfileName = "default"
+
+// PoolConfig holds the connection pool settings, timeouts and execution
+// limits of a connection. Zero durations and counts mean no limit.
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+	// ConnectTimeout bounds dialing and logging in to the server
+	ConnectTimeout time.Duration
+	// QueryTimeout bounds a single statement, see StatementTimeout
+	QueryTimeout time.Duration
+	// MaxRows bounds the rows read from a single query, see RowLimit
+	MaxRows int64
+}
+
+// DefaultPoolConfig returns the pool settings used when DB_MAX_OPEN_CONNS,
+// DB_MAX_IDLE_CONNS, DB_CONN_MAX_LIFETIME, DB_CONN_MAX_IDLE_TIME,
+// DB_CONNECT_TIMEOUT, DB_QUERY_TIMEOUT and DB_MAX_ROWS are not set
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		MaxOpenConns:    25,
+		MaxIdleConns:    5,
+		ConnMaxLifetime: time.Hour,
+		ConnMaxIdleTime: 5 * time.Minute,
+		ConnectTimeout:  10 * time.Second,
+	}
+}
+
+// envPoolConfig reads the pool settings from the DB_* environment variables
+// (counts, and durations in seconds); invalid values are logged and ignored
+func envPoolConfig() PoolConfig {
+	cfg := DefaultPoolConfig()
+	setInt := func(name string, dst *int) {
+		v := os.Getenv(name)
+		if v == "" {
+			return
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			log.Printf("Warning: Invalid %s %q", name, v)
+			return
+		}
+		*dst = n
+	}
+	setSeconds := func(name string, dst *time.Duration) {
+		n := -1
+		setInt(name, &n)
+		if n >= 0 {
+			*dst = time.Duration(n) * time.Second
+		}
+	}
+
+	setInt("DB_MAX_OPEN_CONNS", &cfg.MaxOpenConns)
+	setInt("DB_MAX_IDLE_CONNS", &cfg.MaxIdleConns)
+	setSeconds("DB_CONN_MAX_LIFETIME", &cfg.ConnMaxLifetime)
+	setSeconds("DB_CONN_MAX_IDLE_TIME", &cfg.ConnMaxIdleTime)
+	setSeconds("DB_CONNECT_TIMEOUT", &cfg.ConnectTimeout)
+	setSeconds("DB_QUERY_TIMEOUT", &cfg.QueryTimeout)
+	maxRows := int(cfg.MaxRows)
+	setInt("DB_MAX_ROWS", &maxRows)
+	cfg.MaxRows = int64(maxRows)
+	return cfg
+}
+
+// profilePoolConfig returns the pool settings of a profile; settings the
+// profile leaves at 0 come from the environment
+func profilePoolConfig(p *pb.DatabaseProfile) PoolConfig {
+	cfg := envPoolConfig()
+	if p.MaxOpenConns > 0 {
+		cfg.MaxOpenConns = int(p.MaxOpenConns)
+	}
+	if p.MaxIdleConns > 0 {
+		cfg.MaxIdleConns = int(p.MaxIdleConns)
+	}
+	seconds := func(n int32, dst *time.Duration) {
+		if n > 0 {
+			*dst = time.Duration(n) * time.Second
+		}
+	}
+	seconds(p.ConnMaxLifetimeSeconds, &cfg.ConnMaxLifetime)
+	seconds(p.ConnMaxIdleTimeSeconds, &cfg.ConnMaxIdleTime)
+	seconds(p.ConnectTimeoutSeconds, &cfg.ConnectTimeout)
+	seconds(p.QueryTimeoutSeconds, &cfg.QueryTimeout)
+	if p.MaxRows > 0 {
+		cfg.MaxRows = p.MaxRows
+	}
+	return cfg
+}
+
+// NewDatabaseConnection connects with the DB_* environment variables
+func NewDatabaseConnection(ctx context.Context) (*DatabaseConnection, error) {
+	return OpenDatabaseConnection(ctx, envProfile())
+}
+
+// envProfile builds a connection profile from the DB_* environment variables
+func envProfile() *pb.DatabaseProfile {
 	// Get database configuration from environment variables
 	driver := os.Getenv("DB_DRIVER") // "sqlserver" or "mysql"
 	if driver == "" {
 		driver = "sqlserver" // default
 	}
 
-	var dsn string
+	p := &pb.DatabaseProfile{
+		Name:     EnvProfileName,
+		Driver:   driver,
+		User:     os.Getenv("DB_USER"),
+		Password: os.Getenv("DB_PASSWORD"),
+		Database: os.Getenv("DB_NAME"),
+		FromEnv:  true,
+	}
+	if port, err := strconv.Atoi(os.Getenv("DB_PORT")); err == nil {
+		p.Port = int32(port)
+	}
+
 	switch driver {
 	case "sqlserver":
-		server := os.Getenv("DB_SERVER")
-		if server == "" {
-			server = "localhost"
+		p.Host = os.Getenv("DB_SERVER")
+		if p.User == "" {
+			p.User = "sa"
 		}
-		port := os.Getenv("DB_PORT")
-		if port == "" {
-			port = "1433"
+		if p.Database == "" {
+			p.Database = "master"
 		}
-		user := os.Getenv("DB_USER")
-		if user == "" {
-			user = "sa"
+	case "mysql":
+		p.Host = os.Getenv("DB_HOST")
+		if p.User == "" {
+			p.User = "root"
 		}
-		password := os.Getenv("DB_PASSWORD")
-		database := os.Getenv("DB_NAME")
-		if database == "" {
-			database = "master"
+		if p.Database == "" {
+			p.Database = "mysql"
 		}
+	}
 
-		dsn = fmt.Sprintf("server=%s;user id=%s;password=%s;port=%s;database=%s",
-			server, user, password, port, database)
+	// DB_ALLOW_DDL, DB_ALLOW_OTHER and DB_ALLOW_MULTI_STATEMENTS ("true" /
+	// "false"); read-only is server-wide, see SetReadOnlyMode
+	flag := func(name string) bool {
+		v, _ := strconv.ParseBool(os.Getenv(name))
+		return v
+	}
+	p.AllowDdl = flag("DB_ALLOW_DDL")
+	p.AllowOther = flag("DB_ALLOW_OTHER")
+	p.AllowMultiStatements = flag("DB_ALLOW_MULTI_STATEMENTS")
+	return p
+}
 
-	case "mysql":
-		host := os.Getenv("DB_HOST")
-		if host == "" {
-			host = "localhost"
+// profileDSN builds the driver DSN of a profile. Host and port default to
+// localhost and the driver's standard port. connectTimeout becomes the
+// driver's dial timeout unless the profile parameters set one.
+func profileDSN(p *pb.DatabaseProfile, connectTimeout time.Duration) (string, error) {
+	host := p.Host
+	if host == "" {
+		host = "localhost"
+	}
+
+	switch p.Driver {
+	case "sqlserver":
+		port := p.Port
+		if port == 0 {
+			port = 1433
+		}
+		u := &url.URL{
+			Scheme: "sqlserver",
+			User:   url.UserPassword(p.User, p.Password),
 		}
-		port := os.Getenv("DB_PORT")
-		if port == "" {
-			port = "3306"
+		// Named instance: host\instance
+		if h, instance, ok := strings.Cut(host, `\`); ok {
+			u.Host, u.Path = h, instance
+		} else {
+			u.Host = net.JoinHostPort(host, strconv.Itoa(int(port)))
 		}
-		user := os.Getenv("DB_USER")
-		if user == "" {
-			user = "root"
+		query := url.Values{}
+		if p.Database != "" {
+			query.Set("database", p.Database)
 		}
-		password := os.Getenv("DB_PASSWORD")
-		database := os.Getenv("DB_NAME")
-		if database == "" {
-			database = "mysql"
+		if connectTimeout > 0 {
+			query.Set("dial timeout", strconv.Itoa(int(connectTimeout.Seconds())))
 		}
+		for k, v := range p.Params {
+			query.Set(k, v)
+		}
+		u.RawQuery = query.Encode()
+		return u.String(), nil
 
-		dsn = fmt.Sprintf("%s:%s@tcp(%s:%s)/%s",
-			user, password, host, port, database)
+	case "mysql":
+		port := p.Port
+		if port == 0 {
+			port = 3306
+		}
+		cfg := mysql.NewConfig()
+		cfg.User = p.User
+		cfg.Passwd = p.Password
+		cfg.Net = "tcp"
+		cfg.Addr = net.JoinHostPort(host, strconv.Itoa(int(port)))
+		cfg.DBName = p.Database
+		cfg.Timeout = connectTimeout
+		if len(p.Params) > 0 {
+			cfg.Params = maps.Clone(p.Params)
+		}
+		if v, ok := cfg.Params["timeout"]; ok {
+			// FormatDSN writes Timeout itself; a "timeout" param would be
+			// written twice
+			delete(cfg.Params, "timeout")
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return "", fmt.Errorf("invalid timeout parameter %q: %w", v, err)
+			}
+			cfg.Timeout = d
+		}
+		return cfg.FormatDSN(), nil
 
 	default:
-		return nil, fmt.Errorf("unsupported database driver: %s", driver)
+		return "", fmt.Errorf("unsupported database driver: %s", p.Driver)
+	}
+}
+
+// profilePolicy returns the statement policy of a profile
+func profilePolicy(p *pb.DatabaseProfile) StatementPolicy {
+	return StatementPolicy{
+		ReadOnly:             p.ReadOnly,
+		AllowDDL:             p.AllowDdl,
+		AllowOther:           p.AllowOther,
+		AllowMultiStatements: p.AllowMultiStatements,
+	}
+}
+
+// OpenDatabaseConnection opens and checks a connection for a profile; ctx
+// bounds the connection check
+func OpenDatabaseConnection(ctx context.Context, p *pb.DatabaseProfile) (*DatabaseConnection, error) {
+	pool := profilePoolConfig(p)
+	dsn, err := profileDSN(p, pool.ConnectTimeout)
+	if err != nil {
+		return nil, err
 	}
 
-	db, err := sql.Open(driver, dsn)
+	db, err := sql.Open(p.Driver, dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
+	db.SetMaxOpenConns(pool.MaxOpenConns)
+	db.SetMaxIdleConns(pool.MaxIdleConns)
+	db.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
 
 	// Test connection
-	if err := db.Ping(); err != nil {
+	if pool.ConnectTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, pool.ConnectTimeout)
+		defer cancel()
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
 	return &DatabaseConnection{
-		DB:     db,
-		Driver: driver,
+		DB:      db,
+		Driver:  p.Driver,
+		Profile: p.Name,
+		Policy:  profilePolicy(p),
+		Pool:    pool,
 	}, nil
 }
 
@@ -94,19 +302,93 @@ func (dc *DatabaseConnection) Close() error {
 	return nil
 }
 
-func (dc *DatabaseConnection) Query(query string, args ...interface{}) (*sql.Rows, error) {
-	return dc.DB.Query(query, args...)
+// Query runs a query after checking it against the statement policy. The
+// query is cancelled with ctx: SQL Server stops the statement, MySQL drops
+// the connection it runs on.
+func (dc *DatabaseConnection) Query(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if err := dc.CheckStatement(query); err != nil {
+		return nil, err
+	}
+	return dc.DB.QueryContext(ctx, query, args...)
+}
+
+// QueryRow is not checked against the statement policy; it is meant for the
+// server's own queries, user SQL goes through Query
+func (dc *DatabaseConnection) QueryRow(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return dc.DB.QueryRowContext(ctx, query, args...)
+}
+
+// Exec runs a statement after checking it against the statement policy; the
+// statement is cancelled with ctx
+func (dc *DatabaseConnection) Exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if err := dc.CheckStatement(query); err != nil {
+		return nil, err
+	}
+	return dc.DB.ExecContext(ctx, query, args...)
+}
+
+// StatementTimeout returns the time limit of a statement: the requested
+// limit, bounded by the query timeout of the connection (0 = no limit)
+func (dc *DatabaseConnection) StatementTimeout(requested time.Duration) time.Duration {
+	if limit := dc.Pool.QueryTimeout; limit > 0 && (requested <= 0 || requested > limit) {
+		return limit
+	}
+	return max(requested, 0)
+}
+
+// RowLimit returns the row limit of a query: the requested limit, bounded by
+// the row limit of the connection (0 = no limit)
+func (dc *DatabaseConnection) RowLimit(requested int64) int64 {
+	if limit := dc.Pool.MaxRows; limit > 0 && (requested <= 0 || requested > limit) {
+		return limit
+	}
+	return max(requested, 0)
+}
+
+// WithQueryTimeout bounds ctx by the query timeout of the connection. The
+// rows of a query must be read before cancel is called.
+func (dc *DatabaseConnection) WithQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if dc.Pool.QueryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, dc.Pool.QueryTimeout)
+}
+
+// CheckStatement returns a *StatementDeniedError if the connection policy or
+// the server-wide read-only mode blocks the SQL text
+func (dc *DatabaseConnection) CheckStatement(query string) error {
+	return dc.Policy.Check(dc.Driver, query, ReadOnlyMode())
 }
 
-func (dc *DatabaseConnection) QueryRow(query string, args ...interface{}) *sql.Row {
-	return dc.DB.QueryRow(query, args...)
+// sqlDialect holds how a driver quotes names and numbers placeholders
+type sqlDialect struct {
+	quote       func(name string) string
+	placeholder func(n int) string
 }
 
-func (dc *DatabaseConnection) Exec(query string, args ...interface{}) (sql.Result, error) {
-	return dc.DB.Exec(query, args...)
+var sqlServerDialect = sqlDialect{
+	quote:       func(name string) string { return "[" + strings.ReplaceAll(name, "]", "]]") + "]" },
+	placeholder: func(n int) string { return "@p" + strconv.Itoa(n) },
+}
+
+var mysqlDialect = sqlDialect{
+	quote:       func(name string) string { return "`" + strings.ReplaceAll(name, "`", "``") + "`" },
+	placeholder: func(int) string { return "?" },
+}
+
+// dialect returns the SQL dialect of the driver
+func (dc *DatabaseConnection) dialect() (sqlDialect, error) {
+	switch dc.Driver {
+	case "sqlserver":
+		return sqlServerDialect, nil
+	case "mysql":
+		return mysqlDialect, nil
+	default:
+		return sqlDialect{}, fmt.Errorf("unsupported driver: %s", dc.Driver)
+	}
 }
 
-func (dc *DatabaseConnection) GetTables() ([]string, error) {
+func (dc *DatabaseConnection) GetTables(ctx context.Context) ([]string, error) {
 	var query string
 	switch dc.Driver {
 	case "sqlserver":
@@ -117,7 +399,7 @@ func (dc *DatabaseConnection) GetTables() ([]string, error) {
 		return nil, fmt.Errorf("unsupported driver: %s", dc.Driver)
 	}
 
-	rows, err := dc.Query(query)
+	rows, err := dc.Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,449 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultBrowsePageSize is the page size of BrowseTable when none is given
+const defaultBrowsePageSize = 100
+
+var (
+	// ErrInvalidBrowse is wrapped by PrepareBrowse errors about the options:
+	// unknown columns, bad filters and cursors of another request
+	ErrInvalidBrowse = errors.New("invalid browse request")
+
+	// ErrNoBrowseKey is returned by PrepareBrowse for a table without a
+	// primary key or a unique key of NOT NULL columns to page by
+	ErrNoBrowseKey = errors.New("no primary key or unique key to page by")
+)
+
+// BrowseFilter restricts the rows to those where Column compares to Values
+// with Op. IN takes any number of values, IS NULL and IS NOT NULL none, the
+// others one.
+type BrowseFilter struct {
+	Column string
+	Op     pb.FilterOp
+	Values []any
+}
+
+// BrowseOptions selects a page of a table
+type BrowseOptions struct {
+	// Schema is the schema of the table ("" = default schema)
+	Schema string
+	Table  string
+	// Columns are the columns to return (empty = all); the key columns are
+	// always returned
+	Columns []string
+	// SortColumn orders the rows ("" = primary key); rows with the same
+	// value are ordered by the primary key
+	SortColumn string
+	Descending bool
+	Filters    []BrowseFilter
+	PageSize   int
+	// Cursor is the NextCursor of the previous page ("" = first page)
+	Cursor string
+}
+
+// BrowseQuery is the statement reading a page, built by PrepareBrowse
+type BrowseQuery struct {
+	SQL  string
+	Args []any
+	// KeyColumns are the columns the rows are ordered by: the sort column,
+	// then the primary key
+	KeyColumns []string
+
+	pageSize    int
+	fingerprint string
+	// keyIndex is the position of each key column in the select list
+	keyIndex []int
+}
+
+// BrowsePage is a page of rows in key order
+type BrowsePage struct {
+	Columns     []string
+	ColumnTypes []*sql.ColumnType
+	Rows        [][]any
+	// NextCursor reads the following page ("" = last page)
+	NextCursor string
+}
+
+// PrepareBrowse builds the statement reading a page of a table. Pages are
+// read by key (keyset pagination) instead of OFFSET, so every page costs the
+// same however deep into the table it is; a cursor only works with the
+// options it was returned for.
+func (dc *DatabaseConnection) PrepareBrowse(ctx context.Context, opts BrowseOptions) (*BrowseQuery, error) {
+	d, err := dc.dialect()
+	if err != nil {
+		return nil, err
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = defaultBrowsePageSize
+	}
+
+	ts, err := dc.DescribeTable(ctx, opts.Schema, opts.Table)
+	if err != nil {
+		return nil, err
+	}
+	column := func(name string) (*ColumnInfo, error) {
+		var folded *ColumnInfo
+		for i := range ts.Columns {
+			if ts.Columns[i].Name == name {
+				return &ts.Columns[i], nil
+			}
+			if folded == nil && strings.EqualFold(ts.Columns[i].Name, name) {
+				folded = &ts.Columns[i]
+			}
+		}
+		if folded == nil {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidBrowse, name)
+		}
+		return folded, nil
+	}
+
+	keys, err := browseKey(ts)
+	if err != nil {
+		return nil, err
+	}
+	var keyColumns []*ColumnInfo
+	if opts.SortColumn != "" {
+		c, err := column(opts.SortColumn)
+		if err != nil {
+			return nil, err
+		}
+		keyColumns = append(keyColumns, c)
+	}
+	for _, name := range keys {
+		c, err := column(name)
+		if err != nil {
+			return nil, err
+		}
+		if len(keyColumns) == 0 || keyColumns[0].Name != c.Name {
+			keyColumns = append(keyColumns, c)
+		}
+	}
+
+	selected := opts.Columns
+	if len(selected) == 0 {
+		for _, c := range ts.Columns {
+			selected = append(selected, c.Name)
+		}
+	}
+	var names []string
+	for _, name := range selected {
+		c, err := column(name)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, c.Name)
+	}
+
+	q := &BrowseQuery{pageSize: opts.PageSize}
+	for _, c := range keyColumns {
+		q.KeyColumns = append(q.KeyColumns, c.Name)
+		i := slices.Index(names, c.Name)
+		if i < 0 {
+			names = append(names, c.Name)
+			i = len(names) - 1
+		}
+		q.keyIndex = append(q.keyIndex, i)
+	}
+
+	bind := func(v any) string {
+		q.Args = append(q.Args, v)
+		return d.placeholder(len(q.Args))
+	}
+
+	var where []string
+	for _, f := range opts.Filters {
+		c, err := column(f.Column)
+		if err != nil {
+			return nil, err
+		}
+		cond, err := filterCondition(d.quote(c.Name), f, bind)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, cond)
+	}
+
+	// The fingerprint ties a cursor to the options it was returned for
+	h := sha256.New()
+	fmt.Fprintf(h, "%s.%s|%q|%q|%v", ts.Table.Schema, ts.Table.Name, names, q.KeyColumns, opts.Descending)
+	for _, f := range opts.Filters {
+		fmt.Fprintf(h, "|%s %v %#v", f.Column, f.Op, f.Values)
+	}
+	q.fingerprint = hex.EncodeToString(h.Sum(nil)[:8])
+
+	if opts.Cursor != "" {
+		after, err := q.decodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, keysetCondition(d, keyColumns, after, opts.Descending, bind))
+	}
+
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = d.quote(name)
+	}
+	order := make([]string, len(keyColumns))
+	for i, c := range keyColumns {
+		order[i] = d.quote(c.Name)
+		if opts.Descending {
+			order[i] += " DESC"
+		}
+	}
+
+	// One row more than the page tells whether there is a next page
+	var b strings.Builder
+	b.WriteString("SELECT ")
+	if dc.Driver == "sqlserver" {
+		fmt.Fprintf(&b, "TOP (%d) ", opts.PageSize+1)
+	}
+	b.WriteString(strings.Join(quoted, ", "))
+	fmt.Fprintf(&b, " FROM %s.%s", d.quote(ts.Table.Schema), d.quote(ts.Table.Name))
+	if len(where) > 0 {
+		b.WriteString(" WHERE " + strings.Join(where, " AND "))
+	}
+	b.WriteString(" ORDER BY " + strings.Join(order, ", "))
+	if dc.Driver == "mysql" {
+		fmt.Fprintf(&b, " LIMIT %d", opts.PageSize+1)
+	}
+	q.SQL = b.String()
+	return q, nil
+}
+
+// browseKey returns the primary key of a table, or else its first unique
+// key without nullable columns
+func browseKey(ts *TableSchema) ([]string, error) {
+	if len(ts.PrimaryKey) > 0 {
+		return ts.PrimaryKey, nil
+	}
+
+	nullable := make(map[string]bool, len(ts.Columns))
+	for _, c := range ts.Columns {
+		nullable[c.Name] = c.Nullable
+	}
+	for _, idx := range ts.Indexes {
+		if !idx.Unique || len(idx.Columns) == 0 {
+			continue
+		}
+		usable := true
+		for _, name := range idx.Columns {
+			if nullable[name] {
+				usable = false
+			}
+		}
+		if usable {
+			return idx.Columns, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s.%s", ErrNoBrowseKey, ts.Table.Schema, ts.Table.Name)
+}
+
+// filterCondition renders a filter on the quoted column, binding its values
+func filterCondition(col string, f BrowseFilter, bind func(any) string) (string, error) {
+	want := 1
+	switch f.Op {
+	case pb.FilterOp_FILTER_OP_IS_NULL, pb.FilterOp_FILTER_OP_IS_NOT_NULL:
+		want = 0
+	case pb.FilterOp_FILTER_OP_IN:
+		if len(f.Values) == 0 {
+			return "", fmt.Errorf("%w: %s %v needs at least one value", ErrInvalidBrowse, f.Column, f.Op)
+		}
+		want = len(f.Values)
+	}
+	if len(f.Values) != want {
+		return "", fmt.Errorf("%w: %s %v takes %d value(s), got %d", ErrInvalidBrowse, f.Column, f.Op, want, len(f.Values))
+	}
+	for _, v := range f.Values {
+		if v == nil {
+			return "", fmt.Errorf("%w: %s %v: NULL can only be matched with IS_NULL", ErrInvalidBrowse, f.Column, f.Op)
+		}
+	}
+
+	switch f.Op {
+	case pb.FilterOp_FILTER_OP_EQ:
+		return col + " = " + bind(f.Values[0]), nil
+	case pb.FilterOp_FILTER_OP_NE:
+		return col + " <> " + bind(f.Values[0]), nil
+	case pb.FilterOp_FILTER_OP_LT:
+		return col + " < " + bind(f.Values[0]), nil
+	case pb.FilterOp_FILTER_OP_LE:
+		return col + " <= " + bind(f.Values[0]), nil
+	case pb.FilterOp_FILTER_OP_GT:
+		return col + " > " + bind(f.Values[0]), nil
+	case pb.FilterOp_FILTER_OP_GE:
+		return col + " >= " + bind(f.Values[0]), nil
+	case pb.FilterOp_FILTER_OP_CONTAINS, pb.FilterOp_FILTER_OP_STARTS_WITH:
+		s, ok := f.Values[0].(string)
+		if !ok {
+			return "", fmt.Errorf("%w: %s %v takes a string", ErrInvalidBrowse, f.Column, f.Op)
+		}
+		pattern := escapeLike(s) + "%"
+		if f.Op == pb.FilterOp_FILTER_OP_CONTAINS {
+			pattern = "%" + pattern
+		}
+		return col + " LIKE " + bind(pattern) + " ESCAPE '!'", nil
+	case pb.FilterOp_FILTER_OP_IS_NULL:
+		return col + " IS NULL", nil
+	case pb.FilterOp_FILTER_OP_IS_NOT_NULL:
+		return col + " IS NOT NULL", nil
+	case pb.FilterOp_FILTER_OP_IN:
+		placeholders := make([]string, len(f.Values))
+		for i, v := range f.Values {
+			placeholders[i] = bind(v)
+		}
+		return col + " IN (" + strings.Join(placeholders, ", ") + ")", nil
+	default:
+		return "", fmt.Errorf("%w: %s: unsupported operator %v", ErrInvalidBrowse, f.Column, f.Op)
+	}
+}
+
+// escapeLike escapes the LIKE wildcards of both dialects with '!'
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_", "[", "![").Replace(s)
+}
+
+// keysetCondition matches the rows after the given key in the page order:
+//
+//	k1 > v1 OR (k1 = v1 AND k2 > v2) OR ...
+//
+// NULL sorts first on both SQL Server and MySQL; only the sort column can be
+// NULL since key columns are NOT NULL.
+func keysetCondition(d sqlDialect, columns []*ColumnInfo, after []any, descending bool, bind func(any) string) string {
+	var terms []string
+	var equal []string
+	for i, c := range columns {
+		col := d.quote(c.Name)
+		var next string
+		switch {
+		case after[i] == nil && !descending:
+			next = col + " IS NOT NULL"
+		case after[i] == nil:
+			// Nothing sorts after NULL in descending order
+		case !descending:
+			next = col + " > " + bind(after[i])
+		case c.Nullable:
+			next = "(" + col + " < " + bind(after[i]) + " OR " + col + " IS NULL)"
+		default:
+			next = col + " < " + bind(after[i])
+		}
+		if next != "" {
+			terms = append(terms, "("+strings.Join(slices.Concat(equal, []string{next}), " AND ")+")")
+		}
+
+		if after[i] == nil {
+			equal = append(equal, col+" IS NULL")
+		} else {
+			equal = append(equal, col+" = "+bind(after[i]))
+		}
+	}
+	return "(" + strings.Join(terms, " OR ") + ")"
+}
+
+// decodeCursor returns the key values of a cursor made by Browse
+func (q *BrowseQuery) decodeCursor(cursor string) ([]any, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidBrowse)
+	}
+	c := &pb.BrowseCursor{}
+	if err := proto.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidBrowse)
+	}
+	if c.Fingerprint != q.fingerprint || len(c.Key) != len(q.KeyColumns) {
+		return nil, fmt.Errorf("%w: cursor belongs to a different table, sort order, column list or filter", ErrInvalidBrowse)
+	}
+
+	after := make([]any, len(c.Key))
+	for i, v := range c.Key {
+		if after[i], err = sqlArg(v); err != nil {
+			return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidBrowse)
+		}
+	}
+	return after, nil
+}
+
+// Browse reads the page selected by a BrowseQuery
+func (dc *DatabaseConnection) Browse(ctx context.Context, q *BrowseQuery) (*BrowsePage, error) {
+	rows, err := dc.Query(ctx, q.SQL, q.Args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &BrowsePage{}
+	if page.Columns, err = rows.Columns(); err != nil {
+		return nil, err
+	}
+	if page.ColumnTypes, err = rows.ColumnTypes(); err != nil {
+		return nil, err
+	}
+
+	more := false
+	for rows.Next() {
+		if len(page.Rows) == q.pageSize {
+			more = true
+			break
+		}
+		values, err := scanRow(rows, len(page.Columns))
+		if err != nil {
+			return nil, err
+		}
+		page.Rows = append(page.Rows, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if more {
+		_, columns := columnMeta(dc.Driver, page.ColumnTypes)
+		last := page.Rows[len(page.Rows)-1]
+		c := &pb.BrowseCursor{Fingerprint: q.fingerprint}
+		for _, i := range q.keyIndex {
+			c.Key = append(c.Key, cursorValue(typedCell(columns[i], last[i])))
+		}
+		data, err := proto.Marshal(c)
+		if err != nil {
+			return nil, err
+		}
+		page.NextCursor = base64.RawURLEncoding.EncodeToString(data)
+	}
+	return page, nil
+}
+
+// cursorValue converts a key cell to the value it is compared with on the
+// next page. Values without a zone are UTC wall clock times, which both
+// drivers send back unchanged.
+func cursorValue(c *pb.Cell) *pb.SqlValue {
+	switch v := c.Value.(type) {
+	case *pb.Cell_IntValue:
+		return &pb.SqlValue{Kind: &pb.SqlValue_IntValue{IntValue: v.IntValue}}
+	case *pb.Cell_DecimalValue:
+		return &pb.SqlValue{Kind: &pb.SqlValue_StringValue{StringValue: v.DecimalValue}}
+	case *pb.Cell_FloatValue:
+		return &pb.SqlValue{Kind: &pb.SqlValue_DoubleValue{DoubleValue: v.FloatValue}}
+	case *pb.Cell_BoolValue:
+		return &pb.SqlValue{Kind: &pb.SqlValue_BoolValue{BoolValue: v.BoolValue}}
+	case *pb.Cell_StringValue:
+		return &pb.SqlValue{Kind: &pb.SqlValue_StringValue{StringValue: v.StringValue}}
+	case *pb.Cell_BytesValue:
+		return &pb.SqlValue{Kind: &pb.SqlValue_BytesValue{BytesValue: v.BytesValue}}
+	case *pb.Cell_TimestampValue:
+		return &pb.SqlValue{Kind: &pb.SqlValue_TimeValue{TimeValue: v.TimestampValue.Time}}
+	default:
+		return &pb.SqlValue{Kind: &pb.SqlValue_NullValue{NullValue: true}}
+	}
+}
@@ -0,0 +1,355 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+// exportProgressInterval is how often ExportRows reports progress
+const exportProgressInterval = 500 * time.Millisecond
+
+// ExportFormat is the file format of a query export
+type ExportFormat string
+
+const (
+	// ExportCSV is comma separated values with a header row
+	ExportCSV ExportFormat = "csv"
+	// ExportJSONL is one JSON object per row
+	ExportJSONL ExportFormat = "jsonl"
+	// ExportXLSX is an Excel workbook with a single worksheet
+	ExportXLSX ExportFormat = "xlsx"
+)
+
+// ParseExportFormat parses "csv", "jsonl" or "xlsx"
+func ParseExportFormat(s string) (ExportFormat, error) {
+	switch f := ExportFormat(strings.ToLower(s)); f {
+	case ExportCSV, ExportJSONL, ExportXLSX:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown export format: %q (want csv, jsonl or xlsx)", s)
+	}
+}
+
+// ContentType returns the MIME type of the format
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case ExportJSONL:
+		return "application/x-ndjson"
+	case ExportXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "text/csv"
+	}
+}
+
+// ExportEncoding is the text encoding of a CSV export
+type ExportEncoding string
+
+const (
+	// ExportUTF8 is plain UTF-8
+	ExportUTF8 ExportEncoding = "utf-8"
+	// ExportUTF8BOM is UTF-8 with a byte order mark, which Excel needs to
+	// open a UTF-8 CSV without garbling Japanese text
+	ExportUTF8BOM ExportEncoding = "utf-8-bom"
+	// ExportShiftJIS is Shift_JIS (CP932), the default of Japanese Excel;
+	// characters it cannot represent are replaced
+	ExportShiftJIS ExportEncoding = "shift_jis"
+)
+
+// ParseExportEncoding parses "utf-8", "utf-8-bom" or "shift_jis"
+func ParseExportEncoding(s string) (ExportEncoding, error) {
+	switch strings.ToLower(strings.ReplaceAll(s, "_", "-")) {
+	case "utf-8", "utf8":
+		return ExportUTF8, nil
+	case "utf-8-bom", "utf8-bom":
+		return ExportUTF8BOM, nil
+	case "shift-jis", "sjis", "cp932", "windows-31j":
+		return ExportShiftJIS, nil
+	default:
+		return "", fmt.Errorf("unknown export encoding: %q (want utf-8, utf-8-bom or shift_jis)", s)
+	}
+}
+
+// ExportOptions controls ExportRows
+type ExportOptions struct {
+	Format ExportFormat
+	// Encoding applies to CSV only (default UTF-8 with BOM); JSON Lines and
+	// XLSX are always UTF-8
+	Encoding ExportEncoding
+	// MaxRows stops the export after this many rows (0 = no limit)
+	MaxRows int64
+	// SheetName names the XLSX worksheet (default "Sheet1")
+	SheetName string
+}
+
+// ExportProgress is reported while ExportRows runs
+type ExportProgress struct {
+	Rows  int64
+	Bytes int64
+}
+
+// exportColumn is a result column with the kind of values it holds, used to
+// write numbers and dates as such even when the driver returns text (MySQL
+// returns every value as []byte without parameters)
+type exportColumn struct {
+	name     string
+	numeric  bool
+	temporal bool
+}
+
+// rowExporter writes rows in one format
+type rowExporter interface {
+	writeHeader(columns []exportColumn) error
+	writeRow(values []any) error
+	close() error
+}
+
+// ExportRows streams rows to w in the given format, one row at a time. It
+// returns the number of rows written. progress, if not nil, is called about
+// every 500ms; ctx stops the export between rows.
+func ExportRows(ctx context.Context, rows *sql.Rows, w io.Writer, opts ExportOptions, progress func(ExportProgress)) (int64, error) {
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read columns: %w", err)
+	}
+	columns := make([]exportColumn, len(types))
+	for i, t := range types {
+		columns[i] = exportColumn{name: t.Name()}
+		columns[i].numeric, columns[i].temporal = classifyColumnType(t.DatabaseTypeName())
+	}
+
+	counter := &countingWriter{w: w}
+	buf := bufio.NewWriter(counter)
+
+	var exp rowExporter
+	switch opts.Format {
+	case ExportCSV, "":
+		exp, err = newCSVExporter(buf, opts.Encoding)
+	case ExportJSONL:
+		exp = &jsonlExporter{w: buf}
+	case ExportXLSX:
+		exp, err = newXLSXExporter(buf, opts.SheetName)
+	default:
+		err = fmt.Errorf("unknown export format: %q", opts.Format)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := exp.writeHeader(columns); err != nil {
+		return 0, err
+	}
+
+	var n int64
+	lastReport := time.Now()
+	for rows.Next() {
+		if opts.MaxRows > 0 && n >= opts.MaxRows {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return n, context.Cause(ctx)
+		}
+
+		values, err := scanRow(rows, len(columns))
+		if err != nil {
+			return n, fmt.Errorf("failed to read row: %w", err)
+		}
+		if err := exp.writeRow(values); err != nil {
+			return n, err
+		}
+		n++
+
+		if progress != nil && time.Since(lastReport) >= exportProgressInterval {
+			lastReport = time.Now()
+			progress(ExportProgress{Rows: n, Bytes: counter.n + int64(buf.Buffered())})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return n, err
+	}
+
+	if err := exp.close(); err != nil {
+		return n, err
+	}
+	if err := buf.Flush(); err != nil {
+		return n, err
+	}
+	if progress != nil {
+		progress(ExportProgress{Rows: n, Bytes: counter.n})
+	}
+	return n, nil
+}
+
+// classifyColumnType tells numeric and date/time columns apart by their
+// database type name (e.g. "DECIMAL", "DATETIME2")
+func classifyColumnType(name string) (numeric, temporal bool) {
+	switch strings.ToUpper(name) {
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "INTEGER", "BIGINT",
+		"UNSIGNED TINYINT", "UNSIGNED SMALLINT", "UNSIGNED MEDIUMINT", "UNSIGNED INT", "UNSIGNED BIGINT",
+		"DECIMAL", "NUMERIC", "FLOAT", "DOUBLE", "REAL", "MONEY", "SMALLMONEY":
+		return true, false
+	case "DATE", "DATETIME", "DATETIME2", "SMALLDATETIME", "DATETIMEOFFSET", "TIMESTAMP":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// csvExporter writes CSV with CRLF line endings, as Excel expects
+type csvExporter struct {
+	w      *csv.Writer
+	closer io.Closer
+	record []string
+}
+
+func newCSVExporter(w io.Writer, enc ExportEncoding) (*csvExporter, error) {
+	e := &csvExporter{}
+	switch enc {
+	case ExportUTF8BOM, "":
+		if _, err := io.WriteString(w, "\xEF\xBB\xBF"); err != nil {
+			return nil, err
+		}
+	case ExportUTF8:
+	case ExportShiftJIS:
+		tw := transform.NewWriter(substituteWriter{w}, encoding.ReplaceUnsupported(japanese.ShiftJIS.NewEncoder()))
+		w, e.closer = tw, tw
+	default:
+		return nil, fmt.Errorf("unknown export encoding: %q", enc)
+	}
+	e.w = csv.NewWriter(w)
+	e.w.UseCRLF = true
+	return e, nil
+}
+
+func (e *csvExporter) writeHeader(columns []exportColumn) error {
+	e.record = make([]string, len(columns))
+	for i, c := range columns {
+		e.record[i] = c.name
+	}
+	return e.w.Write(e.record)
+}
+
+func (e *csvExporter) writeRow(values []any) error {
+	for i, v := range values {
+		e.record[i] = formatValue(v)
+	}
+	return e.w.Write(e.record)
+}
+
+func (e *csvExporter) close() error {
+	e.w.Flush()
+	if err := e.w.Error(); err != nil {
+		return err
+	}
+	if e.closer != nil {
+		return e.closer.Close()
+	}
+	return nil
+}
+
+// substituteWriter turns the SUB control character, which the Shift_JIS
+// encoder emits for characters it cannot represent (e.g. emoji), into "?" so
+// that Excel shows something readable. 0x1A never occurs inside a multibyte
+// Shift_JIS character.
+type substituteWriter struct {
+	w io.Writer
+}
+
+func (s substituteWriter) Write(p []byte) (int, error) {
+	if bytes.IndexByte(p, 0x1A) >= 0 {
+		p = bytes.ReplaceAll(p, []byte{0x1A}, []byte{'?'})
+	}
+	return s.w.Write(p)
+}
+
+// jsonlExporter writes one JSON object per row, with keys in column order
+type jsonlExporter struct {
+	w       *bufio.Writer
+	columns []exportColumn
+	keys    [][]byte
+}
+
+func (e *jsonlExporter) writeHeader(columns []exportColumn) error {
+	e.columns = columns
+	e.keys = make([][]byte, len(columns))
+	for i, c := range columns {
+		key, err := json.Marshal(c.name)
+		if err != nil {
+			return err
+		}
+		e.keys[i] = key
+	}
+	return nil
+}
+
+func (e *jsonlExporter) writeRow(values []any) error {
+	e.w.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			e.w.WriteByte(',')
+		}
+		e.w.Write(e.keys[i])
+		e.w.WriteByte(':')
+
+		data, err := json.Marshal(jsonValue(v, e.columns[i].numeric))
+		if err != nil {
+			return err
+		}
+		e.w.Write(data)
+	}
+	e.w.WriteByte('}')
+	_, err := e.w.WriteString("\n")
+	return err
+}
+
+func (e *jsonlExporter) close() error {
+	return nil
+}
+
+// jsonValue converts a driver value for encoding/json: text of numeric
+// columns stays a number, other text a string, and binary data that is not
+// UTF-8 is base64 encoded
+func jsonValue(v any, numeric bool) any {
+	switch v := v.(type) {
+	case []byte:
+		if numeric {
+			if _, err := strconv.ParseFloat(string(v), 64); err == nil {
+				return json.Number(v)
+			}
+		}
+		if utf8.Valid(v) {
+			return string(v)
+		}
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,255 @@
+package server
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// xlsxMaxRows is the row limit of an Excel worksheet, header included
+	xlsxMaxRows = 1048576
+	// xlsxMaxCellText is the character limit of an Excel cell
+	xlsxMaxCellText = 32767
+)
+
+// xlsxEpoch is day 0 of Excel serial dates
+var xlsxEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// Cell styles defined in xl/styles.xml: 1 = header, 2 = date and time
+const (
+	xlsxStyleHeader   = 1
+	xlsxStyleDateTime = 2
+)
+
+// xlsxParts are the fixed parts of the workbook; the worksheet itself is
+// streamed after them
+var xlsxParts = []struct{ name, content string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`},
+	{"xl/styles.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm:ss"/></numFmts>
+<fonts count="2"><font><sz val="11"/></font><font><b/><sz val="11"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="3">
+<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>
+<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>
+<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+</cellXfs>
+</styleSheet>`},
+}
+
+// xlsxExporter streams a single-sheet workbook. Strings are written inline
+// so that nothing has to be kept in memory for a shared string table.
+type xlsxExporter struct {
+	zw      *zip.Writer
+	w       *bufio.Writer
+	sheet   string
+	columns []exportColumn
+	rows    int
+}
+
+func newXLSXExporter(w io.Writer, sheet string) (*xlsxExporter, error) {
+	if sheet == "" {
+		sheet = "Sheet1"
+	}
+	e := &xlsxExporter{zw: zip.NewWriter(w), sheet: xlsxSheetName(sheet)}
+
+	workbook := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="` + xmlEscape(e.sheet) + `" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+	for _, part := range xlsxParts {
+		if err := e.writePart(part.name, part.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := e.writePart("xl/workbook.xml", workbook); err != nil {
+		return nil, err
+	}
+
+	f, err := e.zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	e.w = bufio.NewWriter(f)
+	e.w.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>` +
+		`<sheetData>`)
+	return e, nil
+}
+
+func (e *xlsxExporter) writePart(name, content string) error {
+	f, err := e.zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(f, content)
+	return err
+}
+
+// xlsxSheetName removes the characters Excel does not allow in sheet names
+// and applies the 31 character limit
+func xlsxSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	if utf8.RuneCountInString(name) > 31 {
+		name = string([]rune(name)[:31])
+	}
+	return name
+}
+
+func (e *xlsxExporter) writeHeader(columns []exportColumn) error {
+	e.columns = columns
+	e.w.WriteString("<row>")
+	for _, c := range columns {
+		e.writeString(c.name, xlsxStyleHeader)
+	}
+	_, err := e.w.WriteString("</row>")
+	e.rows++
+	return err
+}
+
+func (e *xlsxExporter) writeRow(values []any) error {
+	if e.rows >= xlsxMaxRows {
+		return fmt.Errorf("result exceeds the XLSX limit of %d rows", xlsxMaxRows-1)
+	}
+
+	e.w.WriteString("<row>")
+	for i, v := range values {
+		e.writeCell(v, e.columns[i])
+	}
+	_, err := e.w.WriteString("</row>")
+	e.rows++
+	return err
+}
+
+func (e *xlsxExporter) writeCell(v any, col exportColumn) {
+	switch v := v.(type) {
+	case nil:
+		e.w.WriteString("<c/>")
+	case int64:
+		// Excel keeps 15 significant digits; larger IDs stay exact as text
+		if v > 1e15 || v < -1e15 {
+			e.writeString(strconv.FormatInt(v, 10), 0)
+			return
+		}
+		e.writeNumber(strconv.FormatInt(v, 10))
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			e.writeString(strconv.FormatFloat(v, 'g', -1, 64), 0)
+			return
+		}
+		e.writeNumber(strconv.FormatFloat(v, 'g', -1, 64))
+	case bool:
+		b := "0"
+		if v {
+			b = "1"
+		}
+		e.w.WriteString(`<c t="b"><v>` + b + `</v></c>`)
+	case time.Time:
+		e.writeTime(v)
+	case []byte:
+		e.writeText(string(v), col)
+	case string:
+		e.writeText(v, col)
+	default:
+		e.writeString(fmt.Sprint(v), 0)
+	}
+}
+
+// writeText writes a value the driver returned as text, as a number or a
+// date when the column type says so
+func (e *xlsxExporter) writeText(s string, col exportColumn) {
+	if col.numeric {
+		if f, err := strconv.ParseFloat(s, 64); err == nil && math.Abs(f) < 1e15 {
+			e.writeNumber(s)
+			return
+		}
+	}
+	if col.temporal {
+		for _, layout := range []string{"2006-01-02 15:04:05.999999999", "2006-01-02T15:04:05.999999999Z07:00", "2006-01-02"} {
+			if t, err := time.Parse(layout, s); err == nil {
+				e.writeTime(t)
+				return
+			}
+		}
+	}
+	e.writeString(s, 0)
+}
+
+func (e *xlsxExporter) writeNumber(n string) {
+	e.w.WriteString("<c><v>" + n + "</v></c>")
+}
+
+// writeTime writes a date as an Excel serial number (days since 1899-12-30)
+// in the wall clock time of the value. Excel has no dates before 1900, so
+// those are written as text.
+func (e *xlsxExporter) writeTime(t time.Time) {
+	if t.Year() < 1900 {
+		e.writeString(formatValue(t), 0)
+		return
+	}
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	secs := float64(wall.Unix()-xlsxEpoch.Unix()) + float64(wall.Nanosecond())/1e9
+	days := secs / (24 * 60 * 60)
+	fmt.Fprintf(e.w, `<c s="%d"><v>%s</v></c>`, xlsxStyleDateTime, strconv.FormatFloat(days, 'f', -1, 64))
+}
+
+func (e *xlsxExporter) writeString(s string, style int) {
+	if utf8.RuneCountInString(s) > xlsxMaxCellText {
+		s = string([]rune(s)[:xlsxMaxCellText])
+	}
+	if style != 0 {
+		fmt.Fprintf(e.w, `<c s="%d" t="inlineStr"><is><t xml:space="preserve">`, style)
+	} else {
+		e.w.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+	}
+	e.w.WriteString(xmlEscape(s))
+	e.w.WriteString("</t></is></c>")
+}
+
+func (e *xlsxExporter) close() error {
+	e.w.WriteString(`</sheetData></worksheet>`)
+	if err := e.w.Flush(); err != nil {
+		return err
+	}
+	return e.zw.Close()
+}
+
+// xmlEscape escapes text for XML; characters XML cannot hold are replaced
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// defaultQueryHistoryLimit is the page size of ListQueryHistory
+	defaultQueryHistoryLimit = 100
+
+	// maxHistorySQL is the number of bytes of SQL text kept per entry
+	maxHistorySQL = 16 * 1024
+)
+
+// QueryHistory is an append-only JSON Lines store of executed statements,
+// one line per statement, oldest first. Parameter values are not recorded.
+type QueryHistory struct {
+	maxAge time.Duration
+
+	mu      sync.Mutex
+	store   jsonlStore[*pb.QueryHistoryEntry]
+	entries []*pb.QueryHistoryEntry
+}
+
+// OpenQueryHistory loads the history at path, applying the retention limits,
+// and opens it for appending
+func OpenQueryHistory(path string, maxAge time.Duration, maxBytes int64) (*QueryHistory, error) {
+	h := &QueryHistory{
+		maxAge: maxAge,
+		store:  jsonlStore[*pb.QueryHistoryEntry]{name: "query history", path: path, maxBytes: maxBytes},
+	}
+
+	err := h.store.load(func() *pb.QueryHistoryEntry { return &pb.QueryHistoryEntry{} }, func(e *pb.QueryHistoryEntry) {
+		h.entries = append(h.entries, e)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := h.compact(time.Now()); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// compact rewrites the file without entries older than maxAge, then drops
+// the oldest entries that do not fit the size limit
+func (h *QueryHistory) compact(now time.Time) error {
+	if h.maxAge > 0 {
+		cutoff := now.Add(-h.maxAge).Unix()
+		h.entries = slices.DeleteFunc(h.entries, func(e *pb.QueryHistoryEntry) bool {
+			return e.StartedAt < cutoff
+		})
+	}
+
+	newest := slices.Clone(h.entries)
+	slices.Reverse(newest)
+	kept, err := h.store.rewrite(newest)
+	if err != nil {
+		return err
+	}
+	h.entries = slices.Clone(h.entries[len(h.entries)-kept:])
+	return nil
+}
+
+// Record appends an entry; an empty ID is filled in
+func (h *QueryHistory) Record(entry *pb.QueryHistoryEntry) {
+	entry = proto.Clone(entry).(*pb.QueryHistoryEntry)
+	if entry.Id == "" {
+		entry.Id = newID("query")
+	}
+	if len(entry.Sql) > maxHistorySQL {
+		entry.Sql = strings.ToValidUTF8(entry.Sql[:maxHistorySQL], "")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.entries = append(h.entries, entry)
+	if h.store.append(entry) {
+		if err := h.compact(time.Now()); err != nil {
+			log.Printf("Warning: Failed to compact query history: %v", err)
+		}
+	}
+}
+
+// Query returns entries matching the request, newest first
+func (h *QueryHistory) Query(req *pb.ListQueryHistoryRequest) []*pb.QueryHistoryEntry {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultQueryHistoryLimit
+	}
+	search := strings.ToLower(req.Search)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var entries []*pb.QueryHistoryEntry
+	for i := len(h.entries) - 1; i >= 0 && len(entries) < limit; i-- {
+		e := h.entries[i]
+		if req.Profile != "" && e.Profile != req.Profile {
+			continue
+		}
+		if req.ErrorsOnly && e.Error == "" {
+			continue
+		}
+		if req.StartedFrom != 0 && e.StartedAt < req.StartedFrom {
+			continue
+		}
+		if req.StartedTo != 0 && e.StartedAt > req.StartedTo {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(e.Sql), search) &&
+			!strings.Contains(strings.ToLower(e.Error), search) {
+			continue
+		}
+		entries = append(entries, proto.Clone(e).(*pb.QueryHistoryEntry))
+	}
+	return entries
+}
+
+// Clear removes the entries of a profile ("" = all) and returns how many
+// were removed
+func (h *QueryHistory) Clear(profile string) (int, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	n := len(h.entries)
+	h.entries = slices.DeleteFunc(h.entries, func(e *pb.QueryHistoryEntry) bool {
+		return profile == "" || e.Profile == profile
+	})
+	removed := n - len(h.entries)
+	if removed == 0 {
+		return 0, nil
+	}
+	return removed, h.compact(time.Now())
+}
+
+// Close closes the history file
+func (h *QueryHistory) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.store.close()
+}
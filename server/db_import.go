@@ -0,0 +1,634 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	mssql "github.com/microsoft/go-mssqldb"
+	"golang.org/x/text/encoding/japanese"
+)
+
+// ErrInvalidImport is wrapped by PrepareImport errors about the file or the
+// column mapping
+var ErrInvalidImport = errors.New("invalid import")
+
+const (
+	// defaultImportBatchSize is the number of rows per INSERT when none is given
+	defaultImportBatchSize = 500
+	// defaultImportMaxErrors is the number of value errors reported by default
+	defaultImportMaxErrors = 100
+	// maxImportParams keeps an INSERT under the 2100 parameters of SQL Server
+	maxImportParams = 2000
+	// maxImportBatchRows is the row limit of a VALUES list on SQL Server
+	maxImportBatchRows = 1000
+)
+
+// ImportEncoding is the text encoding of an imported CSV
+type ImportEncoding string
+
+const (
+	// ImportDetect picks UTF-8 for files with a BOM or valid UTF-8 text and
+	// Shift_JIS otherwise
+	ImportDetect ImportEncoding = ""
+	// ImportUTF8 is UTF-8 with or without a byte order mark
+	ImportUTF8 ImportEncoding = "utf-8"
+	// ImportShiftJIS is Shift_JIS (CP932), as saved by Japanese Excel
+	ImportShiftJIS ImportEncoding = "shift_jis"
+)
+
+// ParseImportEncoding parses "utf-8" or "shift_jis"; "" detects the encoding
+func ParseImportEncoding(s string) (ImportEncoding, error) {
+	switch strings.ToLower(strings.ReplaceAll(s, "_", "-")) {
+	case "", "auto":
+		return ImportDetect, nil
+	case "utf-8", "utf8", "utf-8-bom", "utf8-bom":
+		return ImportUTF8, nil
+	case "shift-jis", "sjis", "cp932", "windows-31j":
+		return ImportShiftJIS, nil
+	default:
+		return "", fmt.Errorf("unknown import encoding: %q (want utf-8 or shift_jis)", s)
+	}
+}
+
+// ImportMapping maps a CSV column, by header name, to a table column
+type ImportMapping struct {
+	CSVColumn string
+	Column    string
+}
+
+// ImportOptions controls PrepareImport
+type ImportOptions struct {
+	// Schema is the schema of the table ("" = default schema)
+	Schema string
+	Table  string
+	// Encoding of the file ("" = detect)
+	Encoding ImportEncoding
+	// Mappings select the CSV columns to import (empty = every column whose
+	// header names a table column)
+	Mappings []ImportMapping
+	// BatchSize is the number of rows per INSERT
+	BatchSize int
+	// MaxErrors bounds the value errors reported
+	MaxErrors int
+	// EmptyAsNull stores empty cells of string columns as NULL instead of ""
+	EmptyAsNull bool
+}
+
+// ImportRowError is a CSV value that cannot be stored in its column
+type ImportRowError struct {
+	// Line is the line of the record in the file; the header is line 1
+	Line      int64
+	CSVColumn string
+	Column    string
+	Value     string
+	Message   string
+}
+
+// ImportResult is the outcome of validating a CSV file
+type ImportResult struct {
+	Encoding   ImportEncoding
+	CSVColumns []string
+	Mappings   []ImportMapping
+	// Unmapped are the CSV columns that are not imported
+	Unmapped []string
+	// Rows is the number of data records
+	Rows int64
+	// ErrorRows is the number of records with at least one error
+	ErrorRows       int64
+	Errors          []ImportRowError
+	ErrorsTruncated bool
+}
+
+// ImportProgress is reported after each INSERT
+type ImportProgress struct {
+	Inserted int64
+	Total    int64
+	Batch    int
+	Batches  int
+}
+
+// CSVImport is a validated CSV file ready to be inserted, built by
+// PrepareImport
+type CSVImport struct {
+	Result ImportResult
+	// SQL is the INSERT statement of a full batch
+	SQL string
+
+	dialect   sqlDialect
+	table     string
+	columns   []string
+	batchSize int
+	rows      [][]any
+	lines     []int64
+}
+
+// importColumn is a mapped column: the CSV field it reads and the table
+// column it is stored in
+type importColumn struct {
+	field  int
+	header string
+	column *ColumnInfo
+}
+
+// PrepareImport decodes a CSV file with a header row, maps its columns to
+// the table and converts every value to the type of its column. Value errors
+// are collected in the result rather than returned; errors about the file,
+// the table or the mapping wrap ErrInvalidImport.
+func (dc *DatabaseConnection) PrepareImport(ctx context.Context, data []byte, opts ImportOptions) (*CSVImport, error) {
+	d, err := dc.dialect()
+	if err != nil {
+		return nil, err
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultImportBatchSize
+	}
+	if opts.MaxErrors <= 0 {
+		opts.MaxErrors = defaultImportMaxErrors
+	}
+
+	text, enc, err := decodeImportText(data, opts.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	r := csv.NewReader(strings.NewReader(text))
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: the file is empty", ErrInvalidImport)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+
+	ts, err := dc.DescribeTable(ctx, opts.Schema, opts.Table)
+	if err != nil {
+		return nil, err
+	}
+	if ts.Table.IsView {
+		return nil, fmt.Errorf("%w: %s.%s is a view", ErrInvalidImport, ts.Table.Schema, ts.Table.Name)
+	}
+
+	columns, err := mapImportColumns(dc.Driver, ts, header, opts.Mappings)
+	if err != nil {
+		return nil, err
+	}
+
+	imp := &CSVImport{
+		Result:  ImportResult{Encoding: enc, CSVColumns: header},
+		dialect: d,
+		table:   d.quote(ts.Table.Schema) + "." + d.quote(ts.Table.Name),
+	}
+	for i, name := range header {
+		if !slices.ContainsFunc(columns, func(c importColumn) bool { return c.field == i }) {
+			imp.Result.Unmapped = append(imp.Result.Unmapped, name)
+		}
+	}
+	for _, c := range columns {
+		imp.Result.Mappings = append(imp.Result.Mappings, ImportMapping{CSVColumn: c.header, Column: c.column.Name})
+		imp.columns = append(imp.columns, d.quote(c.column.Name))
+	}
+
+	report := func(e ImportRowError) {
+		if len(imp.Result.Errors) < opts.MaxErrors {
+			imp.Result.Errors = append(imp.Result.Errors, e)
+		} else {
+			imp.Result.ErrorsTruncated = true
+		}
+	}
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+		}
+		line, _ := r.FieldPos(0)
+		// Excel leaves rows of empty cells after the data
+		if !slices.ContainsFunc(record, func(s string) bool { return s != "" }) {
+			continue
+		}
+		imp.Result.Rows++
+
+		if len(record) != len(header) {
+			imp.Result.ErrorRows++
+			report(ImportRowError{
+				Line:    int64(line),
+				Message: fmt.Sprintf("expected %d fields, got %d", len(header), len(record)),
+			})
+			continue
+		}
+
+		row := make([]any, len(columns))
+		failed := false
+		for i, c := range columns {
+			v, err := importValue(dc.Driver, c.column, record[c.field], opts.EmptyAsNull)
+			if err != nil {
+				failed = true
+				report(ImportRowError{
+					Line:      int64(line),
+					CSVColumn: c.header,
+					Column:    c.column.Name,
+					Value:     record[c.field],
+					Message:   err.Error(),
+				})
+				continue
+			}
+			row[i] = v
+		}
+		if failed {
+			imp.Result.ErrorRows++
+			continue
+		}
+		imp.rows = append(imp.rows, row)
+		imp.lines = append(imp.lines, int64(line))
+	}
+
+	imp.batchSize = max(1, min(opts.BatchSize, maxImportParams/len(columns), maxImportBatchRows))
+	imp.SQL = imp.insertSQL(min(imp.batchSize, max(len(imp.rows), 1)))
+	return imp, nil
+}
+
+// decodeImportText converts the file to UTF-8 text without a byte order mark
+func decodeImportText(data []byte, enc ImportEncoding) (string, ImportEncoding, error) {
+	bom := []byte("\xef\xbb\xbf")
+	detected := enc == ImportDetect
+	if detected {
+		enc = ImportShiftJIS
+		if bytes.HasPrefix(data, bom) || utf8.Valid(data) {
+			enc = ImportUTF8
+		}
+	}
+
+	switch enc {
+	case ImportUTF8:
+		data = bytes.TrimPrefix(data, bom)
+		if !utf8.Valid(data) {
+			return "", enc, fmt.Errorf("%w: the file is not valid UTF-8", ErrInvalidImport)
+		}
+		return string(data), enc, nil
+	case ImportShiftJIS:
+		// The decoder replaces invalid bytes with U+FFFD, which Shift_JIS
+		// cannot encode, instead of failing
+		text, _ := japanese.ShiftJIS.NewDecoder().Bytes(data)
+		if i := bytes.IndexRune(text, utf8.RuneError); i >= 0 {
+			line := bytes.Count(text[:i], []byte("\n")) + 1
+			if detected {
+				return "", enc, fmt.Errorf("%w: the file is neither UTF-8 nor Shift_JIS (invalid bytes on line %d)", ErrInvalidImport, line)
+			}
+			return "", enc, fmt.Errorf("%w: the file is not valid Shift_JIS (invalid bytes on line %d)", ErrInvalidImport, line)
+		}
+		return string(text), enc, nil
+	default:
+		return "", enc, fmt.Errorf("unknown import encoding: %q", enc)
+	}
+}
+
+// mapImportColumns resolves the mapping of CSV fields to table columns.
+// Every NOT NULL column without a default must be mapped.
+func mapImportColumns(driver string, ts *TableSchema, header []string, mappings []ImportMapping) ([]importColumn, error) {
+	column := func(name string) *ColumnInfo {
+		var folded *ColumnInfo
+		for i := range ts.Columns {
+			if ts.Columns[i].Name == name {
+				return &ts.Columns[i]
+			}
+			if folded == nil && strings.EqualFold(ts.Columns[i].Name, name) {
+				folded = &ts.Columns[i]
+			}
+		}
+		return folded
+	}
+
+	var columns []importColumn
+	if len(mappings) == 0 {
+		for i, name := range header {
+			if c := column(name); c != nil {
+				columns = append(columns, importColumn{field: i, header: name, column: c})
+			}
+		}
+	} else {
+		for _, m := range mappings {
+			field := slices.Index(header, strings.TrimSpace(m.CSVColumn))
+			if field < 0 {
+				return nil, fmt.Errorf("%w: the file has no column %q", ErrInvalidImport, m.CSVColumn)
+			}
+			c := column(m.Column)
+			if c == nil {
+				return nil, fmt.Errorf("%w: %s has no column %q", ErrInvalidImport, ts.Table.Name, m.Column)
+			}
+			columns = append(columns, importColumn{field: field, header: header[field], column: c})
+		}
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("%w: no column of the file matches a column of %s", ErrInvalidImport, ts.Table.Name)
+	}
+
+	for i, c := range columns {
+		for _, prev := range columns[:i] {
+			if prev.column == c.column {
+				return nil, fmt.Errorf("%w: column %s is mapped more than once", ErrInvalidImport, c.column.Name)
+			}
+		}
+		if driver == "sqlserver" && c.column.AutoIncrement {
+			return nil, fmt.Errorf("%w: identity column %s cannot be imported", ErrInvalidImport, c.column.Name)
+		}
+		if driver == "sqlserver" && (c.column.DataType == "timestamp" || c.column.DataType == "rowversion") {
+			return nil, fmt.Errorf("%w: rowversion column %s cannot be imported", ErrInvalidImport, c.column.Name)
+		}
+	}
+
+	for i := range ts.Columns {
+		c := &ts.Columns[i]
+		if c.Nullable || c.Default != nil || c.AutoIncrement || slices.ContainsFunc(columns, func(m importColumn) bool { return m.column == c }) {
+			continue
+		}
+		// SQL Server fills in rowversion columns
+		if driver == "sqlserver" && (c.DataType == "timestamp" || c.DataType == "rowversion") {
+			continue
+		}
+		return nil, fmt.Errorf("%w: column %s is NOT NULL without a default and must be mapped", ErrInvalidImport, c.Name)
+	}
+	return columns, nil
+}
+
+var (
+	// decimalPattern matches plain decimal numbers
+	decimalPattern = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)$`)
+	// thousandsPattern matches numbers with comma thousands separators, as
+	// formatted by Excel
+	thousandsPattern = regexp.MustCompile(`^[+-]?\d{1,3}(,\d{3})+(\.\d*)?$`)
+)
+
+// importDateLayouts are the date formats accepted for DATE and the date part
+// of date and time columns
+var importDateLayouts = []string{"2006-1-2", "2006/1/2"}
+
+// importTimeLayouts are the time formats accepted for TIME and the time part
+// of date and time columns
+var importTimeLayouts = []string{"15:04:05.999999999", "15:04"}
+
+// importValue converts a CSV value to the argument stored in the column.
+// Empty cells are NULL, except for string columns unless emptyAsNull.
+func importValue(driver string, c *ColumnInfo, s string, emptyAsNull bool) (any, error) {
+	typ := strings.ToLower(c.DataType)
+	isString := false
+	switch typ {
+	case "char", "varchar", "nchar", "nvarchar", "text", "ntext", "tinytext", "mediumtext", "longtext",
+		"enum", "set", "json", "xml":
+		isString = true
+	}
+	if s == "" && (!isString || emptyAsNull) {
+		if !c.Nullable {
+			return nil, errors.New("a value is required")
+		}
+		return nil, nil
+	}
+
+	trimmed := strings.TrimSpace(s)
+	number := trimmed
+	if thousandsPattern.MatchString(number) {
+		number = strings.ReplaceAll(number, ",", "")
+	}
+
+	switch typ {
+	case "tinyint", "smallint", "mediumint", "int", "integer", "bigint", "year":
+		bits := map[string]int{"tinyint": 8, "smallint": 16, "mediumint": 24, "int": 32, "integer": 32, "bigint": 64, "year": 16}[typ]
+		// TINYINT is unsigned on SQL Server
+		if strings.Contains(c.ColumnType, "unsigned") || (driver == "sqlserver" && typ == "tinyint") {
+			n, err := strconv.ParseUint(number, 10, bits)
+			if err != nil {
+				return nil, fmt.Errorf("not an integer between 0 and %d", uint64(1)<<bits-1)
+			}
+			if n > math.MaxInt64 {
+				return number, nil
+			}
+			return int64(n), nil
+		}
+		n, err := strconv.ParseInt(number, 10, bits)
+		if err != nil {
+			return nil, fmt.Errorf("not an integer between %d and %d", -(int64(1) << (bits - 1)), int64(1)<<(bits-1)-1)
+		}
+		return n, nil
+
+	case "decimal", "numeric", "money", "smallmoney":
+		precision, scale := c.Precision, c.Scale
+		switch typ {
+		case "money":
+			precision, scale = 19, 4
+		case "smallmoney":
+			precision, scale = 10, 4
+		}
+		return importDecimal(number, precision, scale)
+
+	case "float", "real", "double", "double precision":
+		f, err := strconv.ParseFloat(number, 64)
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, errors.New("not a number")
+		}
+		return f, nil
+
+	case "bit", "bool", "boolean":
+		switch strings.ToLower(trimmed) {
+		case "1", "true":
+			return true, nil
+		case "0", "false":
+			return false, nil
+		}
+		// MySQL BIT(n) holds n bits
+		if driver == "mysql" && typ == "bit" {
+			if n, err := strconv.ParseUint(trimmed, 10, int(max(c.Precision, 1))); err == nil {
+				return int64(n), nil
+			}
+		}
+		return nil, errors.New("not a boolean (0, 1, true or false)")
+
+	case "date":
+		t, err := parseImportTime(trimmed, false, time.UTC)
+		if err != nil {
+			return nil, err
+		}
+		return t, nil
+
+	case "datetime", "datetime2", "smalldatetime", "timestamp":
+		t, err := parseImportTime(trimmed, true, time.UTC)
+		if err != nil {
+			return nil, err
+		}
+		return t, nil
+
+	case "datetimeoffset":
+		// Values without an offset are in the local time zone
+		t, err := parseImportTime(trimmed, true, time.Local)
+		if err != nil {
+			return nil, err
+		}
+		return t, nil
+
+	case "time":
+		for _, layout := range importTimeLayouts {
+			if t, err := time.Parse(layout, trimmed); err == nil {
+				return t.Format("15:04:05.9999999"), nil
+			}
+		}
+		return nil, errors.New("not a time (15:04:05)")
+
+	case "binary", "varbinary", "image", "blob", "tinyblob", "mediumblob", "longblob":
+		hexText, ok := strings.CutPrefix(strings.ToLower(trimmed), "0x")
+		if !ok {
+			return nil, errors.New("binary values must be hexadecimal starting with 0x")
+		}
+		b, err := hex.DecodeString(hexText)
+		if err != nil {
+			return nil, errors.New("not a hexadecimal value")
+		}
+		if c.MaxLength > 0 && int64(len(b)) > c.MaxLength {
+			return nil, fmt.Errorf("longer than %d bytes", c.MaxLength)
+		}
+		return b, nil
+
+	case "uniqueidentifier":
+		var u mssql.UniqueIdentifier
+		if err := u.Scan(trimmed); err != nil {
+			return nil, errors.New("not a GUID")
+		}
+		return u.String(), nil
+	}
+
+	if isString && c.MaxLength > 0 && int64(utf8.RuneCountInString(s)) > c.MaxLength {
+		return nil, fmt.Errorf("longer than %d characters", c.MaxLength)
+	}
+	return s, nil
+}
+
+// importDecimal checks that a number fits DECIMAL(precision, scale) without
+// rounding and returns it as text, which both drivers convert exactly
+func importDecimal(s string, precision, scale int32) (any, error) {
+	if !decimalPattern.MatchString(s) {
+		return nil, errors.New("not a number")
+	}
+	intPart, frac, _ := strings.Cut(strings.TrimLeft(s, "+-"), ".")
+	frac = strings.TrimRight(frac, "0")
+	if precision > 0 && int32(len(strings.TrimLeft(intPart, "0"))) > precision-scale {
+		return nil, fmt.Errorf("more than %d digits before the decimal point", precision-scale)
+	}
+	if int32(len(frac)) > scale {
+		return nil, fmt.Errorf("more than %d decimal places", scale)
+	}
+	return s, nil
+}
+
+// parseImportTime parses a date, or a date and time, as written by Excel or
+// in RFC 3339. Times without a zone are taken in loc.
+func parseImportTime(s string, withTime bool, loc *time.Location) (time.Time, error) {
+	if withTime {
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			if loc == time.UTC {
+				// Columns without a zone store the local wall clock
+				t = t.In(time.Local)
+				t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+			}
+			return t, nil
+		}
+	}
+	for _, date := range importDateLayouts {
+		if t, err := time.ParseInLocation(date, s, loc); err == nil {
+			return t, nil
+		}
+		if !withTime {
+			continue
+		}
+		for _, clock := range importTimeLayouts {
+			for _, sep := range []string{" ", "T"} {
+				if t, err := time.ParseInLocation(date+sep+clock, s, loc); err == nil {
+					return t, nil
+				}
+			}
+		}
+	}
+	if withTime {
+		return time.Time{}, errors.New("not a date and time (2006-01-02 15:04:05)")
+	}
+	return time.Time{}, errors.New("not a date (2006-01-02)")
+}
+
+// insertSQL returns the INSERT statement of n rows
+func (imp *CSVImport) insertSQL(n int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "INSERT INTO %s (%s) VALUES ", imp.table, strings.Join(imp.columns, ", "))
+	p := 0
+	for i := range n {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteByte('(')
+		for j := range imp.columns {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			p++
+			b.WriteString(imp.dialect.placeholder(p))
+		}
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+// InsertImport inserts the rows of a CSVImport in batches inside a single
+// transaction: either every row is inserted or none. It must only be called
+// when the result has no errors. progress, if not nil, is called after each
+// batch; ctx cancels the import and rolls it back.
+func (dc *DatabaseConnection) InsertImport(ctx context.Context, imp *CSVImport, progress func(ImportProgress)) (int64, error) {
+	if imp.Result.ErrorRows > 0 {
+		return 0, fmt.Errorf("%w: %d row(s) have errors", ErrInvalidImport, imp.Result.ErrorRows)
+	}
+	if err := dc.CheckStatement(imp.SQL); err != nil {
+		return 0, err
+	}
+
+	tx, err := dc.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	total := int64(len(imp.rows))
+	batches := (len(imp.rows) + imp.batchSize - 1) / imp.batchSize
+	var inserted int64
+	for batch := 0; batch < batches; batch++ {
+		start := batch * imp.batchSize
+		rows := imp.rows[start:min(start+imp.batchSize, len(imp.rows))]
+		args := make([]any, 0, len(rows)*len(imp.columns))
+		for _, row := range rows {
+			args = append(args, row...)
+		}
+
+		if _, err := tx.ExecContext(ctx, imp.insertSQL(len(rows)), args...); err != nil {
+			return 0, fmt.Errorf("insert failed at lines %d-%d: %w", imp.lines[start], imp.lines[start+len(rows)-1], err)
+		}
+		inserted += int64(len(rows))
+		if progress != nil {
+			progress(ImportProgress{Inserted: inserted, Total: total, Batch: batch + 1, Batches: batches})
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return inserted, nil
+}
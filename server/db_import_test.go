@@ -0,0 +1,189 @@
+package server
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDecodeImportText(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		enc     ImportEncoding
+		want    string
+		wantEnc ImportEncoding
+		wantErr bool
+	}{
+		{"utf-8", []byte("名前,値\n"), ImportDetect, "名前,値\n", ImportUTF8, false},
+		{"utf-8 bom", []byte("\xef\xbb\xbfa,b\n"), ImportDetect, "a,b\n", ImportUTF8, false},
+		{"shift_jis", []byte("\x96\xbc\x91\x4f,\x92\x6c\n"), ImportDetect, "名前,値\n", ImportShiftJIS, false},
+		{"forced shift_jis", []byte("a,b\n"), ImportShiftJIS, "a,b\n", ImportShiftJIS, false},
+		{"invalid shift_jis", []byte("a\n\x81\x20\xff\xa0\n"), ImportDetect, "", ImportShiftJIS, true},
+		{"forced invalid shift_jis", []byte{0x81, 0x20, 0xff, 0xa0}, ImportShiftJIS, "", ImportShiftJIS, true},
+		{"forced invalid utf-8", []byte("\x96\xbc"), ImportUTF8, "", ImportUTF8, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, enc, err := decodeImportText(tt.data, tt.enc)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidImport) {
+					t.Fatalf("decodeImportText() = %q, %v; want ErrInvalidImport", got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want || enc != tt.wantEnc {
+				t.Errorf("decodeImportText() = %q, %s, %v; want %q, %s", got, enc, err, tt.want, tt.wantEnc)
+			}
+		})
+	}
+}
+
+func TestImportValue(t *testing.T) {
+	col := func(dataType string, nullable bool) *ColumnInfo {
+		return &ColumnInfo{Name: "c", DataType: dataType, ColumnType: dataType, Nullable: nullable}
+	}
+	varchar5 := &ColumnInfo{Name: "c", DataType: "nvarchar", Nullable: true, MaxLength: 5}
+	decimal := &ColumnInfo{Name: "c", DataType: "decimal", Nullable: true, Precision: 5, Scale: 2}
+	unsigned := &ColumnInfo{Name: "c", DataType: "int", ColumnType: "int unsigned", Nullable: true}
+	varbinary2 := &ColumnInfo{Name: "c", DataType: "varbinary", Nullable: true, MaxLength: 2}
+
+	tests := []struct {
+		name        string
+		driver      string
+		column      *ColumnInfo
+		value       string
+		emptyAsNull bool
+		want        any
+		wantErr     bool
+	}{
+		{"empty int is null", "sqlserver", col("int", true), "", false, nil, false},
+		{"empty not null", "sqlserver", col("int", false), "", false, nil, true},
+		{"empty string", "sqlserver", col("nvarchar", false), "", false, "", false},
+		{"empty string as null", "sqlserver", col("nvarchar", true), "", true, nil, false},
+		{"int", "sqlserver", col("int", true), " -42 ", false, int64(-42), false},
+		{"int thousands", "sqlserver", col("int", true), "1,234,567", false, int64(1234567), false},
+		{"int overflow", "sqlserver", col("int", true), "2147483648", false, nil, true},
+		{"int not a number", "sqlserver", col("int", true), "12a", false, nil, true},
+		{"sqlserver tinyint", "sqlserver", col("tinyint", true), "255", false, int64(255), false},
+		{"sqlserver negative tinyint", "sqlserver", col("tinyint", true), "-1", false, nil, true},
+		{"mysql tinyint", "mysql", col("tinyint", true), "-128", false, int64(-128), false},
+		{"unsigned", "mysql", unsigned, "4294967295", false, int64(4294967295), false},
+		{"unsigned negative", "mysql", unsigned, "-1", false, nil, true},
+		{"unsigned bigint", "mysql", &ColumnInfo{DataType: "bigint", ColumnType: "bigint unsigned"},
+			"18446744073709551615", false, "18446744073709551615", false},
+		{"decimal", "sqlserver", decimal, "123.45", false, "123.45", false},
+		{"decimal thousands", "sqlserver", decimal, "1,234.5", false, nil, true},
+		{"decimal too many places", "sqlserver", decimal, "1.234", false, nil, true},
+		{"money", "sqlserver", col("money", true), "1,234.5678", false, "1234.5678", false},
+		{"float", "sqlserver", col("float", true), "1.5e3", false, 1500.0, false},
+		{"float nan", "sqlserver", col("float", true), "NaN", false, nil, true},
+		{"bit true", "sqlserver", col("bit", true), "TRUE", false, true, false},
+		{"bit zero", "sqlserver", col("bit", true), "0", false, false, false},
+		{"bit invalid", "sqlserver", col("bit", true), "yes", false, nil, true},
+		{"mysql bit", "mysql", &ColumnInfo{DataType: "bit", Precision: 8}, "200", false, int64(200), false},
+		{"date", "sqlserver", col("date", true), "2024/1/2", false, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), false},
+		{"date with time", "sqlserver", col("date", true), "2024-01-02 03:04", false, nil, true},
+		{"datetime", "sqlserver", col("datetime2", true), "2024/1/2 3:04",
+			false, time.Date(2024, 1, 2, 3, 4, 0, 0, time.UTC), false},
+		{"time", "sqlserver", col("time", true), "9:05", false, "09:05:00", false},
+		{"time invalid", "sqlserver", col("time", true), "25:00", false, nil, true},
+		{"varbinary", "sqlserver", varbinary2, "0x01FF", false, []byte{0x01, 0xff}, false},
+		{"varbinary too long", "sqlserver", varbinary2, "0x010203", false, nil, true},
+		{"varbinary without prefix", "sqlserver", varbinary2, "01", false, nil, true},
+		{"guid", "sqlserver", col("uniqueidentifier", true), "6f9619ff-8b86-d011-b42d-00c04fc964ff",
+			false, "6F9619FF-8B86-D011-B42D-00C04FC964FF", false},
+		{"guid invalid", "sqlserver", col("uniqueidentifier", true), "not-a-guid", false, nil, true},
+		{"string length in characters", "sqlserver", varchar5, "日本語テキ", false, "日本語テキ", false},
+		{"string too long", "sqlserver", varchar5, "日本語テキスト", false, nil, true},
+		{"string keeps spaces", "sqlserver", varchar5, " a ", false, " a ", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := importValue(tt.driver, tt.column, tt.value, tt.emptyAsNull)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("importValue(%q) = %#v, want an error", tt.value, got)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("importValue(%q) = %#v, %v; want %#v", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportDecimal(t *testing.T) {
+	tests := []struct {
+		value            string
+		precision, scale int32
+		wantErr          bool
+	}{
+		{"123.45", 5, 2, false},
+		{"-123.45", 5, 2, false},
+		{"+0.5", 5, 2, false},
+		{".5", 5, 2, false},
+		{"1.", 5, 2, false},
+		{"00123.4500", 5, 2, false},
+		{"1234.5", 5, 2, true},
+		{"1.234", 5, 2, true},
+		{"12345678901234567890.123456789012345678", 38, 18, false},
+		{"1e3", 5, 2, true},
+		{"1,234", 5, 2, true},
+		{"", 5, 2, true},
+		{"-", 5, 2, true},
+	}
+	for _, tt := range tests {
+		got, err := importDecimal(tt.value, tt.precision, tt.scale)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("importDecimal(%q, %d, %d) = %v, %v; want error %v", tt.value, tt.precision, tt.scale, got, err, tt.wantErr)
+		}
+		if err == nil && got != tt.value {
+			t.Errorf("importDecimal(%q) = %v, want the value unchanged", tt.value, got)
+		}
+	}
+}
+
+func TestParseImportTime(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	tests := []struct {
+		value    string
+		withTime bool
+		loc      *time.Location
+		want     time.Time
+		wantErr  bool
+	}{
+		{"2024-01-02", false, time.UTC, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), false},
+		{"2024/1/2", false, time.UTC, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), false},
+		{"2024/13/2", false, time.UTC, time.Time{}, true},
+		{"2024-01-02 03:04:05", false, time.UTC, time.Time{}, true},
+		{"2024/1/2 3:04", true, time.UTC, time.Date(2024, 1, 2, 3, 4, 0, 0, time.UTC), false},
+		{"2024-01-02T03:04:05.123", true, time.UTC, time.Date(2024, 1, 2, 3, 4, 5, 123000000, time.UTC), false},
+		{"2024-01-02", true, time.UTC, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), false},
+		{"2024-01-02 03:04", true, jst, time.Date(2024, 1, 2, 3, 4, 0, 0, jst), false},
+		{"2024-01-02T03:04:05+09:00", true, jst, time.Date(2024, 1, 2, 3, 4, 5, 0, jst), false},
+		{"03:04", true, time.UTC, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseImportTime(tt.value, tt.withTime, tt.loc)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseImportTime(%q, %v) = %v, want an error", tt.value, tt.withTime, got)
+			}
+			continue
+		}
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseImportTime(%q, %v) = %v, %v; want %v", tt.value, tt.withTime, got, err, tt.want)
+		}
+	}
+
+	// A zone stored in a column without one keeps the local wall clock
+	got, err := parseImportTime("2024-01-02T03:04:05Z", true, time.UTC)
+	local := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).In(time.Local)
+	want := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), local.Second(), 0, time.UTC)
+	if err != nil || !got.Equal(want) {
+		t.Errorf("parseImportTime(RFC 3339 UTC) = %v, %v; want %v", got, err, want)
+	}
+}
@@ -0,0 +1,460 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// DatabaseProfiles keeps named connection profiles and a pool of open
+// connections keyed by profile name. The "default" profile is built from the
+// DB_* environment variables and is never stored.
+type DatabaseProfiles struct {
+	// path is the profile file ("" = not persisted)
+	path string
+
+	mu       sync.Mutex
+	profiles map[string]*pb.DatabaseProfile
+	selected string
+	conns    map[string]*DatabaseConnection
+}
+
+// NewDatabaseProfiles loads the profiles stored at path. An empty path keeps
+// profiles in memory only.
+func NewDatabaseProfiles(path string) (*DatabaseProfiles, error) {
+	m := &DatabaseProfiles{
+		path:     path,
+		profiles: map[string]*pb.DatabaseProfile{EnvProfileName: envProfile()},
+		selected: EnvProfileName,
+		conns:    make(map[string]*DatabaseConnection),
+	}
+	if path == "" {
+		return m, nil
+	}
+
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// load reads the profile file
+func (m *DatabaseProfiles) load() error {
+	data, err := os.ReadFile(m.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read database profiles: %w", err)
+	}
+
+	stored := &pb.ListProfilesResponse{}
+	if err := protojson.Unmarshal(data, stored); err != nil {
+		return fmt.Errorf("failed to decode database profiles: %w", err)
+	}
+
+	for _, p := range stored.Profiles {
+		if err := validateProfile(p); err != nil {
+			log.Printf("Warning: Skipping database profile %q: %v", p.Name, err)
+			continue
+		}
+		m.profiles[p.Name] = p
+	}
+	if _, ok := m.profiles[stored.Selected]; ok {
+		m.selected = stored.Selected
+	}
+	return nil
+}
+
+// saveLocked writes the stored profiles, passwords included, to the profile
+// file; the file is only readable by the current user
+func (m *DatabaseProfiles) saveLocked() error {
+	if m.path == "" {
+		return nil
+	}
+
+	stored := &pb.ListProfilesResponse{Selected: m.selected}
+	for _, name := range m.namesLocked() {
+		if p := m.profiles[name]; !p.FromEnv {
+			stored.Profiles = append(stored.Profiles, p)
+		}
+	}
+	data, err := protojson.MarshalOptions{Multiline: true}.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("failed to encode database profiles: %w", err)
+	}
+
+	tmpPath := m.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write database profiles: %w", err)
+	}
+	if err := os.Rename(tmpPath, m.path); err != nil {
+		return fmt.Errorf("failed to replace database profiles: %w", err)
+	}
+	return nil
+}
+
+// namesLocked returns the profile names, the environment profile first
+func (m *DatabaseProfiles) namesLocked() []string {
+	names := make([]string, 0, len(m.profiles))
+	for name := range m.profiles {
+		if name != EnvProfileName {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return append([]string{EnvProfileName}, names...)
+}
+
+// describeLocked returns a copy of a profile without its password
+func (m *DatabaseProfiles) describeLocked(p *pb.DatabaseProfile) *pb.DatabaseProfile {
+	out := proto.Clone(p).(*pb.DatabaseProfile)
+	out.HasPassword = p.Password != ""
+	out.Password = ""
+	out.Selected = p.Name == m.selected
+	return out
+}
+
+// sqlServerIdentityParams are connection parameters that go-mssqldb lets
+// override the host, port and credentials of the URL. They would let a
+// profile send its password to another server than the one it names.
+var sqlServerIdentityParams = map[string]bool{
+	"server": true, "port": true, "user id": true, "password": true, "protocol": true,
+	"failoverpartner": true, "failoverport": true,
+	"data source": true, "address": true, "network address": true, "addr": true,
+	"user": true, "uid": true, "pwd": true,
+}
+
+// validateProfile checks the settings of a profile
+func validateProfile(p *pb.DatabaseProfile) error {
+	if strings.TrimSpace(p.GetName()) == "" {
+		return status.Error(codes.InvalidArgument, "profile.name is required")
+	}
+	if p.Name == EnvProfileName && !p.FromEnv {
+		return status.Errorf(codes.InvalidArgument, "profile name %q is reserved for the DB_* environment variables", EnvProfileName)
+	}
+	if p.Driver != "sqlserver" && p.Driver != "mysql" {
+		return status.Errorf(codes.InvalidArgument, "unsupported driver: %q (want sqlserver or mysql)", p.Driver)
+	}
+	if p.Port < 0 || p.Port > 65535 {
+		return status.Errorf(codes.InvalidArgument, "invalid port: %d", p.Port)
+	}
+	if p.Driver == "sqlserver" {
+		for k := range p.Params {
+			if sqlServerIdentityParams[strings.ToLower(strings.TrimSpace(k))] {
+				return status.Errorf(codes.InvalidArgument, "parameter %q is not allowed; use host, port, user and password", k)
+			}
+		}
+	}
+	if p.MaxOpenConns < 0 || p.MaxIdleConns < 0 || p.ConnMaxLifetimeSeconds < 0 ||
+		p.ConnMaxIdleTimeSeconds < 0 || p.ConnectTimeoutSeconds < 0 || p.QueryTimeoutSeconds < 0 {
+		return status.Error(codes.InvalidArgument, "pool settings and timeouts must not be negative")
+	}
+	return nil
+}
+
+// keepPassword gives p the password of the saved profile of the same name
+// when p leaves it empty. The password is only carried over to the same
+// driver, host, port, user and connection parameters, so that a saved name
+// cannot be pointed at another server to obtain the stored credentials.
+func keepPassword(p, saved *pb.DatabaseProfile) error {
+	if p.Password != "" || saved.Password == "" {
+		return nil
+	}
+	if p.Driver != saved.Driver || p.Host != saved.Host || p.Port != saved.Port || p.User != saved.User ||
+		!maps.Equal(p.Params, saved.Params) {
+		return status.Errorf(codes.InvalidArgument,
+			"profile.password is required: driver, host, port, user or params differ from the saved profile %q", saved.Name)
+	}
+	p.Password = saved.Password
+	return nil
+}
+
+// Selected returns the name of the selected profile
+func (m *DatabaseProfiles) Selected() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.selected
+}
+
+// Conn returns the open connection of a profile ("" = the selected one),
+// connecting on first use; ctx bounds the connection attempt
+func (m *DatabaseProfiles) Conn(ctx context.Context, name string) (*DatabaseConnection, error) {
+	m.mu.Lock()
+	if name == "" {
+		name = m.selected
+	}
+	if conn, ok := m.conns[name]; ok {
+		m.mu.Unlock()
+		return conn, nil
+	}
+	p, ok := m.profiles[name]
+	if !ok {
+		m.mu.Unlock()
+		return nil, status.Errorf(codes.NotFound, "database profile not found: %s", name)
+	}
+	p = proto.Clone(p).(*pb.DatabaseProfile)
+	m.mu.Unlock()
+
+	// Connect without holding the lock; the ping can take a while
+	conn, err := OpenDatabaseConnection(ctx, p)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		return nil, status.Errorf(codes.Unavailable, "database %s is not connected: %v", name, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if existing, ok := m.conns[name]; ok {
+		go conn.Close()
+		return existing, nil
+	}
+	if current, ok := m.profiles[name]; !ok || !proto.Equal(current, p) {
+		go conn.Close()
+		return nil, status.Errorf(codes.Aborted, "database profile %s changed while connecting", name)
+	}
+	m.conns[name] = conn
+	log.Printf("Database connected: %s (%s, max %d connections, query timeout %v)",
+		name, p.Driver, conn.Pool.MaxOpenConns, conn.Pool.QueryTimeout)
+	return conn, nil
+}
+
+// dropConnLocked closes the connection of a profile in the background; open
+// queries on it are allowed to finish
+func (m *DatabaseProfiles) dropConnLocked(name string) {
+	if conn, ok := m.conns[name]; ok {
+		delete(m.conns, name)
+		go conn.Close()
+	}
+}
+
+// Close closes every open connection
+func (m *DatabaseProfiles) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for name, conn := range m.conns {
+		conn.Close()
+		delete(m.conns, name)
+	}
+}
+
+// ListProfiles returns all profiles without passwords
+func (s *DatabaseService) ListProfiles(ctx context.Context, req *pb.ListProfilesRequest) (*pb.ListProfilesResponse, error) {
+	m := s.profiles
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	resp := &pb.ListProfilesResponse{Selected: m.selected}
+	for _, name := range m.namesLocked() {
+		resp.Profiles = append(resp.Profiles, m.describeLocked(m.profiles[name]))
+	}
+	return resp, nil
+}
+
+// SaveProfile adds a profile or replaces the one with the same name. An
+// empty password keeps the stored one unless clear_password is set; see
+// keepPassword.
+func (s *DatabaseService) SaveProfile(ctx context.Context, req *pb.SaveProfileRequest) (*pb.DatabaseProfile, error) {
+	if req.Profile == nil {
+		return nil, status.Error(codes.InvalidArgument, "profile is required")
+	}
+	p := proto.Clone(req.Profile).(*pb.DatabaseProfile)
+	p.Name = strings.TrimSpace(p.GetName())
+	p.FromEnv = false
+	p.HasPassword = false
+	p.Selected = false
+	if err := validateProfile(p); err != nil {
+		return nil, err
+	}
+
+	m := s.profiles
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	old, exists := m.profiles[p.Name]
+	if exists && !req.ClearPassword {
+		if err := keepPassword(p, old); err != nil {
+			return nil, err
+		}
+	}
+
+	m.profiles[p.Name] = p
+	if err := m.saveLocked(); err != nil {
+		if exists {
+			m.profiles[p.Name] = old
+		} else {
+			delete(m.profiles, p.Name)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	m.dropConnLocked(p.Name)
+	log.Printf("Database profile saved: %s (%s, %s:%d/%s)", p.Name, p.Driver, p.Host, p.Port, p.Database)
+
+	return m.describeLocked(p), nil
+}
+
+// RemoveProfile deletes a profile and closes its connection. Removing the
+// selected profile selects the environment profile.
+func (s *DatabaseService) RemoveProfile(ctx context.Context, req *pb.RemoveProfileRequest) (*pb.RemoveProfileResponse, error) {
+	if req.Name == EnvProfileName {
+		return nil, status.Error(codes.FailedPrecondition, "the environment profile cannot be removed")
+	}
+
+	m := s.profiles
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	old, ok := m.profiles[req.Name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "database profile not found: %s", req.Name)
+	}
+	oldSelected := m.selected
+
+	delete(m.profiles, req.Name)
+	if m.selected == req.Name {
+		m.selected = EnvProfileName
+	}
+	if err := m.saveLocked(); err != nil {
+		m.profiles[req.Name] = old
+		m.selected = oldSelected
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	m.dropConnLocked(req.Name)
+	log.Printf("Database profile removed: %s", req.Name)
+
+	return &pb.RemoveProfileResponse{}, nil
+}
+
+// SelectProfile makes a profile the one used when a request names none
+func (s *DatabaseService) SelectProfile(ctx context.Context, req *pb.SelectProfileRequest) (*pb.DatabaseProfile, error) {
+	m := s.profiles
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p, ok := m.profiles[req.Name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "database profile not found: %s", req.Name)
+	}
+	oldSelected := m.selected
+
+	m.selected = req.Name
+	if err := m.saveLocked(); err != nil {
+		m.selected = oldSelected
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	log.Printf("Database profile selected: %s", req.Name)
+
+	return m.describeLocked(p), nil
+}
+
+// TestProfile connects with a profile, saved or not, and reports the server
+// version. Connection failures are reported in the response, not as errors.
+func (s *DatabaseService) TestProfile(ctx context.Context, req *pb.TestProfileRequest) (*pb.TestProfileResponse, error) {
+	m := s.profiles
+	m.mu.Lock()
+	var p *pb.DatabaseProfile
+	switch {
+	case req.Profile != nil:
+		p = proto.Clone(req.Profile).(*pb.DatabaseProfile)
+		if saved, ok := m.profiles[p.Name]; ok {
+			if err := keepPassword(p, saved); err != nil {
+				m.mu.Unlock()
+				return nil, err
+			}
+		}
+	case req.Name != "":
+		saved, ok := m.profiles[req.Name]
+		if !ok {
+			m.mu.Unlock()
+			return nil, status.Errorf(codes.NotFound, "database profile not found: %s", req.Name)
+		}
+		p = proto.Clone(saved).(*pb.DatabaseProfile)
+	default:
+		m.mu.Unlock()
+		return nil, status.Error(codes.InvalidArgument, "profile or name is required")
+	}
+	m.mu.Unlock()
+
+	if p.Name == "" {
+		p.Name = "test"
+	}
+	if err := validateProfile(p); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	conn, err := OpenDatabaseConnection(ctx, p)
+	if err != nil {
+		return &pb.TestProfileResponse{Error: err.Error(), LatencyMs: time.Since(start).Milliseconds()}, nil
+	}
+	defer conn.Close()
+
+	query := "SELECT VERSION()"
+	if p.Driver == "sqlserver" {
+		query = "SELECT @@VERSION"
+	}
+	var version string
+	if err := conn.QueryRow(ctx, query).Scan(&version); err != nil {
+		return &pb.TestProfileResponse{Error: err.Error(), LatencyMs: time.Since(start).Milliseconds()}, nil
+	}
+	// @@VERSION spans several lines; the first names the product
+	version, _, _ = strings.Cut(version, "\n")
+
+	return &pb.TestProfileResponse{
+		Ok:            true,
+		ServerVersion: strings.TrimSpace(version),
+		LatencyMs:     time.Since(start).Milliseconds(),
+	}, nil
+}
+
+// GetPoolStats returns the pool settings and live sql.DBStats of every open
+// connection
+func (s *DatabaseService) GetPoolStats(ctx context.Context, req *pb.GetPoolStatsRequest) (*pb.GetPoolStatsResponse, error) {
+	m := s.profiles
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	resp := &pb.GetPoolStatsResponse{}
+	for _, name := range m.namesLocked() {
+		conn, ok := m.conns[name]
+		if !ok {
+			continue
+		}
+		st := conn.DB.Stats()
+		resp.Pools = append(resp.Pools, &pb.PoolStats{
+			Profile:                name,
+			Driver:                 conn.Driver,
+			MaxOpenConns:           int32(st.MaxOpenConnections),
+			MaxIdleConns:           int32(conn.Pool.MaxIdleConns),
+			ConnMaxLifetimeSeconds: int64(conn.Pool.ConnMaxLifetime.Seconds()),
+			ConnMaxIdleTimeSeconds: int64(conn.Pool.ConnMaxIdleTime.Seconds()),
+			QueryTimeoutSeconds:    int64(conn.Pool.QueryTimeout.Seconds()),
+			OpenConnections:        int32(st.OpenConnections),
+			InUse:                  int32(st.InUse),
+			Idle:                   int32(st.Idle),
+			WaitCount:              st.WaitCount,
+			WaitDurationMs:         st.WaitDuration.Milliseconds(),
+			MaxIdleClosed:          st.MaxIdleClosed,
+			MaxIdleTimeClosed:      st.MaxIdleTimeClosed,
+			MaxLifetimeClosed:      st.MaxLifetimeClosed,
+		})
+	}
+	return resp, nil
+}
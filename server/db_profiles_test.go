@@ -0,0 +1,63 @@
+package server
+
+import (
+	"testing"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestKeepPassword(t *testing.T) {
+	saved := &pb.DatabaseProfile{
+		Name: "prod", Driver: "sqlserver", Host: "db.local", Port: 1433, User: "app", Password: "secret",
+		Params: map[string]string{"encrypt": "true"},
+	}
+	tests := []struct {
+		name   string
+		change func(p *pb.DatabaseProfile)
+		want   string
+	}{
+		{"unchanged", func(p *pb.DatabaseProfile) {}, "secret"},
+		{"other database", func(p *pb.DatabaseProfile) { p.Database = "test" }, "secret"},
+		{"new password", func(p *pb.DatabaseProfile) { p.Host = "other"; p.Password = "mine" }, "mine"},
+		{"other host", func(p *pb.DatabaseProfile) { p.Host = "attacker-host" }, ""},
+		{"other port", func(p *pb.DatabaseProfile) { p.Port = 14330 }, ""},
+		{"other user", func(p *pb.DatabaseProfile) { p.User = "sa" }, ""},
+		{"other driver", func(p *pb.DatabaseProfile) { p.Driver = "mysql" }, ""},
+		{"other params", func(p *pb.DatabaseProfile) { p.Params = map[string]string{"encrypt": "disable"} }, ""},
+		{"server param", func(p *pb.DatabaseProfile) { p.Params["server"] = "attacker-host" }, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := proto.Clone(saved).(*pb.DatabaseProfile)
+			p.Password = ""
+			tt.change(p)
+			err := keepPassword(p, saved)
+			if tt.want == "" {
+				if status.Code(err) != codes.InvalidArgument || p.Password != "" {
+					t.Fatalf("keepPassword() = %v with password %q, want InvalidArgument and no password", err, p.Password)
+				}
+				return
+			}
+			if err != nil || p.Password != tt.want {
+				t.Fatalf("keepPassword() = %v with password %q, want %q", err, p.Password, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateProfileIdentityParams(t *testing.T) {
+	for _, key := range []string{"server", "Server", "port", "user id", "password", "Data Source", "uid", "pwd", "failoverpartner"} {
+		p := &pb.DatabaseProfile{Name: "p", Driver: "sqlserver", Host: "db.local", Params: map[string]string{key: "x"}}
+		if err := validateProfile(p); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("validateProfile(params %q) = %v, want InvalidArgument", key, err)
+		}
+	}
+
+	p := &pb.DatabaseProfile{Name: "p", Driver: "sqlserver", Host: "db.local", Params: map[string]string{"encrypt": "disable"}}
+	if err := validateProfile(p); err != nil {
+		t.Errorf("validateProfile(encrypt) = %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrQueryKilled is the cancel cause of a statement stopped by KillQuery
+var ErrQueryKilled = errors.New("query killed")
+
+// runningQuery is a statement in progress. finish must be called when it is
+// done.
+type runningQuery struct {
+	s      *DatabaseService
+	conn   *DatabaseConnection
+	info   *pb.RunningQuery
+	start  time.Time
+	cancel context.CancelCauseFunc
+}
+
+// runningQueries tracks the statements in progress by ID
+type runningQueries struct {
+	mu      sync.Mutex
+	queries map[string]*runningQuery
+}
+
+// startQuery registers a statement and returns the context to run it with:
+// ctx bounded by timeout (0 = none) and cancelled by KillQuery
+func (s *DatabaseService) startQuery(ctx context.Context, conn *DatabaseConnection, source, savedQuery, query string, timeout time.Duration) (context.Context, *runningQuery) {
+	now := time.Now()
+	q := &runningQuery{
+		s:     s,
+		conn:  conn,
+		start: now,
+		info: &pb.RunningQuery{
+			Id:         newID("query"),
+			Profile:    conn.Profile,
+			Sql:        query,
+			Source:     source,
+			SavedQuery: savedQuery,
+			StartedAt:  now.Unix(),
+		},
+	}
+
+	ctx, q.cancel = context.WithCancelCause(ctx)
+	if timeout > 0 {
+		cause := fmt.Errorf("time limit of %v exceeded: %w", timeout, context.DeadlineExceeded)
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeoutCause(ctx, timeout, cause)
+		cancel := q.cancel
+		q.cancel = func(err error) {
+			cancel(err)
+			cancelTimeout()
+		}
+		q.info.Deadline = now.Add(timeout).Unix()
+	}
+
+	s.running.mu.Lock()
+	s.running.queries[q.info.Id] = q
+	s.running.mu.Unlock()
+	return ctx, q
+}
+
+// finish unregisters the statement and records it in the query history
+func (q *runningQuery) finish(rows int64, err error) {
+	q.s.running.mu.Lock()
+	delete(q.s.running.queries, q.info.Id)
+	q.s.running.mu.Unlock()
+	q.cancel(nil)
+
+	if q.s.history == nil {
+		return
+	}
+	entry := &pb.QueryHistoryEntry{
+		Id:         q.info.Id,
+		Profile:    q.info.Profile,
+		Sql:        q.info.Sql,
+		Source:     q.info.Source,
+		SavedQuery: q.info.SavedQuery,
+		StartedAt:  q.info.StartedAt,
+		DurationMs: time.Since(q.start).Milliseconds(),
+		Rows:       rows,
+	}
+	if err != nil {
+		entry.Error = status.Convert(err).Message()
+	}
+	q.s.history.Record(entry)
+}
+
+// ListRunningQueries returns the statements in progress, oldest first
+func (s *DatabaseService) ListRunningQueries(ctx context.Context, req *pb.ListRunningQueriesRequest) (*pb.ListRunningQueriesResponse, error) {
+	s.running.mu.Lock()
+	resp := &pb.ListRunningQueriesResponse{}
+	for _, q := range s.running.queries {
+		if req.Profile != "" && q.info.Profile != req.Profile {
+			continue
+		}
+		info := proto.Clone(q.info).(*pb.RunningQuery)
+		info.ElapsedMs = time.Since(q.start).Milliseconds()
+		resp.Queries = append(resp.Queries, info)
+	}
+	s.running.mu.Unlock()
+
+	slices.SortFunc(resp.Queries, func(a, b *pb.RunningQuery) int {
+		return cmp.Or(cmp.Compare(b.ElapsedMs, a.ElapsedMs), strings.Compare(a.Id, b.Id))
+	})
+	return resp, nil
+}
+
+// KillQuery cancels a statement in progress. The caller of the statement
+// gets ABORTED.
+func (s *DatabaseService) KillQuery(ctx context.Context, req *pb.KillQueryRequest) (*pb.KillQueryResponse, error) {
+	s.running.mu.Lock()
+	q, ok := s.running.queries[req.Id]
+	s.running.mu.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "running query not found: %s", req.Id)
+	}
+
+	q.cancel(ErrQueryKilled)
+	log.Printf("Query %s killed (%s, %s)", req.Id, q.info.Profile, q.info.Source)
+	return &pb.KillQueryResponse{}, nil
+}
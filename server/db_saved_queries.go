@@ -0,0 +1,468 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// savedQueriesFilename is the file name offered by ExportSavedQueries
+const savedQueriesFilename = "saved_queries.json"
+
+// savedQueryParamTypes are the parameter types of saved queries ("" = string)
+var savedQueryParamTypes = []string{"", "string", "int", "double", "bool", "time"}
+
+// SavedQueries keeps named, parameterised queries
+type SavedQueries struct {
+	// path is the saved query file ("" = not persisted)
+	path string
+
+	mu      sync.Mutex
+	queries map[string]*pb.SavedQuery
+}
+
+// NewSavedQueries loads the queries stored at path. An empty path keeps
+// queries in memory only.
+func NewSavedQueries(path string) (*SavedQueries, error) {
+	q := &SavedQueries{
+		path:    path,
+		queries: make(map[string]*pb.SavedQuery),
+	}
+	if path == "" {
+		return q, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return q, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read saved queries: %w", err)
+	}
+	stored := &pb.ListSavedQueriesResponse{}
+	if err := protojson.Unmarshal(data, stored); err != nil {
+		return nil, fmt.Errorf("failed to decode saved queries: %w", err)
+	}
+	for _, sq := range stored.Queries {
+		if err := validateSavedQuery(sq); err != nil {
+			log.Printf("Warning: Skipping saved query %q: %v", sq.Name, err)
+			continue
+		}
+		q.queries[sq.Name] = sq
+	}
+	return q, nil
+}
+
+// saveLocked writes the queries to the saved query file
+func (q *SavedQueries) saveLocked() error {
+	if q.path == "" {
+		return nil
+	}
+
+	data, err := marshalSavedQueries(q.sortedLocked())
+	if err != nil {
+		return err
+	}
+	tmpPath := q.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write saved queries: %w", err)
+	}
+	if err := os.Rename(tmpPath, q.path); err != nil {
+		return fmt.Errorf("failed to replace saved queries: %w", err)
+	}
+	return nil
+}
+
+// sortedLocked returns the queries sorted by name
+func (q *SavedQueries) sortedLocked() []*pb.SavedQuery {
+	queries := make([]*pb.SavedQuery, 0, len(q.queries))
+	for _, sq := range q.queries {
+		queries = append(queries, sq)
+	}
+	slices.SortFunc(queries, func(a, b *pb.SavedQuery) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return queries
+}
+
+// marshalSavedQueries encodes queries in the saved query file format, which
+// is also the format shared with ExportSavedQueries
+func marshalSavedQueries(queries []*pb.SavedQuery) ([]byte, error) {
+	data, err := protojson.MarshalOptions{Multiline: true}.Marshal(&pb.ListSavedQueriesResponse{Queries: queries})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode saved queries: %w", err)
+	}
+	return data, nil
+}
+
+// Get returns a copy of a saved query
+func (q *SavedQueries) Get(name string) (*pb.SavedQuery, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	sq, ok := q.queries[name]
+	if !ok {
+		return nil, false
+	}
+	return proto.Clone(sq).(*pb.SavedQuery), true
+}
+
+// validateSavedQuery checks a query and its parameter definitions
+func validateSavedQuery(sq *pb.SavedQuery) error {
+	if strings.TrimSpace(sq.GetName()) == "" {
+		return status.Error(codes.InvalidArgument, "query.name is required")
+	}
+	if strings.TrimSpace(sq.Sql) == "" {
+		return status.Error(codes.InvalidArgument, "query.sql is required")
+	}
+	seen := make(map[string]bool, len(sq.Params))
+	for i, p := range sq.Params {
+		if p.Name == "" {
+			return status.Errorf(codes.InvalidArgument, "parameter %d has no name", i+1)
+		}
+		if seen[p.Name] {
+			return status.Errorf(codes.InvalidArgument, "duplicate parameter: %s", p.Name)
+		}
+		seen[p.Name] = true
+		if !slices.Contains(savedQueryParamTypes, p.Type) {
+			return status.Errorf(codes.InvalidArgument, "parameter %s: unknown type %q (want string, int, double, bool or time)", p.Name, p.Type)
+		}
+		if p.DefaultValue != nil {
+			if _, err := parseParamValue(p.Type, *p.DefaultValue); err != nil {
+				return status.Errorf(codes.InvalidArgument, "parameter %s: invalid default value: %v", p.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// parseParamValue converts the text form of a parameter value to a driver
+// argument of the parameter type. Times without a zone are local times.
+func parseParamValue(typ, s string) (any, error) {
+	switch typ {
+	case "", "string":
+		return s, nil
+	case "int":
+		return strconv.ParseInt(s, 10, 64)
+	case "double":
+		return strconv.ParseFloat(s, 64)
+	case "bool":
+		return strconv.ParseBool(s)
+	case "time":
+		for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02"} {
+			if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+				return t, nil
+			}
+		}
+		return time.Parse(time.RFC3339, s)
+	default:
+		return nil, fmt.Errorf("unknown parameter type: %q", typ)
+	}
+}
+
+// savedQueryArgs builds the positional arguments of a saved query from the
+// named values of a request, filling in defaults
+func savedQueryArgs(sq *pb.SavedQuery, values map[string]*pb.SqlValue) ([]any, error) {
+	for name := range values {
+		if !slices.ContainsFunc(sq.Params, func(p *pb.SavedQueryParam) bool { return p.Name == name }) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown parameter: %s", name)
+		}
+	}
+
+	args := make([]any, len(sq.Params))
+	for i, p := range sq.Params {
+		if v, ok := values[p.Name]; ok {
+			arg, err := sqlArgs([]*pb.SqlValue{v})
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "parameter %s: %v", p.Name, status.Convert(err).Message())
+			}
+			args[i] = arg[0]
+			continue
+		}
+		if p.DefaultValue == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "parameter %s is required", p.Name)
+		}
+		arg, err := parseParamValue(p.Type, *p.DefaultValue)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "parameter %s: invalid default value: %v", p.Name, err)
+		}
+		args[i] = arg
+	}
+	return args, nil
+}
+
+// matchSavedQuery reports whether a query has the tag and contains the
+// lower-case search text in its name, description, SQL or tags
+func matchSavedQuery(sq *pb.SavedQuery, search, tag string) bool {
+	if tag != "" && !slices.Contains(sq.Tags, tag) {
+		return false
+	}
+	if search == "" {
+		return true
+	}
+	for _, s := range append([]string{sq.Name, sq.Description, sq.Sql}, sq.Tags...) {
+		if strings.Contains(strings.ToLower(s), search) {
+			return true
+		}
+	}
+	return false
+}
+
+// savedQueriesEnabled returns the saved query store, or Unavailable
+func (s *DatabaseService) savedQueriesEnabled() (*SavedQueries, error) {
+	if s.queries == nil {
+		return nil, status.Error(codes.Unavailable, "saved queries are not enabled")
+	}
+	return s.queries, nil
+}
+
+// ListSavedQueries returns the saved queries matching the search, by name
+func (s *DatabaseService) ListSavedQueries(ctx context.Context, req *pb.ListSavedQueriesRequest) (*pb.ListSavedQueriesResponse, error) {
+	q, err := s.savedQueriesEnabled()
+	if err != nil {
+		return nil, err
+	}
+	search := strings.ToLower(req.Search)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	resp := &pb.ListSavedQueriesResponse{}
+	for _, sq := range q.sortedLocked() {
+		if matchSavedQuery(sq, search, req.Tag) {
+			resp.Queries = append(resp.Queries, proto.Clone(sq).(*pb.SavedQuery))
+		}
+	}
+	return resp, nil
+}
+
+// SaveQuery adds a query or replaces the one with the same name
+func (s *DatabaseService) SaveQuery(ctx context.Context, req *pb.SaveQueryRequest) (*pb.SavedQuery, error) {
+	q, err := s.savedQueriesEnabled()
+	if err != nil {
+		return nil, err
+	}
+	if req.Query == nil {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	sq := proto.Clone(req.Query).(*pb.SavedQuery)
+	sq.Name = strings.TrimSpace(sq.GetName())
+	if err := validateSavedQuery(sq); err != nil {
+		return nil, err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now().Unix()
+	old, exists := q.queries[sq.Name]
+	sq.CreatedAt = now
+	if exists {
+		sq.CreatedAt = old.CreatedAt
+	}
+	sq.UpdatedAt = now
+
+	q.queries[sq.Name] = sq
+	if err := q.saveLocked(); err != nil {
+		if exists {
+			q.queries[sq.Name] = old
+		} else {
+			delete(q.queries, sq.Name)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	log.Printf("Saved query saved: %s", sq.Name)
+
+	return proto.Clone(sq).(*pb.SavedQuery), nil
+}
+
+// DeleteSavedQuery removes a saved query
+func (s *DatabaseService) DeleteSavedQuery(ctx context.Context, req *pb.DeleteSavedQueryRequest) (*pb.DeleteSavedQueryResponse, error) {
+	q, err := s.savedQueriesEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	old, ok := q.queries[req.Name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "saved query not found: %s", req.Name)
+	}
+	delete(q.queries, req.Name)
+	if err := q.saveLocked(); err != nil {
+		q.queries[req.Name] = old
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	log.Printf("Saved query deleted: %s", req.Name)
+
+	return &pb.DeleteSavedQueryResponse{}, nil
+}
+
+// RunSavedQuery runs a saved query with named parameter values. Reads
+// return rows; other statements return the affected row count.
+func (s *DatabaseService) RunSavedQuery(ctx context.Context, req *pb.RunSavedQueryRequest) (*pb.RunSavedQueryResponse, error) {
+	q, err := s.savedQueriesEnabled()
+	if err != nil {
+		return nil, err
+	}
+	sq, ok := q.Get(req.Name)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "saved query not found: %s", req.Name)
+	}
+	args, err := savedQueryArgs(sq, req.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := req.Profile
+	if profile == "" {
+		profile = sq.Profile
+	}
+	conn, err := s.profiles.Conn(ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := conn.StatementTimeout(time.Duration(req.TimeoutSeconds) * time.Second)
+	ctx, running := s.startQuery(ctx, conn, "saved", sq.Name, sq.Sql, timeout)
+	resp := &pb.RunSavedQueryResponse{}
+	if ClassifySQL(conn.Driver, sq.Sql).Kind == StatementRead {
+		resp.Result, err = queryRows(ctx, conn, sq.Sql, args, int(req.MaxRows), req.Typed)
+		running.finish(int64(resp.Result.GetCount()), err)
+	} else {
+		resp.Execute, err = execStatement(ctx, conn, sq.Sql, args)
+		running.finish(resp.Execute.GetAffectedRows(), err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ExportSavedQueries returns saved queries as a JSON file that
+// ImportSavedQueries reads
+func (s *DatabaseService) ExportSavedQueries(ctx context.Context, req *pb.ExportSavedQueriesRequest) (*pb.ExportSavedQueriesResponse, error) {
+	q, err := s.savedQueriesEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	q.mu.Lock()
+	var queries []*pb.SavedQuery
+	for _, sq := range q.sortedLocked() {
+		if len(req.Names) == 0 || slices.Contains(req.Names, sq.Name) {
+			queries = append(queries, sq)
+		}
+	}
+	for _, name := range req.Names {
+		if _, ok := q.queries[name]; !ok {
+			q.mu.Unlock()
+			return nil, status.Errorf(codes.NotFound, "saved query not found: %s", name)
+		}
+	}
+	data, err := marshalSavedQueries(queries)
+	q.mu.Unlock()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.ExportSavedQueriesResponse{
+		Data:     data,
+		Filename: savedQueriesFilename,
+		Count:    int32(len(queries)),
+	}, nil
+}
+
+// ImportSavedQueries adds the queries of an exported file. Queries with the
+// name of an existing query are skipped unless overwrite is set; nothing is
+// imported if any query is invalid.
+func (s *DatabaseService) ImportSavedQueries(ctx context.Context, req *pb.ImportSavedQueriesRequest) (*pb.ImportSavedQueriesResponse, error) {
+	q, err := s.savedQueriesEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	file := &pb.ListSavedQueriesResponse{}
+	if err := protojson.Unmarshal(req.Data, file); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid saved query file: %v", err)
+	}
+	for _, sq := range file.Queries {
+		sq.Name = strings.TrimSpace(sq.Name)
+		if err := validateSavedQuery(sq); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "query %q: %s", sq.Name, status.Convert(err).Message())
+		}
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	old := make(map[string]*pb.SavedQuery, len(q.queries))
+	for name, sq := range q.queries {
+		old[name] = sq
+	}
+
+	resp := &pb.ImportSavedQueriesResponse{}
+	now := time.Now().Unix()
+	for _, sq := range file.Queries {
+		if existing, ok := q.queries[sq.Name]; ok {
+			if !req.Overwrite {
+				resp.Skipped = append(resp.Skipped, sq.Name)
+				continue
+			}
+			sq.CreatedAt = existing.CreatedAt
+		} else if sq.CreatedAt == 0 {
+			sq.CreatedAt = now
+		}
+		sq.UpdatedAt = now
+		q.queries[sq.Name] = sq
+		resp.Imported = append(resp.Imported, sq.Name)
+	}
+	if len(resp.Imported) == 0 {
+		return resp, nil
+	}
+	if err := q.saveLocked(); err != nil {
+		q.queries = old
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	log.Printf("Saved queries imported: %d (skipped %d)", len(resp.Imported), len(resp.Skipped))
+
+	return resp, nil
+}
+
+// ListQueryHistory returns the statements run on a profile ("" = all),
+// newest first
+func (s *DatabaseService) ListQueryHistory(ctx context.Context, req *pb.ListQueryHistoryRequest) (*pb.ListQueryHistoryResponse, error) {
+	if s.history == nil {
+		return nil, status.Error(codes.Unavailable, "query history is not enabled")
+	}
+	if req.StartedFrom != 0 && req.StartedTo != 0 && req.StartedFrom > req.StartedTo {
+		return nil, status.Error(codes.InvalidArgument, "started_from must not be after started_to")
+	}
+	return &pb.ListQueryHistoryResponse{Entries: s.history.Query(req)}, nil
+}
+
+// ClearQueryHistory removes the history of a profile ("" = all)
+func (s *DatabaseService) ClearQueryHistory(ctx context.Context, req *pb.ClearQueryHistoryRequest) (*pb.ClearQueryHistoryResponse, error) {
+	if s.history == nil {
+		return nil, status.Error(codes.Unavailable, "query history is not enabled")
+	}
+	removed, err := s.history.Clear(req.Profile)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.ClearQueryHistoryResponse{Removed: int32(removed)}, nil
+}
@@ -0,0 +1,361 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrTableNotFound is returned by DescribeTable for an unknown table or view
+var ErrTableNotFound = errors.New("table not found")
+
+// TableInfo summarises a table or view
+type TableInfo struct {
+	Schema string
+	Name   string
+	IsView bool
+	// RowEstimate comes from the statistics (-1 = unknown)
+	RowEstimate int64
+}
+
+// ColumnInfo describes a column of a table or view
+type ColumnInfo struct {
+	Name    string
+	Ordinal int32
+	// DataType is the bare type name, e.g. "varchar"
+	DataType string
+	// ColumnType includes length or precision, e.g. "varchar(50)"
+	ColumnType string
+	Nullable   bool
+	// Default is the default expression (nil = none)
+	Default   *string
+	Collation string
+	// MaxLength is the length of string and binary types (-1 = max)
+	MaxLength     int64
+	Precision     int32
+	Scale         int32
+	AutoIncrement bool
+}
+
+// IndexInfo describes an index and its key columns
+type IndexInfo struct {
+	Name    string
+	Columns []string
+	Unique  bool
+	Primary bool
+}
+
+// ForeignKeyInfo describes a foreign key constraint
+type ForeignKeyInfo struct {
+	Name              string
+	Columns           []string
+	ReferencedSchema  string
+	ReferencedTable   string
+	ReferencedColumns []string
+	// OnDelete and OnUpdate are "NO ACTION", "CASCADE", "SET NULL",
+	// "SET DEFAULT" or "RESTRICT"
+	OnDelete string
+	OnUpdate string
+}
+
+// TableSchema is the full definition of a table or view
+type TableSchema struct {
+	Table          TableInfo
+	Columns        []ColumnInfo
+	PrimaryKey     []string
+	Indexes        []IndexInfo
+	ForeignKeys    []ForeignKeyInfo
+	ViewDefinition string
+}
+
+// schemaQueries are the introspection queries of a driver. tables takes the
+// schema; the others take the schema and the table, in that order.
+type schemaQueries struct {
+	currentSchema string
+	tables        string
+	columns       string
+	indexes       string
+	foreignKeys   string
+	view          string
+}
+
+var sqlServerSchemaQueries = schemaQueries{
+	currentSchema: "SELECT SCHEMA_NAME()",
+	tables: `SELECT s.name, o.name, CAST(CASE WHEN o.type = 'V' THEN 1 ELSE 0 END AS bit),
+	COALESCE((SELECT SUM(p.rows) FROM sys.partitions p WHERE p.object_id = o.object_id AND p.index_id IN (0, 1)), -1)
+FROM sys.objects o
+JOIN sys.schemas s ON s.schema_id = o.schema_id
+WHERE o.type IN ('U', 'V') AND o.is_ms_shipped = 0 AND (@p1 = '' OR s.name = @p1)
+ORDER BY s.name, o.name`,
+	columns: `SELECT c.COLUMN_NAME, c.ORDINAL_POSITION, c.DATA_TYPE, NULL, c.IS_NULLABLE, c.COLUMN_DEFAULT, c.COLLATION_NAME,
+	c.CHARACTER_MAXIMUM_LENGTH, c.NUMERIC_PRECISION, c.NUMERIC_SCALE,
+	CAST(COALESCE(COLUMNPROPERTY(OBJECT_ID(QUOTENAME(c.TABLE_SCHEMA) + '.' + QUOTENAME(c.TABLE_NAME)), c.COLUMN_NAME, 'IsIdentity'), 0) AS bit)
+FROM INFORMATION_SCHEMA.COLUMNS c
+WHERE c.TABLE_SCHEMA = @p1 AND c.TABLE_NAME = @p2
+ORDER BY c.ORDINAL_POSITION`,
+	indexes: `SELECT i.name, i.is_unique, i.is_primary_key, c.name
+FROM sys.indexes i
+JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+WHERE i.object_id = OBJECT_ID(QUOTENAME(@p1) + '.' + QUOTENAME(@p2)) AND i.name IS NOT NULL AND ic.is_included_column = 0
+ORDER BY i.is_primary_key DESC, i.name, ic.key_ordinal`,
+	foreignKeys: `SELECT fk.name, pc.name, rs.name, rt.name, rc.name,
+	REPLACE(fk.delete_referential_action_desc, '_', ' '), REPLACE(fk.update_referential_action_desc, '_', ' ')
+FROM sys.foreign_keys fk
+JOIN sys.foreign_key_columns fkc ON fkc.constraint_object_id = fk.object_id
+JOIN sys.columns pc ON pc.object_id = fkc.parent_object_id AND pc.column_id = fkc.parent_column_id
+JOIN sys.objects rt ON rt.object_id = fkc.referenced_object_id
+JOIN sys.schemas rs ON rs.schema_id = rt.schema_id
+JOIN sys.columns rc ON rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id
+WHERE fk.parent_object_id = OBJECT_ID(QUOTENAME(@p1) + '.' + QUOTENAME(@p2))
+ORDER BY fk.name, fkc.constraint_column_id`,
+	view: "SELECT OBJECT_DEFINITION(OBJECT_ID(QUOTENAME(@p1) + '.' + QUOTENAME(@p2)))",
+}
+
+var mysqlSchemaQueries = schemaQueries{
+	currentSchema: "SELECT DATABASE()",
+	tables: `SELECT TABLE_SCHEMA, TABLE_NAME, TABLE_TYPE = 'VIEW', COALESCE(TABLE_ROWS, -1)
+FROM information_schema.TABLES
+WHERE TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE())
+ORDER BY TABLE_NAME`,
+	columns: `SELECT COLUMN_NAME, ORDINAL_POSITION, DATA_TYPE, COLUMN_TYPE, IS_NULLABLE, COLUMN_DEFAULT, COLLATION_NAME,
+	CHARACTER_MAXIMUM_LENGTH, NUMERIC_PRECISION, NUMERIC_SCALE, EXTRA LIKE '%auto_increment%'
+FROM information_schema.COLUMNS
+WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?
+ORDER BY ORDINAL_POSITION`,
+	indexes: `SELECT INDEX_NAME, NON_UNIQUE = 0, INDEX_NAME = 'PRIMARY', COLUMN_NAME
+FROM information_schema.STATISTICS
+WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?
+ORDER BY INDEX_NAME = 'PRIMARY' DESC, INDEX_NAME, SEQ_IN_INDEX`,
+	foreignKeys: `SELECT k.CONSTRAINT_NAME, k.COLUMN_NAME, k.REFERENCED_TABLE_SCHEMA, k.REFERENCED_TABLE_NAME, k.REFERENCED_COLUMN_NAME,
+	r.DELETE_RULE, r.UPDATE_RULE
+FROM information_schema.KEY_COLUMN_USAGE k
+JOIN information_schema.REFERENTIAL_CONSTRAINTS r
+	ON r.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA AND r.CONSTRAINT_NAME = k.CONSTRAINT_NAME AND r.TABLE_NAME = k.TABLE_NAME
+WHERE k.TABLE_SCHEMA = ? AND k.TABLE_NAME = ? AND k.REFERENCED_TABLE_NAME IS NOT NULL
+ORDER BY k.CONSTRAINT_NAME, k.ORDINAL_POSITION`,
+	view: "SELECT VIEW_DEFINITION FROM information_schema.VIEWS WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?",
+}
+
+func (dc *DatabaseConnection) schemaQueries() (*schemaQueries, error) {
+	switch dc.Driver {
+	case "sqlserver":
+		return &sqlServerSchemaQueries, nil
+	case "mysql":
+		return &mysqlSchemaQueries, nil
+	default:
+		return nil, fmt.Errorf("unsupported driver: %s", dc.Driver)
+	}
+}
+
+// ListTableInfo returns the tables and views of a schema. An empty schema
+// means every user schema on SQL Server and the current database on MySQL.
+func (dc *DatabaseConnection) ListTableInfo(ctx context.Context, schema string) ([]TableInfo, error) {
+	q, err := dc.schemaQueries()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := dc.Query(ctx, q.tables, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []TableInfo
+	for rows.Next() {
+		var t TableInfo
+		if err := rows.Scan(&t.Schema, &t.Name, &t.IsView, &t.RowEstimate); err != nil {
+			return nil, err
+		}
+		tables = append(tables, t)
+	}
+	return tables, rows.Err()
+}
+
+// DescribeTable returns the columns, keys and indexes of a table or view. An
+// empty schema means the default schema of the connection.
+func (dc *DatabaseConnection) DescribeTable(ctx context.Context, schema, table string) (*TableSchema, error) {
+	q, err := dc.schemaQueries()
+	if err != nil {
+		return nil, err
+	}
+
+	if schema == "" {
+		var current sql.NullString
+		if err := dc.QueryRow(ctx, q.currentSchema).Scan(&current); err != nil {
+			return nil, err
+		}
+		if !current.Valid {
+			return nil, errors.New("no default schema selected")
+		}
+		schema = current.String
+	}
+
+	info, err := dc.findTable(ctx, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	ts := &TableSchema{Table: *info}
+
+	if ts.Columns, err = dc.describeColumns(ctx, q, info); err != nil {
+		return nil, fmt.Errorf("failed to read columns: %w", err)
+	}
+	if ts.Indexes, err = dc.describeIndexes(ctx, q, info); err != nil {
+		return nil, fmt.Errorf("failed to read indexes: %w", err)
+	}
+	for _, idx := range ts.Indexes {
+		if idx.Primary {
+			ts.PrimaryKey = idx.Columns
+		}
+	}
+	if ts.ForeignKeys, err = dc.describeForeignKeys(ctx, q, info); err != nil {
+		return nil, fmt.Errorf("failed to read foreign keys: %w", err)
+	}
+
+	if info.IsView {
+		var def sql.NullString
+		if err := dc.QueryRow(ctx, q.view, info.Schema, info.Name).Scan(&def); err != nil {
+			return nil, fmt.Errorf("failed to read view definition: %w", err)
+		}
+		ts.ViewDefinition = def.String
+	}
+	return ts, nil
+}
+
+// findTable looks up a table by name, preferring an exact match over a case
+// insensitive one
+func (dc *DatabaseConnection) findTable(ctx context.Context, schema, table string) (*TableInfo, error) {
+	tables, err := dc.ListTableInfo(ctx, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	var folded *TableInfo
+	for i := range tables {
+		if tables[i].Schema != schema && !strings.EqualFold(tables[i].Schema, schema) {
+			continue
+		}
+		if tables[i].Name == table {
+			return &tables[i], nil
+		}
+		if folded == nil && strings.EqualFold(tables[i].Name, table) {
+			folded = &tables[i]
+		}
+	}
+	if folded == nil {
+		return nil, fmt.Errorf("%w: %s.%s", ErrTableNotFound, schema, table)
+	}
+	return folded, nil
+}
+
+func (dc *DatabaseConnection) describeColumns(ctx context.Context, q *schemaQueries, t *TableInfo) ([]ColumnInfo, error) {
+	rows, err := dc.Query(ctx, q.columns, t.Schema, t.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []ColumnInfo
+	for rows.Next() {
+		var c ColumnInfo
+		var columnType, nullable, def, collation sql.NullString
+		var maxLength, precision, scale sql.NullInt64
+		if err := rows.Scan(&c.Name, &c.Ordinal, &c.DataType, &columnType, &nullable, &def, &collation,
+			&maxLength, &precision, &scale, &c.AutoIncrement); err != nil {
+			return nil, err
+		}
+
+		c.Nullable = nullable.String == "YES"
+		if def.Valid {
+			c.Default = &def.String
+		}
+		c.Collation = collation.String
+		c.MaxLength = maxLength.Int64
+		c.Precision = int32(precision.Int64)
+		c.Scale = int32(scale.Int64)
+		c.ColumnType = columnType.String
+		if c.ColumnType == "" {
+			c.ColumnType = sqlServerColumnType(c)
+		}
+		columns = append(columns, c)
+	}
+	return columns, rows.Err()
+}
+
+// sqlServerColumnType formats a column type the way SSMS shows it, since
+// INFORMATION_SCHEMA on SQL Server has no COLUMN_TYPE
+func sqlServerColumnType(c ColumnInfo) string {
+	switch c.DataType {
+	case "char", "varchar", "nchar", "nvarchar", "binary", "varbinary":
+		if c.MaxLength == -1 {
+			return c.DataType + "(max)"
+		}
+		return fmt.Sprintf("%s(%d)", c.DataType, c.MaxLength)
+	case "decimal", "numeric":
+		return fmt.Sprintf("%s(%d,%d)", c.DataType, c.Precision, c.Scale)
+	default:
+		return c.DataType
+	}
+}
+
+func (dc *DatabaseConnection) describeIndexes(ctx context.Context, q *schemaQueries, t *TableInfo) ([]IndexInfo, error) {
+	rows, err := dc.Query(ctx, q.indexes, t.Schema, t.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Rows are ordered by index, then by key position
+	var indexes []IndexInfo
+	for rows.Next() {
+		var name, column sql.NullString
+		var unique, primary bool
+		if err := rows.Scan(&name, &unique, &primary, &column); err != nil {
+			return nil, err
+		}
+
+		if n := len(indexes); n == 0 || indexes[n-1].Name != name.String {
+			indexes = append(indexes, IndexInfo{Name: name.String, Unique: unique, Primary: primary})
+		}
+		// Functional index parts (MySQL 8) have no column name
+		if column.Valid {
+			idx := &indexes[len(indexes)-1]
+			idx.Columns = append(idx.Columns, column.String)
+		}
+	}
+	return indexes, rows.Err()
+}
+
+func (dc *DatabaseConnection) describeForeignKeys(ctx context.Context, q *schemaQueries, t *TableInfo) ([]ForeignKeyInfo, error) {
+	rows, err := dc.Query(ctx, q.foreignKeys, t.Schema, t.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Rows are ordered by constraint, then by column position
+	var keys []ForeignKeyInfo
+	for rows.Next() {
+		var name, column, refSchema, refTable, refColumn, onDelete, onUpdate string
+		if err := rows.Scan(&name, &column, &refSchema, &refTable, &refColumn, &onDelete, &onUpdate); err != nil {
+			return nil, err
+		}
+
+		if n := len(keys); n == 0 || keys[n-1].Name != name {
+			keys = append(keys, ForeignKeyInfo{
+				Name:             name,
+				ReferencedSchema: refSchema,
+				ReferencedTable:  refTable,
+				OnDelete:         onDelete,
+				OnUpdate:         onUpdate,
+			})
+		}
+		fk := &keys[len(keys)-1]
+		fk.Columns = append(fk.Columns, column)
+		fk.ReferencedColumns = append(fk.ReferencedColumns, refColumn)
+	}
+	return keys, rows.Err()
+}
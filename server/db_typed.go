@@ -0,0 +1,267 @@
+package server
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	mssql "github.com/microsoft/go-mssqldb"
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// cellKinds maps database type names, as reported by the sqlserver and
+// mysql drivers, to the kind of cell their values are encoded as
+var cellKinds = map[string]pb.CellKind{
+	"TINYINT": pb.CellKind_CELL_KIND_INT, "SMALLINT": pb.CellKind_CELL_KIND_INT,
+	"MEDIUMINT": pb.CellKind_CELL_KIND_INT, "INT": pb.CellKind_CELL_KIND_INT,
+	"BIGINT": pb.CellKind_CELL_KIND_INT, "YEAR": pb.CellKind_CELL_KIND_INT,
+	"UNSIGNED TINYINT": pb.CellKind_CELL_KIND_INT, "UNSIGNED SMALLINT": pb.CellKind_CELL_KIND_INT,
+	"UNSIGNED MEDIUMINT": pb.CellKind_CELL_KIND_INT, "UNSIGNED INT": pb.CellKind_CELL_KIND_INT,
+	"UNSIGNED BIGINT": pb.CellKind_CELL_KIND_INT,
+
+	"DECIMAL": pb.CellKind_CELL_KIND_DECIMAL, "NUMERIC": pb.CellKind_CELL_KIND_DECIMAL,
+	"MONEY": pb.CellKind_CELL_KIND_DECIMAL, "SMALLMONEY": pb.CellKind_CELL_KIND_DECIMAL,
+
+	"FLOAT": pb.CellKind_CELL_KIND_FLOAT, "REAL": pb.CellKind_CELL_KIND_FLOAT,
+	"DOUBLE": pb.CellKind_CELL_KIND_FLOAT,
+
+	"CHAR": pb.CellKind_CELL_KIND_STRING, "VARCHAR": pb.CellKind_CELL_KIND_STRING,
+	"NCHAR": pb.CellKind_CELL_KIND_STRING, "NVARCHAR": pb.CellKind_CELL_KIND_STRING,
+	"TEXT": pb.CellKind_CELL_KIND_STRING, "NTEXT": pb.CellKind_CELL_KIND_STRING,
+	"TINYTEXT": pb.CellKind_CELL_KIND_STRING, "MEDIUMTEXT": pb.CellKind_CELL_KIND_STRING,
+	"LONGTEXT": pb.CellKind_CELL_KIND_STRING, "XML": pb.CellKind_CELL_KIND_STRING,
+	"JSON": pb.CellKind_CELL_KIND_STRING, "ENUM": pb.CellKind_CELL_KIND_STRING,
+	"SET": pb.CellKind_CELL_KIND_STRING, "UNIQUEIDENTIFIER": pb.CellKind_CELL_KIND_STRING,
+
+	"BINARY": pb.CellKind_CELL_KIND_BYTES, "VARBINARY": pb.CellKind_CELL_KIND_BYTES,
+	"IMAGE": pb.CellKind_CELL_KIND_BYTES, "BLOB": pb.CellKind_CELL_KIND_BYTES,
+	"TINYBLOB": pb.CellKind_CELL_KIND_BYTES, "MEDIUMBLOB": pb.CellKind_CELL_KIND_BYTES,
+	"LONGBLOB": pb.CellKind_CELL_KIND_BYTES, "GEOMETRY": pb.CellKind_CELL_KIND_BYTES,
+
+	"DATETIME": pb.CellKind_CELL_KIND_TIMESTAMP, "DATETIME2": pb.CellKind_CELL_KIND_TIMESTAMP,
+	"SMALLDATETIME": pb.CellKind_CELL_KIND_TIMESTAMP, "DATETIMEOFFSET": pb.CellKind_CELL_KIND_TIMESTAMP,
+	"TIMESTAMP": pb.CellKind_CELL_KIND_TIMESTAMP,
+
+	"DATE": pb.CellKind_CELL_KIND_DATE,
+	"TIME": pb.CellKind_CELL_KIND_TIME,
+}
+
+// typedColumn is a result column with what is needed to encode its values
+type typedColumn struct {
+	kind pb.CellKind
+	// dbType is the upper-case database type name
+	dbType string
+	// zoned is set for types whose values carry a UTC offset
+	zoned bool
+}
+
+// columnMeta describes the result columns for a typed response. BIT is a
+// boolean on SQL Server but a bit string, encoded as bytes, on MySQL.
+func columnMeta(driver string, types []*sql.ColumnType) ([]*pb.ColumnMeta, []typedColumn) {
+	meta := make([]*pb.ColumnMeta, len(types))
+	columns := make([]typedColumn, len(types))
+	for i, t := range types {
+		dbType := strings.ToUpper(t.DatabaseTypeName())
+		kind, ok := cellKinds[dbType]
+		if dbType == "BIT" {
+			kind, ok = pb.CellKind_CELL_KIND_BYTES, true
+			if driver == "sqlserver" {
+				kind = pb.CellKind_CELL_KIND_BOOL
+			}
+		}
+		if !ok {
+			kind = pb.CellKind_CELL_KIND_UNSPECIFIED
+		}
+		columns[i] = typedColumn{kind: kind, dbType: dbType, zoned: dbType == "DATETIMEOFFSET"}
+
+		m := &pb.ColumnMeta{Name: t.Name(), DatabaseType: dbType, Kind: kind}
+		if nullable, ok := t.Nullable(); ok {
+			m.Nullable = &nullable
+		}
+		if length, ok := t.Length(); ok {
+			m.Length = &length
+		}
+		if precision, scale, ok := t.DecimalSize(); ok {
+			m.Precision, m.Scale = &precision, &scale
+		}
+		meta[i] = m
+	}
+	return meta, columns
+}
+
+// typedCells encodes a row of driver values
+func typedCells(columns []typedColumn, values []any) []*pb.Cell {
+	cells := make([]*pb.Cell, len(values))
+	for i, v := range values {
+		cells[i] = typedCell(columns[i], v)
+	}
+	return cells
+}
+
+// typedCell encodes a driver value as a cell of the column kind. Values the
+// driver returns as text (MySQL without parameters, decimals) are parsed;
+// values that do not fit the kind are encoded by their Go type.
+func typedCell(col typedColumn, v any) *pb.Cell {
+	if v == nil {
+		return &pb.Cell{Value: &pb.Cell_NullValue{NullValue: true}}
+	}
+	text, isText := v.(string)
+	if b, ok := v.([]byte); ok {
+		text, isText = string(b), true
+	}
+
+	switch col.kind {
+	case pb.CellKind_CELL_KIND_INT:
+		switch v := v.(type) {
+		case int64:
+			return intCell(v)
+		case uint64:
+			if v <= math.MaxInt64 {
+				return intCell(int64(v))
+			}
+			return decimalCell(strconv.FormatUint(v, 10))
+		}
+		if isText {
+			if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+				return intCell(n)
+			}
+			// UNSIGNED BIGINT beyond int64
+			if _, err := strconv.ParseUint(text, 10, 64); err == nil {
+				return decimalCell(text)
+			}
+		}
+
+	case pb.CellKind_CELL_KIND_DECIMAL:
+		switch v := v.(type) {
+		case int64:
+			return decimalCell(strconv.FormatInt(v, 10))
+		case float64:
+			return decimalCell(strconv.FormatFloat(v, 'f', -1, 64))
+		}
+		if isText {
+			return decimalCell(text)
+		}
+
+	case pb.CellKind_CELL_KIND_FLOAT:
+		switch v := v.(type) {
+		case float64:
+			return floatCell(v)
+		case float32:
+			return floatCell(float64(v))
+		}
+		if isText {
+			if f, err := strconv.ParseFloat(text, 64); err == nil {
+				return floatCell(f)
+			}
+		}
+
+	case pb.CellKind_CELL_KIND_BOOL:
+		switch v := v.(type) {
+		case bool:
+			return &pb.Cell{Value: &pb.Cell_BoolValue{BoolValue: v}}
+		case int64:
+			return &pb.Cell{Value: &pb.Cell_BoolValue{BoolValue: v != 0}}
+		}
+
+	case pb.CellKind_CELL_KIND_STRING:
+		if b, ok := v.([]byte); ok && col.dbType == "UNIQUEIDENTIFIER" {
+			var u mssql.UniqueIdentifier
+			if err := u.Scan(b); err == nil {
+				return stringCell(u.String())
+			}
+		}
+		if isText && utf8.ValidString(text) {
+			return stringCell(text)
+		}
+
+	case pb.CellKind_CELL_KIND_BYTES:
+		switch v := v.(type) {
+		case []byte:
+			return &pb.Cell{Value: &pb.Cell_BytesValue{BytesValue: v}}
+		case string:
+			return &pb.Cell{Value: &pb.Cell_BytesValue{BytesValue: []byte(v)}}
+		}
+
+	case pb.CellKind_CELL_KIND_TIMESTAMP, pb.CellKind_CELL_KIND_DATE:
+		if t, ok := v.(time.Time); ok {
+			return timeCell(t, col.zoned)
+		}
+		if isText {
+			for _, layout := range []string{"2006-01-02 15:04:05.999999999", "2006-01-02", time.RFC3339Nano} {
+				if t, err := time.Parse(layout, text); err == nil {
+					return timeCell(t, col.zoned)
+				}
+			}
+			// MySQL zero dates ("0000-00-00") have no time value
+			return stringCell(text)
+		}
+
+	case pb.CellKind_CELL_KIND_TIME:
+		if t, ok := v.(time.Time); ok {
+			return stringCell(t.Format("15:04:05.9999999"))
+		}
+		if isText {
+			return stringCell(text)
+		}
+	}
+
+	return genericCell(v)
+}
+
+// genericCell encodes a value by its Go type
+func genericCell(v any) *pb.Cell {
+	switch v := v.(type) {
+	case int64:
+		return intCell(v)
+	case float64:
+		return floatCell(v)
+	case bool:
+		return &pb.Cell{Value: &pb.Cell_BoolValue{BoolValue: v}}
+	case time.Time:
+		return timeCell(v, false)
+	case string:
+		return stringCell(v)
+	case []byte:
+		if utf8.Valid(v) {
+			return stringCell(string(v))
+		}
+		return &pb.Cell{Value: &pb.Cell_BytesValue{BytesValue: v}}
+	default:
+		return stringCell(fmt.Sprint(v))
+	}
+}
+
+func intCell(n int64) *pb.Cell {
+	return &pb.Cell{Value: &pb.Cell_IntValue{IntValue: n}}
+}
+
+func decimalCell(s string) *pb.Cell {
+	return &pb.Cell{Value: &pb.Cell_DecimalValue{DecimalValue: s}}
+}
+
+func floatCell(f float64) *pb.Cell {
+	return &pb.Cell{Value: &pb.Cell_FloatValue{FloatValue: f}}
+}
+
+func stringCell(s string) *pb.Cell {
+	return &pb.Cell{Value: &pb.Cell_StringValue{StringValue: s}}
+}
+
+// timeCell encodes a time. Values without a zone keep their wall clock,
+// expressed as UTC.
+func timeCell(t time.Time, zoned bool) *pb.Cell {
+	ts := &pb.ZonedTimestamp{HasZone: zoned}
+	if zoned {
+		_, offset := t.Zone()
+		ts.UtcOffsetSeconds = int32(offset)
+	} else {
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	}
+	ts.Time = timestamppb.New(t)
+	return &pb.Cell{Value: &pb.Cell_TimestampValue{TimestampValue: ts}}
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	mssql "github.com/microsoft/go-mssqldb"
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestTypedCell(t *testing.T) {
+	col := func(dbType string) typedColumn {
+		return typedColumn{kind: cellKinds[dbType], dbType: dbType, zoned: dbType == "DATETIMEOFFSET"}
+	}
+	bit := typedColumn{kind: pb.CellKind_CELL_KIND_BOOL, dbType: "BIT"}
+	jst := time.FixedZone("JST", 9*60*60)
+
+	var guid mssql.UniqueIdentifier
+	if err := guid.Scan("6F9619FF-8B86-D011-B42D-00C04FC964FF"); err != nil {
+		t.Fatal(err)
+	}
+	// The driver returns the wire bytes, which are mixed-endian
+	guidBytes, _ := guid.Value()
+
+	null := &pb.Cell{Value: &pb.Cell_NullValue{NullValue: true}}
+	timestamp := func(t time.Time, zoned bool, offset int32) *pb.Cell {
+		return &pb.Cell{Value: &pb.Cell_TimestampValue{TimestampValue: &pb.ZonedTimestamp{
+			Time: timestamppb.New(t), HasZone: zoned, UtcOffsetSeconds: offset,
+		}}}
+	}
+
+	tests := []struct {
+		name  string
+		col   typedColumn
+		value any
+		want  *pb.Cell
+	}{
+		{"null int", col("INT"), nil, null},
+		{"null string", col("NVARCHAR"), nil, null},
+		{"null timestamp", col("DATETIME2"), nil, null},
+		{"int64 max", col("BIGINT"), int64(math.MaxInt64), intCell(math.MaxInt64)},
+		{"int64 min", col("BIGINT"), int64(math.MinInt64), intCell(math.MinInt64)},
+		{"uint64 in range", col("UNSIGNED BIGINT"), uint64(math.MaxInt64), intCell(math.MaxInt64)},
+		{"uint64 beyond int64", col("UNSIGNED BIGINT"), uint64(math.MaxUint64), decimalCell("18446744073709551615")},
+		{"int text", col("INT"), []byte("-42"), intCell(-42)},
+		{"int text beyond int64", col("UNSIGNED BIGINT"), []byte("18446744073709551615"), decimalCell("18446744073709551615")},
+		{"decimal bytes", col("DECIMAL"), []byte("12345678901234567890.123456789"), decimalCell("12345678901234567890.123456789")},
+		{"negative decimal bytes", col("NUMERIC"), []byte("-0.0100"), decimalCell("-0.0100")},
+		{"money bytes", col("MONEY"), []byte("1234.5678"), decimalCell("1234.5678")},
+		{"decimal int64", col("DECIMAL"), int64(7), decimalCell("7")},
+		{"float", col("FLOAT"), 1.5, floatCell(1.5)},
+		{"real", col("REAL"), float32(0.25), floatCell(0.25)},
+		{"float text", col("DOUBLE"), []byte("1e3"), floatCell(1000)},
+		{"bit", bit, true, &pb.Cell{Value: &pb.Cell_BoolValue{BoolValue: true}}},
+		{"bit int64", bit, int64(0), &pb.Cell{Value: &pb.Cell_BoolValue{BoolValue: false}}},
+		{"nvarchar", col("NVARCHAR"), "名前", stringCell("名前")},
+		{"uniqueidentifier", col("UNIQUEIDENTIFIER"), guidBytes, stringCell("6F9619FF-8B86-D011-B42D-00C04FC964FF")},
+		{"varbinary", col("VARBINARY"), []byte{0x00, 0xff}, &pb.Cell{Value: &pb.Cell_BytesValue{BytesValue: []byte{0x00, 0xff}}}},
+		{"datetime without zone", col("DATETIME2"), time.Date(2024, 1, 2, 3, 4, 5, 123456700, jst),
+			timestamp(time.Date(2024, 1, 2, 3, 4, 5, 123456700, time.UTC), false, 0)},
+		{"datetimeoffset", col("DATETIMEOFFSET"), time.Date(2024, 1, 2, 3, 4, 5, 0, jst),
+			timestamp(time.Date(2024, 1, 1, 18, 4, 5, 0, time.UTC), true, 9*60*60)},
+		{"datetime text", col("DATETIME"), []byte("2024-01-02 03:04:05.5"),
+			timestamp(time.Date(2024, 1, 2, 3, 4, 5, 500000000, time.UTC), false, 0)},
+		{"date", col("DATE"), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			timestamp(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), false, 0)},
+		{"mysql zero date", col("DATE"), []byte("0000-00-00"), stringCell("0000-00-00")},
+		{"time", col("TIME"), time.Date(1, 1, 1, 13, 14, 15, 100000000, time.UTC), stringCell("13:14:15.1")},
+		{"time text", col("TIME"), []byte("13:14:15"), stringCell("13:14:15")},
+		{"unknown type", typedColumn{dbType: "SQL_VARIANT"}, int64(3), intCell(3)},
+		{"int that is not a number", col("INT"), []byte("abc"), stringCell("abc")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := typedCell(tt.col, tt.value); !proto.Equal(got, tt.want) {
+				t.Errorf("typedCell(%s, %#v) = %v, want %v", tt.col.dbType, tt.value, got, tt.want)
+			}
+		})
+	}
+}
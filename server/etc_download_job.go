@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/yhonda-ohishi-pub-dev/desktop-server/progressclient"
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+)
+
+const (
+	// EtcDownloadJob is the job type that downloads ETC statements
+	EtcDownloadJob = "etc_download"
+
+	// etcScraperExe is launched from the directory of the executable
+	etcScraperExe = "etc_meisai_scraper.exe"
+)
+
+// EtcDownloadJobType runs etc_meisai_scraper.exe for each job. The scraper
+// reports its own progress through PublishProgress (see
+// progressclient.DialFromEnv); job params are passed as --key=value flags.
+// Downloads share the "etc_scraper" group so that only one browser session
+// runs at a time by default. Failed downloads are retried up to three times
+// since the ETC site is often temporarily unavailable.
+func EtcDownloadJobType(grpcAddr string) JobType {
+	return JobType{
+		Name:  EtcDownloadJob,
+		Group: "etc_scraper",
+		Retry: RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: 30 * time.Second,
+			MaxBackoff:     5 * time.Minute,
+			Jitter:         0.2,
+			RetryOn:        []ErrorClass{ErrorClassTimeout, ErrorClassNetwork, ErrorClassProcess},
+		},
+		Run: func(ctx context.Context, run *JobRun) error {
+			exePath, err := os.Executable()
+			if err != nil {
+				return fmt.Errorf("failed to get executable path: %w", err)
+			}
+			exeDir := filepath.Dir(exePath)
+			scraperPath := filepath.Join(exeDir, etcScraperExe)
+			if _, err := os.Stat(scraperPath); err != nil {
+				return Permanent(fmt.Errorf("%s not found: %w", etcScraperExe, err))
+			}
+
+			publisherId := "job:" + run.ID
+			revoke := run.progress.GrantJob(run.ID, publisherId)
+			defer revoke()
+
+			keys := make([]string, 0, len(run.Params))
+			for k := range run.Params {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			args := make([]string, 0, len(keys))
+			for _, k := range keys {
+				args = append(args, fmt.Sprintf("--%s=%s", k, run.Params[k]))
+			}
+
+			cmd := exec.CommandContext(ctx, scraperPath, args...)
+			cmd.Dir = exeDir
+			cmd.Env = append(os.Environ(),
+				progressclient.EnvAddr+"="+grpcAddr,
+				progressclient.EnvJobID+"="+run.ID,
+				progressclient.EnvPublisherID+"="+publisherId,
+			)
+			cmd.Stdout = log.Writer()
+			cmd.Stderr = log.Writer()
+
+			log.Printf("Launching %s for job %s", etcScraperExe, run.ID)
+			if err := cmd.Run(); err != nil {
+				return fmt.Errorf("%s failed: %w", etcScraperExe, err)
+			}
+			if run.progress.jobState(run.ID) == pb.JobState_JOB_STATE_ERROR {
+				return WithErrorClass(fmt.Errorf("%s reported an error", etcScraperExe), ErrorClassProcess)
+			}
+			return nil
+		},
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"time"
 
 	"github.com/yhonda-ohishi/db_service/src/registry"
 	dtakoeventsregistry "github.com/yhonda-ohishi/dtako_events/pkg/registry"
 	dtakorowsregistry "github.com/yhonda-ohishi/dtako_rows/v3/pkg/registry"
 	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -17,8 +19,18 @@ type GRPCServer struct {
 	grpcServer *grpc.Server
 }
 
-func NewGRPCServer(progressService *ProgressService) *GRPCServer {
-	grpcSrv := grpc.NewServer()
+func NewGRPCServer(progressService *ProgressService, jobQueue *JobQueueService, scheduler *SchedulerService, webhooks *WebhookService, database *DatabaseService) *GRPCServer {
+	grpcSrv := grpc.NewServer(
+		// Ping idle connections so that dead streaming clients are detected
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    30 * time.Second,
+			Timeout: 10 * time.Second,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             10 * time.Second,
+			PermitWithoutStream: true,
+		}),
+	)
 
 	// Register db_service (excluding DTakoEventsService and DTakoRowsService)
 	dbRegistry := registry.Register(grpcSrv, registry.WithExcludeServices("DTakoEventsService", "DTakoRowsService"))
@@ -40,6 +52,22 @@ func NewGRPCServer(progressService *ProgressService) *GRPCServer {
 	// Register ProgressService for gRPC streaming
 	pb.RegisterProgressServiceServer(grpcSrv, progressService)
 
+	// Register JobQueueService for queued jobs
+	pb.RegisterJobQueueServiceServer(grpcSrv, jobQueue)
+
+	// Register SchedulerService for recurring jobs (nil if schedules failed to load)
+	if scheduler != nil {
+		pb.RegisterSchedulerServiceServer(grpcSrv, scheduler)
+	}
+
+	// Register WebhookService for job notifications (nil if the config is invalid)
+	if webhooks != nil {
+		pb.RegisterWebhookServiceServer(grpcSrv, webhooks)
+	}
+
+	// Register DatabaseService for ad-hoc SQL
+	pb.RegisterDatabaseServiceServer(grpcSrv, database)
+
 	// Register reflection service for grpcurl and other tools
 	reflection.Register(grpcSrv)
 
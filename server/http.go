@@ -3,25 +3,47 @@ package server
 import (
 	"context"
 	"fmt"
+	"log"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/yhonda-ohishi-pub-dev/desktop-server/frontend"
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
 
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
 	"github.com/rs/cors"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
+const (
+	// sseHeartbeatInterval is how often a comment line is sent on idle SSE streams
+	sseHeartbeatInterval = 15 * time.Second
+	// sseWriteTimeout bounds a single write to an SSE client
+	sseWriteTimeout = 10 * time.Second
+	// websocketPingInterval is the ping interval of gRPC-Web websockets
+	websocketPingInterval = 20 * time.Second
+)
+
+// allowedOrigins are the browser origins of the development frontends; the
+// embedded frontend is served by the server itself
+var allowedOrigins = []string{"http://localhost:5173", "http://localhost:8080"}
+
 type HTTPServer struct {
 	grpcServer      *GRPCServer
 	httpServer      *http.Server
 	progressService *ProgressService
+	database        *DatabaseService
 }
 
-func NewHTTPServer(grpcServer *GRPCServer, progressService *ProgressService) *HTTPServer {
+func NewHTTPServer(grpcServer *GRPCServer, progressService *ProgressService, database *DatabaseService) *HTTPServer {
 	return &HTTPServer{
 		grpcServer:      grpcServer,
 		progressService: progressService,
+		database:        database,
 	}
 }
 
@@ -35,6 +57,8 @@ func (s *HTTPServer) Start(addr string) error {
 		grpcweb.WithWebsocketOriginFunc(func(req *http.Request) bool {
 			return true
 		}),
+		// Keep idle websocket streams alive through proxies and detect dead peers
+		grpcweb.WithWebsocketPingInterval(websocketPingInterval),
 	)
 
 	mux := http.NewServeMux()
@@ -42,6 +66,15 @@ func (s *HTTPServer) Start(addr string) error {
 	// gRPC-Web endpoint (includes ProgressService streaming)
 	mux.Handle("/api/", http.StripPrefix("/api", wrappedGrpc))
 
+	// Server-Sent Events for clients without gRPC-Web
+	mux.HandleFunc("GET /events/progress", s.handleProgressEvents)
+
+	// Query result downloads (CSV, JSON Lines, XLSX)
+	mux.HandleFunc("POST /export/query", crossSiteGuard(s.handleExportQuery))
+
+	// CSV uploads into tables
+	mux.HandleFunc("POST /import/csv", crossSiteGuard(s.handleImportCSV))
+
 	// Serve embedded frontend files
 	distFS, err := frontend.GetDistFS()
 	if err != nil {
@@ -71,10 +104,10 @@ func (s *HTTPServer) Start(addr string) error {
 
 	// Add CORS middleware
 	corsHandler := cors.New(cors.Options{
-		AllowedOrigins:   []string{"http://localhost:5173", "http://localhost:8080"},
+		AllowedOrigins:   allowedOrigins,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"*"},
-		ExposedHeaders:   []string{"Grpc-Status", "Grpc-Message", "Grpc-Encoding", "Grpc-Accept-Encoding"},
+		ExposedHeaders:   []string{"Grpc-Status", "Grpc-Message", "Grpc-Encoding", "Grpc-Accept-Encoding", "Content-Disposition", "X-Job-Id"},
 		AllowCredentials: true,
 	}).Handler(mux)
 
@@ -91,6 +124,137 @@ func (s *HTTPServer) Start(addr string) error {
 	return s.httpServer.ListenAndServe()
 }
 
+// crossSiteGuard protects endpoints that run SQL from requests a page on
+// another site can make without a CORS preflight (form posts, links and
+// images). A browser Origin must be the server itself or an allowed origin,
+// and the X-Requested-With header, which forces a preflight, is required.
+// Clients outside a browser send no Origin and only need the header.
+func crossSiteGuard(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if origin := r.Header.Get("Origin"); origin != "" && !slices.Contains(allowedOrigins, origin) &&
+			origin != "http://"+r.Host && origin != "https://"+r.Host {
+			http.Error(w, "origin not allowed", http.StatusForbidden)
+			return
+		}
+		if r.Header.Get("X-Requested-With") == "" {
+			http.Error(w, "X-Requested-With header is required", http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// handleProgressEvents streams progress updates as Server-Sent Events.
+// Query parameters: job_id, job_ids and job_types (comma separated),
+// mode=persistent, since_sequence, since_timestamp. A Last-Event-ID header
+// (sent by EventSource on reconnect) resumes after that sequence.
+func (s *HTTPServer) handleProgressEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &pb.StreamProgressRequest{
+		JobId:    query.Get("job_id"),
+		JobIds:   splitList(query.Get("job_ids")),
+		JobTypes: splitList(query.Get("job_types")),
+	}
+	switch query.Get("mode") {
+	case "", "auto_close":
+	case "persistent":
+		req.Mode = pb.SubscriptionMode_SUBSCRIPTION_MODE_PERSISTENT
+	default:
+		http.Error(w, "invalid mode", http.StatusBadRequest)
+		return
+	}
+	var err error
+	if v := query.Get("since_sequence"); v != "" {
+		if req.SinceSequence, err = strconv.ParseInt(v, 10, 64); err != nil {
+			http.Error(w, "invalid since_sequence", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := query.Get("since_timestamp"); v != "" {
+		if req.SinceTimestamp, err = strconv.ParseInt(v, 10, 64); err != nil {
+			http.Error(w, "invalid since_timestamp", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := r.Header.Get("Last-Event-ID"); v != "" {
+		if req.SinceSequence, err = strconv.ParseInt(v, 10, 64); err != nil {
+			http.Error(w, "invalid Last-Event-ID", http.StatusBadRequest)
+			return
+		}
+	}
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		log.Printf("SSE client does not support streaming: %v", err)
+		return
+	}
+
+	log.Printf("New SSE progress client connected (job_id filter: %s, job_ids: %v, job_types: %v, mode: %v, since_sequence: %d)",
+		req.JobId, req.JobIds, req.JobTypes, req.Mode, req.SinceSequence)
+
+	// The subscriber goroutine and the heartbeat below share the writer
+	var mu sync.Mutex
+	write := func(data string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		rc.SetWriteDeadline(time.Now().Add(sseWriteTimeout))
+		if _, err := fmt.Fprint(w, data); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+
+	marshal := protojson.MarshalOptions{UseProtoNames: true}
+	info := s.progressService.subscribe(r.Context(), req, func(update *pb.ProgressUpdate) error {
+		data, err := marshal.Marshal(update)
+		if err != nil {
+			return err
+		}
+		return write(fmt.Sprintf("id: %d\nevent: progress\ndata: %s\n\n", update.Sequence, data))
+	})
+	defer func() {
+		s.progressService.unsubscribe(info)
+		// No writes may happen once the handler has returned
+		<-info.stopped
+		log.Println("SSE progress client disconnected")
+	}()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-info.done:
+			if _, err := info.result(); err != nil {
+				write(fmt.Sprintf("event: error\ndata: %s\n\n", err))
+			}
+			return
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if err := write(": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// splitList splits a comma separated query parameter, skipping empty items
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func (s *HTTPServer) Stop() {
 	if s.httpServer != nil {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
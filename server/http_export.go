@@ -0,0 +1,194 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// DatabaseExportJob is the job type of query exports
+	DatabaseExportJob = "db_export"
+
+	// maxExportRequestSize bounds the JSON body of POST /export/query
+	maxExportRequestSize = 1 << 20
+)
+
+// handleExportQuery streams the result of a read-only query as a file
+// download (POST with an ExportQueryRequest as JSON; the route is wrapped in
+// crossSiteGuard). Progress is reported as a db_export job whose ID is
+// returned in the X-Job-Id header; cancelling the job stops the download.
+// Errors after the download has started are reported in the X-Export-Error
+// trailer.
+func (s *HTTPServer) handleExportQuery(w http.ResponseWriter, r *http.Request) {
+	req, err := parseExportRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Sql) == "" {
+		http.Error(w, "sql is required", http.StatusBadRequest)
+		return
+	}
+	conn, err := s.database.profiles.Conn(r.Context(), req.Profile)
+	if err != nil {
+		code := http.StatusServiceUnavailable
+		if status.Code(err) == codes.NotFound {
+			code = http.StatusNotFound
+		}
+		http.Error(w, status.Convert(err).Message(), code)
+		return
+	}
+	opts := ExportOptions{Format: ExportCSV, Encoding: ExportUTF8BOM, MaxRows: conn.RowLimit(req.MaxRows), SheetName: req.Filename}
+	if req.Format != "" {
+		if opts.Format, err = ParseExportFormat(req.Format); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if req.Encoding != "" {
+		if opts.Encoding, err = ParseExportEncoding(req.Encoding); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Only reads can be exported, whatever the connection policy allows
+	if c := ClassifySQL(conn.Driver, req.Sql); c.Kind != StatementRead || c.MultiStatement() {
+		http.Error(w, "only a single read-only statement can be exported", http.StatusForbidden)
+		return
+	}
+
+	args, err := sqlArgs(req.Params)
+	if err != nil {
+		http.Error(w, status.Convert(err).Message(), http.StatusBadRequest)
+		return
+	}
+
+	jobId := req.JobId
+	if jobId == "" {
+		jobId = newID(DatabaseExportJob)
+	}
+	ctx, release, err := s.progressService.RegisterJob(r.Context(), jobId)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	defer release()
+
+	details := map[string]string{"format": string(opts.Format)}
+	s.progressService.BroadcastProgress(&pb.ProgressUpdate{
+		Type:    pb.ProgressType_PROGRESS_TYPE_STARTED,
+		Message: "クエリを実行しています",
+		JobId:   jobId,
+		JobType: DatabaseExportJob,
+		Details: details,
+	})
+
+	// The time limit covers the whole download, not just the start of the query
+	timeout := conn.StatementTimeout(time.Duration(req.TimeoutSeconds) * time.Second)
+	queryCtx, q := s.database.startQuery(ctx, conn, "export", "", req.Sql, timeout)
+	rows, err := conn.Query(queryCtx, req.Sql, args...)
+	if err != nil {
+		q.finish(0, err)
+		s.finishExport(ctx, r, jobId, 0, err)
+		var denied *StatementDeniedError
+		if errors.As(err, &denied) {
+			http.Error(w, denied.Error(), http.StatusForbidden)
+		} else {
+			http.Error(w, fmt.Sprintf("query failed: %v", err), http.StatusBadRequest)
+		}
+		return
+	}
+	defer rows.Close()
+
+	filename := exportFilename(req.Filename) + "." + string(opts.Format)
+	w.Header().Set("Content-Type", opts.Format.ContentType())
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.Header().Set("X-Job-Id", jobId)
+	w.Header().Set("Trailer", "X-Export-Rows, X-Export-Error")
+	w.WriteHeader(http.StatusOK)
+
+	n, err := ExportRows(queryCtx, rows, w, opts, func(p ExportProgress) {
+		s.progressService.reportJob(ctx, &pb.ProgressUpdate{
+			Type:             pb.ProgressType_PROGRESS_TYPE_PROGRESS,
+			Message:          fmt.Sprintf("%d行を出力しました", p.Rows),
+			JobId:            jobId,
+			BytesTransferred: p.Bytes,
+			Details:          map[string]string{"format": string(opts.Format), "rows": strconv.FormatInt(p.Rows, 10)},
+		})
+	})
+
+	w.Header().Set("X-Export-Rows", strconv.FormatInt(n, 10))
+	if err != nil {
+		w.Header().Set("X-Export-Error", err.Error())
+	}
+	q.finish(n, err)
+	s.finishExport(ctx, r, jobId, n, err)
+}
+
+// finishExport reports the outcome of an export job. A job cancelled through
+// CancelJob has already been reported.
+func (s *HTTPServer) finishExport(ctx context.Context, r *http.Request, jobId string, rows int64, err error) {
+	update := &pb.ProgressUpdate{
+		Type:       pb.ProgressType_PROGRESS_TYPE_COMPLETE,
+		Message:    fmt.Sprintf("%d行をエクスポートしました", rows),
+		JobId:      jobId,
+		Percentage: 100,
+		Details:    map[string]string{"rows": strconv.FormatInt(rows, 10)},
+	}
+	switch {
+	case errors.Is(context.Cause(ctx), ErrJobCancelled):
+		log.Printf("Export %s cancelled after %d rows", jobId, rows)
+		return
+	case r.Context().Err() != nil:
+		update.Type = pb.ProgressType_PROGRESS_TYPE_ERROR
+		update.Message = fmt.Sprintf("ダウンロードが中断されました（%d行）", rows)
+		update.Percentage = 0
+	case err != nil:
+		update.Type = pb.ProgressType_PROGRESS_TYPE_ERROR
+		update.Message = fmt.Sprintf("エクスポートに失敗しました: %v", err)
+		update.Percentage = 0
+	}
+	log.Printf("Export %s finished: %s", jobId, update.Message)
+	s.progressService.reportJob(context.Background(), update)
+}
+
+// parseExportRequest reads the ExportQueryRequest JSON body
+func parseExportRequest(r *http.Request) (*pb.ExportQueryRequest, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxExportRequestSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request: %w", err)
+	}
+	req := &pb.ExportQueryRequest{}
+	if err := protojson.Unmarshal(body, req); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+	return req, nil
+}
+
+// exportFilename makes a download file name (without extension) safe
+func exportFilename(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < ' ' || strings.ContainsRune(`\/:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if name == "" {
+		return "export"
+	}
+	return name
+}
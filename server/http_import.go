@@ -0,0 +1,258 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// DatabaseImportJob is the job type of CSV imports
+	DatabaseImportJob = "db_import"
+
+	// maxImportSize bounds the upload of POST /import/csv
+	maxImportSize = 32 << 20
+)
+
+// handleImportCSV imports an uploaded CSV file into a table (multipart form
+// with the file in "file" and an ImportCsvRequest as JSON in "request", or
+// its fields as form values). The file is validated completely first; a file
+// with errors is not imported and the errors are returned with 422. Progress
+// is reported as a db_import job; cancelling the job rolls the import back.
+// The route is wrapped in crossSiteGuard.
+func (s *HTTPServer) handleImportCSV(w http.ResponseWriter, r *http.Request) {
+	req, data, err := parseImportRequest(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Table == "" {
+		http.Error(w, "table is required", http.StatusBadRequest)
+		return
+	}
+	conn, err := s.database.profiles.Conn(r.Context(), req.Profile)
+	if err != nil {
+		code := http.StatusServiceUnavailable
+		if status.Code(err) == codes.NotFound {
+			code = http.StatusNotFound
+		}
+		http.Error(w, status.Convert(err).Message(), code)
+		return
+	}
+
+	opts := ImportOptions{
+		Schema:      req.Schema,
+		Table:       req.Table,
+		BatchSize:   int(req.BatchSize),
+		MaxErrors:   int(req.MaxErrors),
+		EmptyAsNull: req.EmptyAsNull,
+	}
+	if opts.Encoding, err = ParseImportEncoding(req.Encoding); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, m := range req.Mappings {
+		opts.Mappings = append(opts.Mappings, ImportMapping{CSVColumn: m.CsvColumn, Column: m.Column})
+	}
+
+	jobId := req.JobId
+	if jobId == "" {
+		jobId = newID(DatabaseImportJob)
+	}
+	ctx, release, err := s.progressService.RegisterJob(r.Context(), jobId)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	defer release()
+
+	s.progressService.BroadcastProgress(&pb.ProgressUpdate{
+		Type:    pb.ProgressType_PROGRESS_TYPE_STARTED,
+		Message: "CSVを検証しています",
+		JobId:   jobId,
+		JobType: DatabaseImportJob,
+		Details: map[string]string{"table": req.Table, "dry_run": strconv.FormatBool(req.DryRun)},
+	})
+
+	describeCtx, cancel := conn.WithQueryTimeout(ctx)
+	imp, err := conn.PrepareImport(describeCtx, data, opts)
+	cancel()
+	if err != nil {
+		s.finishImport(ctx, jobId, "", err)
+		switch {
+		case errors.Is(err, ErrTableNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, ErrInvalidImport):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, fmt.Sprintf("failed to describe table: %v", err), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	resp := importResponse(jobId, req.DryRun, &imp.Result)
+	if imp.Result.ErrorRows > 0 {
+		s.finishImport(ctx, jobId, "", fmt.Errorf("%d行にエラーがあります", imp.Result.ErrorRows))
+		writeImportResponse(w, http.StatusUnprocessableEntity, resp)
+		return
+	}
+	if req.DryRun {
+		s.finishImport(ctx, jobId, fmt.Sprintf("%d行を検証しました（エラーなし）", imp.Result.Rows), nil)
+		writeImportResponse(w, http.StatusOK, resp)
+		return
+	}
+
+	// The import runs as long as the request; KillQuery and CancelJob roll it back
+	queryCtx, q := s.database.startQuery(ctx, conn, "import", "", imp.SQL, 0)
+	n, err := conn.InsertImport(queryCtx, imp, func(p ImportProgress) {
+		s.progressService.reportJob(ctx, &pb.ProgressUpdate{
+			Type:        pb.ProgressType_PROGRESS_TYPE_PROGRESS,
+			Message:     fmt.Sprintf("%d/%d行を取り込みました", p.Inserted, p.Total),
+			JobId:       jobId,
+			CurrentStep: int32(p.Batch),
+			TotalSteps:  int32(p.Batches),
+			Percentage:  int32(p.Inserted * 100 / p.Total),
+			Details:     map[string]string{"table": req.Table, "rows": strconv.FormatInt(p.Inserted, 10)},
+		})
+	})
+	q.finish(n, err)
+	if err != nil {
+		s.finishImport(ctx, jobId, "", err)
+		var denied *StatementDeniedError
+		switch {
+		case errors.As(err, &denied):
+			http.Error(w, denied.Error(), http.StatusForbidden)
+		case queryCtx.Err() != nil:
+			http.Error(w, fmt.Sprintf("import stopped: %v", context.Cause(queryCtx)), http.StatusConflict)
+		default:
+			http.Error(w, fmt.Sprintf("import failed: %v", err), http.StatusBadRequest)
+		}
+		return
+	}
+
+	resp.Inserted = n
+	s.finishImport(ctx, jobId, fmt.Sprintf("%d行を取り込みました", n), nil)
+	writeImportResponse(w, http.StatusOK, resp)
+}
+
+// finishImport reports the outcome of an import job. A job cancelled through
+// CancelJob has already been reported.
+func (s *HTTPServer) finishImport(ctx context.Context, jobId, message string, err error) {
+	update := &pb.ProgressUpdate{
+		Type:       pb.ProgressType_PROGRESS_TYPE_COMPLETE,
+		Message:    message,
+		JobId:      jobId,
+		Percentage: 100,
+	}
+	switch {
+	case errors.Is(context.Cause(ctx), ErrJobCancelled):
+		log.Printf("Import %s cancelled", jobId)
+		return
+	case err != nil:
+		update.Type = pb.ProgressType_PROGRESS_TYPE_ERROR
+		update.Message = fmt.Sprintf("取り込みに失敗しました: %v", err)
+		update.Percentage = 0
+	}
+	log.Printf("Import %s finished: %s", jobId, update.Message)
+	s.progressService.reportJob(context.Background(), update)
+}
+
+// parseImportRequest reads the ImportCsvRequest and the file of a multipart
+// upload
+func parseImportRequest(w http.ResponseWriter, r *http.Request) (*pb.ImportCsvRequest, []byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	if err := r.ParseMultipartForm(maxImportSize); err != nil {
+		return nil, nil, fmt.Errorf("invalid upload: %w", err)
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		return nil, nil, errors.New("file is required")
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	req := &pb.ImportCsvRequest{}
+	if v := r.FormValue("request"); v != "" {
+		if err := protojson.Unmarshal([]byte(v), req); err != nil {
+			return nil, nil, fmt.Errorf("invalid request: %w", err)
+		}
+		return req, data, nil
+	}
+
+	req.Profile = r.FormValue("profile")
+	req.Schema = r.FormValue("schema")
+	req.Table = r.FormValue("table")
+	req.Encoding = r.FormValue("encoding")
+	req.JobId = r.FormValue("job_id")
+	for name, dst := range map[string]*bool{"dry_run": &req.DryRun, "empty_as_null": &req.EmptyAsNull} {
+		if v := r.FormValue(name); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid %s", name)
+			}
+			*dst = b
+		}
+	}
+	for name, dst := range map[string]*int32{"batch_size": &req.BatchSize, "max_errors": &req.MaxErrors} {
+		if v := r.FormValue(name); v != "" {
+			n, err := strconv.ParseInt(v, 10, 32)
+			if err != nil || n < 0 {
+				return nil, nil, fmt.Errorf("invalid %s", name)
+			}
+			*dst = int32(n)
+		}
+	}
+	return req, data, nil
+}
+
+// importResponse converts the validation result of an import
+func importResponse(jobId string, dryRun bool, res *ImportResult) *pb.ImportCsvResponse {
+	resp := &pb.ImportCsvResponse{
+		JobId:              jobId,
+		DryRun:             dryRun,
+		Encoding:           string(res.Encoding),
+		CsvColumns:         res.CSVColumns,
+		UnmappedCsvColumns: res.Unmapped,
+		Rows:               res.Rows,
+		ErrorRows:          res.ErrorRows,
+		ErrorsTruncated:    res.ErrorsTruncated,
+	}
+	for _, m := range res.Mappings {
+		resp.Mappings = append(resp.Mappings, &pb.ImportColumnMapping{CsvColumn: m.CSVColumn, Column: m.Column})
+	}
+	for _, e := range res.Errors {
+		resp.Errors = append(resp.Errors, &pb.ImportError{
+			Line:      e.Line,
+			CsvColumn: e.CSVColumn,
+			Column:    e.Column,
+			Value:     e.Value,
+			Message:   e.Message,
+		})
+	}
+	return resp
+}
+
+// writeImportResponse writes an ImportCsvResponse as JSON
+func writeImportResponse(w http.ResponseWriter, code int, resp *pb.ImportCsvResponse) {
+	body, err := protojson.Marshal(resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body)
+}
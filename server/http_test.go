@@ -0,0 +1,41 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCrossSiteGuard(t *testing.T) {
+	tests := []struct {
+		name        string
+		origin      string
+		requestedBy string
+		want        int
+	}{
+		{"cross-site form post", "https://evil.example", "", http.StatusForbidden},
+		{"cross-site with header", "https://evil.example", "XMLHttpRequest", http.StatusForbidden},
+		{"same origin without header", "http://desktop.local:8080", "", http.StatusForbidden},
+		{"same origin", "http://desktop.local:8080", "XMLHttpRequest", http.StatusOK},
+		{"development origin", "http://localhost:5173", "XMLHttpRequest", http.StatusOK},
+		{"no origin without header", "", "", http.StatusForbidden},
+		{"no origin", "", "curl", http.StatusOK},
+	}
+	handler := crossSiteGuard(func(w http.ResponseWriter, r *http.Request) {})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "http://desktop.local:8080/import/csv", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if tt.requestedBy != "" {
+				r.Header.Set("X-Requested-With", tt.requestedBy)
+			}
+			w := httptest.NewRecorder()
+			handler(w, r)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrJobCancelled is the cause of a job context cancelled through CancelJob
+var ErrJobCancelled = errors.New("job cancelled")
+
+// registeredJob is a producer-owned job that can be cancelled
+type registeredJob struct {
+	cancel context.CancelCauseFunc
+}
+
+// RegisterJob registers a cancellable job. The returned context is cancelled
+// (with cause ErrJobCancelled) when CancelJob is called for jobId, or when
+// parent is done. The producer must call release once the job has finished.
+func (s *ProgressService) RegisterJob(parent context.Context, jobId string) (ctx context.Context, release func(), err error) {
+	if jobId == "" {
+		return nil, nil, fmt.Errorf("job id is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.cancels[jobId]; exists {
+		return nil, nil, fmt.Errorf("job already registered: %s", jobId)
+	}
+
+	ctx, cancel := context.WithCancelCause(parent)
+	reg := &registeredJob{cancel: cancel}
+	s.cancels[jobId] = reg
+
+	release = func() {
+		s.mu.Lock()
+		if s.cancels[jobId] == reg {
+			delete(s.cancels, jobId)
+		}
+		s.mu.Unlock()
+		cancel(nil)
+	}
+	return ctx, release, nil
+}
+
+// CancelJob cancels a registered job and notifies subscribers with a
+// PROGRESS_TYPE_CANCELLED update
+func (s *ProgressService) CancelJob(ctx context.Context, req *pb.CancelJobRequest) (*pb.Job, error) {
+	if req.JobId == "" {
+		return nil, status.Error(codes.InvalidArgument, "job_id is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, known := s.jobs.get(req.JobId)
+	if known && job.FinishedAt != 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "job %s has already finished (state: %v)", req.JobId, job.State)
+	}
+
+	reg, ok := s.cancels[req.JobId]
+	if !ok {
+		if !known {
+			return nil, status.Errorf(codes.NotFound, "job not found: %s", req.JobId)
+		}
+		return nil, status.Errorf(codes.FailedPrecondition, "job %s cannot be cancelled", req.JobId)
+	}
+
+	reg.cancel(ErrJobCancelled)
+	delete(s.cancels, req.JobId)
+	delete(s.owners, req.JobId)
+
+	message := "ジョブがキャンセルされました"
+	if req.Reason != "" {
+		message = fmt.Sprintf("%s: %s", message, req.Reason)
+	}
+	log.Printf("Cancelling job %s (reason: %s)", req.JobId, req.Reason)
+
+	s.broadcastLocked(&pb.ProgressUpdate{
+		Type:    pb.ProgressType_PROGRESS_TYPE_CANCELLED,
+		Message: message,
+		JobId:   req.JobId,
+		JobType: job.GetJobType(),
+	})
+
+	job, _ = s.jobs.get(req.JobId)
+	return job, nil
+}
+
+// reportJob broadcasts an update for a registered job unless its context is
+// done or, for updates other than QUEUED/STARTED, the job has already
+// finished, e.g. because it was cancelled or a granted publisher reported the
+// outcome itself
+func (s *ProgressService) reportJob(ctx context.Context, update *pb.ProgressUpdate) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ctx.Err() != nil {
+		return false
+	}
+	if job, ok := s.jobs.get(update.JobId); ok && job.FinishedAt != 0 && !isStart(update.Type) {
+		return false
+	}
+
+	s.broadcastLocked(proto.Clone(update).(*pb.ProgressUpdate))
+	return true
+}
+
+// jobState returns the current state of a job, or JOB_STATE_UNSPECIFIED if
+// it is unknown
+func (s *ProgressService) jobState(jobId string) pb.JobState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, _ := s.jobs.get(jobId)
+	return job.GetState()
+}
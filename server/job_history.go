@@ -0,0 +1,220 @@
+package server
+
+import (
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultHistoryMaxAge is how long finished jobs are kept in the history
+	DefaultHistoryMaxAge = 30 * 24 * time.Hour
+	// DefaultHistoryMaxBytes is the size at which the history file is compacted
+	DefaultHistoryMaxBytes = 5 * 1024 * 1024
+
+	// defaultHistoryLimit is the page size of ListJobHistory
+	defaultHistoryLimit = 100
+)
+
+// JobHistory is an append-only JSON Lines store of job lifecycle events.
+// Each line is a snapshot of a job at a state change; the latest line per
+// job wins when the file is loaded. Records are written by a single writer
+// goroutine so that Record never waits for the disk.
+type JobHistory struct {
+	maxAge time.Duration
+
+	mu      sync.Mutex
+	jobs    map[string]*pb.Job
+	pending []*pb.Job
+	closed  bool
+
+	// store is only used by the writer once the history is open
+	store jsonlStore[*pb.Job]
+	wake  chan struct{}
+	done  chan struct{}
+	err   error
+}
+
+// OpenJobHistory loads the history at path, applying the retention limits,
+// and opens it for appending
+func OpenJobHistory(path string, maxAge time.Duration, maxBytes int64) (*JobHistory, error) {
+	h := &JobHistory{
+		maxAge: maxAge,
+		store:  jsonlStore[*pb.Job]{name: "job history", path: path, maxBytes: maxBytes},
+		jobs:   make(map[string]*pb.Job),
+		wake:   make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+
+	err := h.store.load(func() *pb.Job { return &pb.Job{} }, func(job *pb.Job) {
+		h.jobs[job.JobId] = job
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := h.compact(time.Now()); err != nil {
+		return nil, err
+	}
+	go h.write()
+	return h, nil
+}
+
+// compact rewrites the file with only the latest record per job, dropping
+// jobs older than maxAge and then the oldest jobs that do not fit the size
+// limit. The file is written without holding h.mu.
+func (h *JobHistory) compact(now time.Time) error {
+	var jobs []*pb.Job
+	h.mu.Lock()
+	for id, job := range h.jobs {
+		if h.maxAge > 0 && jobTime(job) < now.Add(-h.maxAge).Unix() {
+			delete(h.jobs, id)
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	h.mu.Unlock()
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobTime(jobs[i]) > jobTime(jobs[j])
+	})
+
+	kept, err := h.store.rewrite(jobs)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, job := range jobs[kept:] {
+		// A job recorded again during the rewrite is still pending
+		if h.jobs[job.JobId] == job {
+			delete(h.jobs, job.JobId)
+		}
+	}
+	return nil
+}
+
+// Record keeps a job snapshot and queues it for the writer
+func (h *JobHistory) Record(job *pb.Job) {
+	job = proto.Clone(job).(*pb.Job)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+	h.jobs[job.JobId] = job
+	h.pending = append(h.pending, job)
+	select {
+	case h.wake <- struct{}{}:
+	default:
+	}
+}
+
+// write appends queued records until the history is closed, then closes the
+// file
+func (h *JobHistory) write() {
+	defer close(h.done)
+
+	for range h.wake {
+		h.mu.Lock()
+		pending, closed := h.pending, h.closed
+		h.pending = nil
+		h.mu.Unlock()
+
+		compact := false
+		for _, job := range pending {
+			if h.store.append(job) {
+				compact = true
+			}
+		}
+		if compact {
+			if err := h.compact(time.Now()); err != nil {
+				log.Printf("Warning: Failed to compact job history: %v", err)
+			}
+		}
+		if closed {
+			h.err = h.store.close()
+			return
+		}
+	}
+}
+
+// Jobs returns the latest snapshot of every job in the history
+func (h *JobHistory) Jobs() []*pb.Job {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	jobs := make([]*pb.Job, 0, len(h.jobs))
+	for _, job := range h.jobs {
+		jobs = append(jobs, proto.Clone(job).(*pb.Job))
+	}
+	return jobs
+}
+
+// Query returns jobs matching the request, newest first
+func (h *JobHistory) Query(req *pb.ListJobHistoryRequest) []*pb.Job {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+
+	h.mu.Lock()
+	var jobs []*pb.Job
+	for _, job := range h.jobs {
+		if req.StartedFrom != 0 && job.StartedAt < req.StartedFrom {
+			continue
+		}
+		if req.StartedTo != 0 && job.StartedAt > req.StartedTo {
+			continue
+		}
+		if !matchJob(job, req.States, req.JobType) {
+			continue
+		}
+		jobs = append(jobs, proto.Clone(job).(*pb.Job))
+	}
+	h.mu.Unlock()
+
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].StartedAt != jobs[j].StartedAt {
+			return jobs[i].StartedAt > jobs[j].StartedAt
+		}
+		return jobs[i].JobId < jobs[j].JobId
+	})
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	return jobs
+}
+
+// Close writes the queued records and closes the history file; later
+// records are dropped
+func (h *JobHistory) Close() error {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		<-h.done
+		return nil
+	}
+	h.closed = true
+	h.mu.Unlock()
+
+	select {
+	case h.wake <- struct{}{}:
+	default:
+	}
+	<-h.done
+	return h.err
+}
+
+// jobTime is the time used for retention: when the job last changed
+func jobTime(job *pb.Job) int64 {
+	if job.UpdatedAt != 0 {
+		return job.UpdatedAt
+	}
+	return job.StartedAt
+}
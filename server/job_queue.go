@@ -0,0 +1,532 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultGroupLimit is how many jobs of a concurrency group run at once
+// unless SetGroupLimit says otherwise
+const defaultGroupLimit = 1
+
+// JobFunc runs a queued job. ctx is cancelled when the job is cancelled
+// through CancelJob. Returning nil completes the job and returning an error
+// fails it, unless the job already reported its outcome. Failed jobs are
+// retried according to the job type's RetryPolicy and end up in the dead
+// letters once it gives up.
+type JobFunc func(ctx context.Context, run *JobRun) error
+
+// JobType is a kind of job that can be submitted to the queue
+type JobType struct {
+	Name string
+	// Group is the default concurrency group; empty means Name
+	Group string
+	Run   JobFunc
+	Retry RetryPolicy
+}
+
+// JobRun is a job handed to a JobFunc. For job types with a retry policy,
+// ID is the ID of the current attempt, a sub-job of the queued job.
+type JobRun struct {
+	ID     string
+	Type   string
+	Params map[string]string
+
+	progress *ProgressService
+}
+
+// Report broadcasts a progress update for the job
+func (r *JobRun) Report(update *pb.ProgressUpdate) {
+	update.JobId = r.ID
+	update.JobType = r.Type
+	r.progress.BroadcastProgress(update)
+}
+
+// Progress reports step progress of the job
+func (r *JobRun) Progress(message string, currentStep, totalSteps int32) {
+	var percentage int32
+	if totalSteps > 0 {
+		percentage = currentStep * 100 / totalSteps
+	}
+	r.Report(&pb.ProgressUpdate{
+		Type:        pb.ProgressType_PROGRESS_TYPE_PROGRESS,
+		Message:     message,
+		CurrentStep: currentStep,
+		TotalSteps:  totalSteps,
+		Percentage:  percentage,
+	})
+}
+
+// queuedJob is a job waiting in or running from the queue
+type queuedJob struct {
+	id          string
+	jobType     JobType
+	group       string
+	priority    int32
+	params      map[string]string
+	submittedAt time.Time
+	// position is the last queue position reported to subscribers
+	position int32
+	// attempts counts every attempt, including earlier runs before RetryJob
+	attempts  int32
+	lastErr   string
+	lastClass ErrorClass
+
+	ctx     context.Context
+	release func()
+}
+
+// JobQueueService runs submitted jobs in priority order while limiting how
+// many jobs of each concurrency group run at the same time. Queue positions
+// and the job lifecycle are reported through ProgressService.
+type JobQueueService struct {
+	pb.UnimplementedJobQueueServiceServer
+
+	progress *ProgressService
+
+	mu      sync.Mutex
+	types   map[string]JobType
+	limits  map[string]int
+	waiting map[string][]*queuedJob
+	running map[string][]*queuedJob
+
+	deadLetters map[string]*queuedJob
+	deadOrder   []string
+}
+
+// NewJobQueueService creates a job queue reporting through progress
+func NewJobQueueService(progress *ProgressService) *JobQueueService {
+	return &JobQueueService{
+		progress: progress,
+		types:    make(map[string]JobType),
+		limits:   make(map[string]int),
+		waiting:  make(map[string][]*queuedJob),
+		running:  make(map[string][]*queuedJob),
+
+		deadLetters: make(map[string]*queuedJob),
+	}
+}
+
+// RegisterJobType makes a job type available to SubmitJob
+func (q *JobQueueService) RegisterJobType(t JobType) {
+	if t.Group == "" {
+		t.Group = t.Name
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.types[t.Name] = t
+}
+
+// hasJobType reports whether a job type has been registered
+func (q *JobQueueService) hasJobType(name string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	_, ok := q.types[name]
+	return ok
+}
+
+// SetGroupLimit sets how many jobs of a concurrency group may run at once
+func (q *JobQueueService) SetGroupLimit(group string, n int) {
+	if n < 1 {
+		n = 1
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.limits[group] = n
+	q.dispatchLocked(group)
+}
+
+// SubmitJob queues a job and starts it as soon as its group has capacity
+func (q *JobQueueService) SubmitJob(ctx context.Context, req *pb.SubmitJobRequest) (*pb.QueuedJob, error) {
+	return q.Submit(req)
+}
+
+// Submit queues a job; it is SubmitJob for callers inside the server
+func (q *JobQueueService) Submit(req *pb.SubmitJobRequest) (*pb.QueuedJob, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.submitLocked(req, 0)
+}
+
+// submitLocked queues a job whose earlier runs made the given number of
+// attempts
+func (q *JobQueueService) submitLocked(req *pb.SubmitJobRequest, attempts int32) (*pb.QueuedJob, error) {
+	t, ok := q.types[req.JobType]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown job type: %s", req.JobType)
+	}
+
+	jobId := req.JobId
+	if jobId == "" {
+		jobId = newID(t.Name)
+	}
+
+	jobCtx, release, err := q.progress.RegisterJob(context.Background(), jobId)
+	if err != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "job %s is already queued or running", jobId)
+	}
+
+	job := &queuedJob{
+		id:          jobId,
+		jobType:     t,
+		group:       req.ConcurrencyGroup,
+		priority:    req.Priority,
+		params:      req.Params,
+		submittedAt: time.Now(),
+		attempts:    attempts,
+		ctx:         jobCtx,
+		release:     release,
+	}
+	if job.group == "" {
+		job.group = t.Group
+	}
+
+	q.insertLocked(job, -1)
+	log.Printf("Job queued: %s (type: %s, group: %s, priority: %d)", job.id, t.Name, job.group, job.priority)
+
+	// Take a cancelled job out of the queue right away so that the jobs
+	// behind it move up; CancelJob itself only cancels the context
+	context.AfterFunc(jobCtx, func() {
+		q.remove(job)
+	})
+
+	q.announceLocked(job.group)
+	q.dispatchLocked(job.group)
+	return q.describeLocked(job), nil
+}
+
+// insertLocked adds a job to its group's waiting list, at index if it is
+// not negative and otherwise behind every job of the same or higher priority.
+// ReorderJob can move jobs out of priority order, so the list is scanned from
+// the end rather than searched.
+func (q *JobQueueService) insertLocked(job *queuedJob, index int) {
+	waiting := q.waiting[job.group]
+	if index < 0 || index > len(waiting) {
+		index = 0
+		for i := len(waiting) - 1; i >= 0; i-- {
+			if waiting[i].priority >= job.priority {
+				index = i + 1
+				break
+			}
+		}
+	}
+	waiting = append(waiting, nil)
+	copy(waiting[index+1:], waiting[index:])
+	waiting[index] = job
+	q.waiting[job.group] = waiting
+}
+
+// removeWaitingLocked takes a job out of its group's waiting list and
+// reports whether it was waiting
+func (q *JobQueueService) removeWaitingLocked(job *queuedJob) bool {
+	waiting := q.waiting[job.group]
+	for i, w := range waiting {
+		if w == job {
+			q.waiting[job.group] = append(waiting[:i], waiting[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// remove drops a cancelled job that has not started yet
+func (q *JobQueueService) remove(job *queuedJob) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.removeWaitingLocked(job) {
+		job.release()
+		q.announceLocked(job.group)
+	}
+}
+
+// announceLocked reports the position of every waiting job of a group whose
+// position changed since it was last reported
+func (q *JobQueueService) announceLocked(group string) {
+	for i, job := range q.waiting[group] {
+		position := int32(i + 1)
+		if job.position == position {
+			continue
+		}
+		job.position = position
+		q.progress.reportJob(job.ctx, &pb.ProgressUpdate{
+			Type:          pb.ProgressType_PROGRESS_TYPE_QUEUED,
+			Message:       fmt.Sprintf("実行待ち（%d番目）", position),
+			JobId:         job.id,
+			JobType:       job.jobType.Name,
+			QueuePosition: position,
+		})
+	}
+}
+
+// dispatchLocked starts waiting jobs of a group while it has capacity
+func (q *JobQueueService) dispatchLocked(group string) {
+	limit := q.limits[group]
+	if limit == 0 {
+		limit = defaultGroupLimit
+	}
+
+	started := false
+	for len(q.running[group]) < limit && len(q.waiting[group]) > 0 {
+		job := q.waiting[group][0]
+		q.waiting[group] = q.waiting[group][1:]
+		started = true
+
+		// Checking the context and reporting STARTED happen atomically, so a
+		// job cancelled while queued is never started
+		if !q.progress.reportJob(job.ctx, &pb.ProgressUpdate{
+			Type:    pb.ProgressType_PROGRESS_TYPE_STARTED,
+			Message: "ジョブを開始しました",
+			JobId:   job.id,
+			JobType: job.jobType.Name,
+		}) {
+			job.release()
+			continue
+		}
+
+		job.position = 0
+		q.running[group] = append(q.running[group], job)
+		go q.run(job)
+	}
+
+	if started {
+		q.announceLocked(group)
+	}
+}
+
+// run executes a started job and reports its outcome
+func (q *JobQueueService) run(job *queuedJob) {
+	log.Printf("Job started: %s (type: %s)", job.id, job.jobType.Name)
+
+	var err error
+	if job.jobType.Retry.MaxAttempts > 1 {
+		err = q.runAttempts(job)
+	} else {
+		q.nextAttempt(job)
+		err = q.execute(job, job.id)
+	}
+	cancelled := errors.Is(context.Cause(job.ctx), ErrJobCancelled)
+
+	update := &pb.ProgressUpdate{
+		Type:       pb.ProgressType_PROGRESS_TYPE_COMPLETE,
+		Message:    "ジョブが完了しました",
+		JobId:      job.id,
+		JobType:    job.jobType.Name,
+		Percentage: 100,
+	}
+	if err != nil {
+		update = &pb.ProgressUpdate{
+			Type:    pb.ProgressType_PROGRESS_TYPE_ERROR,
+			Message: fmt.Sprintf("ジョブが失敗しました: %v", err),
+			JobId:   job.id,
+			JobType: job.jobType.Name,
+		}
+		if !cancelled {
+			// The job ends as an ERROR like any other failure; the detail
+			// tells watchers that RetryJob can requeue it
+			update.Details = map[string]string{
+				DeadLetterDetail: "true",
+				"attempts":       strconv.Itoa(int(job.attempts)),
+				"error_class":    string(ClassifyError(err)),
+			}
+		}
+	}
+
+	switch {
+	case cancelled:
+		log.Printf("Job cancelled: %s", job.id)
+	case err != nil:
+		log.Printf("Job failed after %d attempts: %s: %v", job.attempts, job.id, err)
+	default:
+		log.Printf("Job finished: %s", job.id)
+	}
+
+	// Hold the queue lock until the job is released so that RetryJob cannot
+	// requeue the job ID before its final update has been reported
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.progress.reportJob(job.ctx, update)
+	if err != nil && !cancelled {
+		job.lastErr = err.Error()
+		job.lastClass = ClassifyError(err)
+		q.deadLetterLocked(job)
+	}
+	job.release()
+
+	running := q.running[job.group]
+	for i, r := range running {
+		if r == job {
+			q.running[job.group] = append(running[:i], running[i+1:]...)
+			break
+		}
+	}
+	q.dispatchLocked(job.group)
+}
+
+// execute calls the job function, turning a panic into an error so that a
+// broken job type cannot take the server down or block its group forever
+func (q *JobQueueService) execute(job *queuedJob, runId string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return job.jobType.Run(job.ctx, &JobRun{
+		ID:       runId,
+		Type:     job.jobType.Name,
+		Params:   job.params,
+		progress: q.progress,
+	})
+}
+
+// describeLocked converts a job for the API
+func (q *JobQueueService) describeLocked(job *queuedJob) *pb.QueuedJob {
+	state := pb.JobState_JOB_STATE_QUEUED
+	var position int32
+	if i := q.indexLocked(job); i >= 0 {
+		position = int32(i + 1)
+	} else {
+		state = q.progress.jobState(job.id)
+	}
+
+	return &pb.QueuedJob{
+		JobId:            job.id,
+		JobType:          job.jobType.Name,
+		ConcurrencyGroup: job.group,
+		Priority:         job.priority,
+		State:            state,
+		Position:         position,
+		SubmittedAt:      job.submittedAt.Unix(),
+		Params:           job.params,
+		Attempts:         job.attempts,
+		MaxAttempts:      int32(max(job.jobType.Retry.MaxAttempts, 1)),
+		LastError:        job.lastErr,
+		LastErrorClass:   string(job.lastClass),
+	}
+}
+
+// indexLocked returns the index of a job in its group's waiting list, or -1
+func (q *JobQueueService) indexLocked(job *queuedJob) int {
+	for i, w := range q.waiting[job.group] {
+		if w == job {
+			return i
+		}
+	}
+	return -1
+}
+
+// findLocked looks up a waiting or running job by ID
+func (q *JobQueueService) findLocked(jobId string) *queuedJob {
+	for _, jobs := range []map[string][]*queuedJob{q.waiting, q.running} {
+		for _, group := range jobs {
+			for _, job := range group {
+				if job.id == jobId {
+					return job
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ListQueue returns running jobs followed by waiting jobs, per group
+func (q *JobQueueService) ListQueue(ctx context.Context, req *pb.ListQueueRequest) (*pb.ListQueueResponse, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	groups := make(map[string]bool)
+	for group := range q.waiting {
+		groups[group] = true
+	}
+	for group := range q.running {
+		groups[group] = true
+	}
+	names := make([]string, 0, len(groups))
+	for group := range groups {
+		if req.ConcurrencyGroup == "" || group == req.ConcurrencyGroup {
+			names = append(names, group)
+		}
+	}
+	sort.Strings(names)
+
+	resp := &pb.ListQueueResponse{}
+	for _, group := range names {
+		for _, job := range q.running[group] {
+			resp.Jobs = append(resp.Jobs, q.describeLocked(job))
+		}
+		for _, job := range q.waiting[group] {
+			resp.Jobs = append(resp.Jobs, q.describeLocked(job))
+		}
+	}
+	return resp, nil
+}
+
+// ReorderJob changes the priority and/or position of a waiting job
+func (q *JobQueueService) ReorderJob(ctx context.Context, req *pb.ReorderJobRequest) (*pb.QueuedJob, error) {
+	if req.JobId == "" {
+		return nil, status.Error(codes.InvalidArgument, "job_id is required")
+	}
+	if req.Priority == nil && req.Position <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "priority or position is required")
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job := q.findLocked(req.JobId)
+	if job == nil {
+		return nil, status.Errorf(codes.NotFound, "job not in queue: %s", req.JobId)
+	}
+	if !q.removeWaitingLocked(job) {
+		return nil, status.Errorf(codes.FailedPrecondition, "job %s is already running", req.JobId)
+	}
+
+	if req.Priority != nil {
+		job.priority = *req.Priority
+	}
+	q.insertLocked(job, int(req.Position)-1)
+	log.Printf("Job reordered: %s (priority: %d, position: %d)", job.id, job.priority, q.indexLocked(job)+1)
+
+	q.announceLocked(job.group)
+	return q.describeLocked(job), nil
+}
+
+// CancelJob cancels a waiting or running job. It behaves like
+// ProgressService.CancelJob but only accepts jobs from the queue.
+func (q *JobQueueService) CancelJob(ctx context.Context, req *pb.CancelJobRequest) (*pb.Job, error) {
+	if req.JobId == "" {
+		return nil, status.Error(codes.InvalidArgument, "job_id is required")
+	}
+
+	q.mu.Lock()
+	job := q.findLocked(req.JobId)
+	q.mu.Unlock()
+	if job == nil {
+		return nil, status.Errorf(codes.NotFound, "job not in queue: %s", req.JobId)
+	}
+
+	return q.progress.CancelJob(ctx, req)
+}
+
+// newID returns a random ID such as "etc_download-k3v9x2m4q8wz"
+func newID(prefix string) string {
+	return fmt.Sprintf("%s-%s", prefix, strings.ToLower(rand.Text()[:12]))
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestInsertLocked(t *testing.T) {
+	tests := []struct {
+		name     string
+		waiting  []int32
+		priority int32
+		want     []string
+	}{
+		{"empty", nil, 0, []string{"new"}},
+		{"behind the same priority", []int32{5, 0, 0}, 0, []string{"0", "1", "2", "new"}},
+		{"ahead of lower priorities", []int32{5, 0, 0}, 3, []string{"0", "new", "1", "2"}},
+		{"ahead of everything", []int32{5, 0}, 9, []string{"new", "0", "1"}},
+		// Job 1 was moved ahead of job 0 by ReorderJob
+		{"manual order", []int32{0, 5, 5, 0}, 3, []string{"0", "1", "2", "new", "3"}},
+		{"manual order kept for lower priority", []int32{0, 5, 0}, 0, []string{"0", "1", "2", "new"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &JobQueueService{waiting: make(map[string][]*queuedJob)}
+			for i, p := range tt.waiting {
+				q.waiting["g"] = append(q.waiting["g"], &queuedJob{id: string(rune('0' + i)), group: "g", priority: p})
+			}
+			q.insertLocked(&queuedJob{id: "new", group: "g", priority: tt.priority}, -1)
+
+			var got []string
+			for _, job := range q.waiting["g"] {
+				got = append(got, job.id)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("waiting = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
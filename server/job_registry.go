@@ -0,0 +1,262 @@
+package server
+
+import (
+	"sort"
+	"sync"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// registryMaxFinishedJobs bounds how many finished jobs are remembered
+const registryMaxFinishedJobs = 200
+
+// jobRegistry derives job state from broadcast progress updates. It is not
+// safe for concurrent use; ProgressService guards it with its own mutex.
+type jobRegistry struct {
+	jobs     map[string]*pb.Job
+	watchers []*jobWatcher
+}
+
+func newJobRegistry() *jobRegistry {
+	return &jobRegistry{
+		jobs: make(map[string]*pb.Job),
+	}
+}
+
+// apply updates the job an update belongs to and notifies watchers. It
+// returns a snapshot of the job and whether its state changed, or nil for
+// updates without a job ID.
+func (r *jobRegistry) apply(update *pb.ProgressUpdate) (*pb.Job, bool) {
+	if update.JobId == "" {
+		return nil, false
+	}
+
+	job, ok := r.jobs[update.JobId]
+	if !ok || (isStart(update.Type) && job.FinishedAt != 0) {
+		// New job, or a finished job ID being reused for another run
+		job = &pb.Job{
+			JobId:     update.JobId,
+			StartedAt: update.Timestamp,
+		}
+		r.jobs[update.JobId] = job
+	}
+
+	if update.JobType != "" {
+		job.JobType = update.JobType
+	}
+	if update.Message != "" {
+		job.Message = update.Message
+	}
+	if update.TotalSteps != 0 {
+		job.TotalSteps = update.TotalSteps
+	}
+	if update.ParentJobId != "" {
+		job.ParentJobId = update.ParentJobId
+	}
+	if update.BytesTotal != 0 {
+		job.BytesTotal = update.BytesTotal
+	}
+	if len(update.Details) > 0 {
+		job.Details = update.Details
+	}
+	if update.Type != pb.ProgressType_PROGRESS_TYPE_ERROR && update.Type != pb.ProgressType_PROGRESS_TYPE_CANCELLED &&
+		update.Type != pb.ProgressType_PROGRESS_TYPE_DEAD_LETTER {
+		job.CurrentStep = update.CurrentStep
+		job.Percentage = update.Percentage
+		job.BytesTransferred = update.BytesTransferred
+	}
+	job.BytesPerSecond = update.BytesPerSecond
+	job.EtaSeconds = update.EtaSeconds
+	job.UpdatedAt = update.Timestamp
+	job.LastSequence = update.Sequence
+	job.QueuePosition = update.QueuePosition
+	previous := job.State
+
+	switch update.Type {
+	case pb.ProgressType_PROGRESS_TYPE_QUEUED:
+		job.State = pb.JobState_JOB_STATE_QUEUED
+	case pb.ProgressType_PROGRESS_TYPE_STARTED:
+		if previous == pb.JobState_JOB_STATE_QUEUED {
+			// Time spent waiting in the queue is not part of the run
+			job.StartedAt = update.Timestamp
+		}
+		job.State = pb.JobState_JOB_STATE_STARTED
+	case pb.ProgressType_PROGRESS_TYPE_PROGRESS:
+		job.State = pb.JobState_JOB_STATE_RUNNING
+	case pb.ProgressType_PROGRESS_TYPE_COMPLETE:
+		job.State = pb.JobState_JOB_STATE_COMPLETE
+		job.FinishedAt = update.Timestamp
+	case pb.ProgressType_PROGRESS_TYPE_ERROR:
+		job.State = pb.JobState_JOB_STATE_ERROR
+		if update.Details[DeadLetterDetail] == "true" {
+			job.State = pb.JobState_JOB_STATE_DEAD_LETTER
+		}
+		job.FinishedAt = update.Timestamp
+	case pb.ProgressType_PROGRESS_TYPE_CANCELLED:
+		job.State = pb.JobState_JOB_STATE_CANCELLED
+		job.FinishedAt = update.Timestamp
+	case pb.ProgressType_PROGRESS_TYPE_DEAD_LETTER:
+		job.State = pb.JobState_JOB_STATE_DEAD_LETTER
+		job.FinishedAt = update.Timestamp
+	default:
+		if job.State == pb.JobState_JOB_STATE_UNSPECIFIED {
+			job.State = pb.JobState_JOB_STATE_RUNNING
+		}
+	}
+
+	if job.FinishedAt != 0 {
+		r.trim()
+	}
+
+	snapshot := proto.Clone(job).(*pb.Job)
+	for _, w := range r.watchers {
+		w.notify(snapshot)
+	}
+	return snapshot, job.State != previous
+}
+
+// restore adds a job loaded from the history unless it is already known
+func (r *jobRegistry) restore(job *pb.Job) {
+	if _, ok := r.jobs[job.JobId]; ok {
+		return
+	}
+	r.jobs[job.JobId] = proto.Clone(job).(*pb.Job)
+}
+
+// trim forgets the oldest finished jobs beyond registryMaxFinishedJobs
+func (r *jobRegistry) trim() {
+	var finished []*pb.Job
+	for _, job := range r.jobs {
+		if job.FinishedAt != 0 {
+			finished = append(finished, job)
+		}
+	}
+	if len(finished) <= registryMaxFinishedJobs {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].FinishedAt < finished[j].FinishedAt
+	})
+	for _, job := range finished[:len(finished)-registryMaxFinishedJobs] {
+		delete(r.jobs, job.JobId)
+	}
+}
+
+// get returns a copy of a job
+func (r *jobRegistry) get(jobId string) (*pb.Job, bool) {
+	job, ok := r.jobs[jobId]
+	if !ok {
+		return nil, false
+	}
+	return proto.Clone(job).(*pb.Job), true
+}
+
+// cancelled reports whether a job has been cancelled
+func (r *jobRegistry) cancelled(jobId string) bool {
+	job, ok := r.jobs[jobId]
+	return ok && job.State == pb.JobState_JOB_STATE_CANCELLED
+}
+
+// list returns copies of matching jobs, newest first
+func (r *jobRegistry) list(states []pb.JobState, jobType, parentJobId string) []*pb.Job {
+	jobs := make([]*pb.Job, 0, len(r.jobs))
+	for _, job := range r.jobs {
+		if !matchJob(job, states, jobType) {
+			continue
+		}
+		if parentJobId != "" && job.ParentJobId != parentJobId {
+			continue
+		}
+		jobs = append(jobs, proto.Clone(job).(*pb.Job))
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].StartedAt != jobs[j].StartedAt {
+			return jobs[i].StartedAt > jobs[j].StartedAt
+		}
+		return jobs[i].JobId < jobs[j].JobId
+	})
+	return jobs
+}
+
+func (r *jobRegistry) addWatcher(w *jobWatcher) {
+	r.watchers = append(r.watchers, w)
+}
+
+func (r *jobRegistry) removeWatcher(w *jobWatcher) {
+	for i, rw := range r.watchers {
+		if rw == w {
+			r.watchers = append(r.watchers[:i], r.watchers[i+1:]...)
+			return
+		}
+	}
+}
+
+// matchJob reports whether a job passes the state and type filters
+func matchJob(job *pb.Job, states []pb.JobState, jobType string) bool {
+	if jobType != "" && job.JobType != jobType {
+		return false
+	}
+	if len(states) == 0 {
+		return true
+	}
+	for _, state := range states {
+		if job.State == state {
+			return true
+		}
+	}
+	return false
+}
+
+// jobWatcher buffers job changes for one WatchJobs stream. Pending changes
+// are keyed by job ID, so a slow client only ever receives the latest state
+// of each job and memory stays bounded by the number of jobs.
+type jobWatcher struct {
+	jobType string
+
+	mu      sync.Mutex
+	pending map[string]*pb.Job
+	order   []string
+	wake    chan struct{}
+}
+
+func newJobWatcher(jobType string) *jobWatcher {
+	return &jobWatcher{
+		jobType: jobType,
+		pending: make(map[string]*pb.Job),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// notify queues a job change without blocking
+func (w *jobWatcher) notify(job *pb.Job) {
+	if w.jobType != "" && job.JobType != w.jobType {
+		return
+	}
+
+	w.mu.Lock()
+	if _, ok := w.pending[job.JobId]; !ok {
+		w.order = append(w.order, job.JobId)
+	}
+	w.pending[job.JobId] = job
+	w.mu.Unlock()
+
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// drain takes all pending job changes in the order they first arrived
+func (w *jobWatcher) drain() []*pb.Job {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	jobs := make([]*pb.Job, 0, len(w.order))
+	for _, id := range w.order {
+		jobs = append(jobs, w.pending[id])
+	}
+	w.pending = make(map[string]*pb.Job)
+	w.order = nil
+	return jobs
+}
@@ -0,0 +1,294 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/rand/v2"
+	"net"
+	"os"
+	"os/exec"
+	"time"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// deadLetterMax bounds how many failed jobs are kept for RetryJob
+const deadLetterMax = 100
+
+// DeadLetterDetail is set to "true" in the details of the ERROR update of a
+// job that was moved to the dead letters
+const DeadLetterDetail = "dead_letter"
+
+// ErrorClass groups job errors for retry decisions
+type ErrorClass string
+
+const (
+	// ErrorClassTimeout is a deadline or I/O timeout
+	ErrorClassTimeout ErrorClass = "timeout"
+	// ErrorClassNetwork is a connection or DNS failure
+	ErrorClassNetwork ErrorClass = "network"
+	// ErrorClassProcess is a helper process that exited with an error
+	ErrorClassProcess ErrorClass = "process"
+	// ErrorClassPermanent is an error that retrying cannot fix
+	ErrorClassPermanent ErrorClass = "permanent"
+	// ErrorClassUnknown is any other error
+	ErrorClassUnknown ErrorClass = "unknown"
+)
+
+// classifiedError is an error with an explicit class
+type classifiedError struct {
+	class ErrorClass
+	err   error
+}
+
+func (e *classifiedError) Error() string { return e.err.Error() }
+func (e *classifiedError) Unwrap() error { return e.err }
+
+// WithErrorClass marks err as belonging to class, overriding ClassifyError
+func WithErrorClass(err error, class ErrorClass) error {
+	if err == nil {
+		return nil
+	}
+	return &classifiedError{class: class, err: err}
+}
+
+// Permanent marks err as not worth retrying
+func Permanent(err error) error {
+	return WithErrorClass(err, ErrorClassPermanent)
+}
+
+// ClassifyError returns the class of a job error
+func ClassifyError(err error) ErrorClass {
+	var classified *classifiedError
+	if errors.As(err, &classified) {
+		return classified.class
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) {
+		return ErrorClassTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return ErrorClassTimeout
+		}
+		return ErrorClassNetwork
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return ErrorClassProcess
+	}
+	return ErrorClassUnknown
+}
+
+// RetryPolicy describes how failed attempts of a job type are retried. The
+// zero value runs a job once.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts per run, including the first
+	MaxAttempts int
+	// InitialBackoff is the delay before the second attempt
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts (0 = no cap)
+	MaxBackoff time.Duration
+	// Multiplier grows the delay after each attempt (default 2)
+	Multiplier float64
+	// Jitter randomises each delay by up to this fraction (0-1)
+	Jitter float64
+	// RetryOn lists the error classes worth retrying; nil means every
+	// class except ErrorClassPermanent
+	RetryOn []ErrorClass
+}
+
+// retryable reports whether an error of class should be retried
+func (p RetryPolicy) retryable(class ErrorClass) bool {
+	if p.RetryOn == nil {
+		return class != ErrorClassPermanent
+	}
+	for _, c := range p.RetryOn {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay after the given failed attempt (1-based)
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		delay *= 1 + p.Jitter*(2*rand.Float64()-1)
+	}
+	return time.Duration(delay)
+}
+
+// runAttempts runs a job until an attempt succeeds, fails with an error that
+// is not retryable, or the policy runs out of attempts. Each attempt is
+// reported as a sub-job of the job.
+func (q *JobQueueService) runAttempts(job *queuedJob) error {
+	policy := job.jobType.Retry
+	total := int32(policy.MaxAttempts)
+
+	for n := int32(1); ; n++ {
+		attemptId := fmt.Sprintf("%s-attempt-%d", job.id, q.nextAttempt(job))
+
+		q.progress.reportJob(job.ctx, &pb.ProgressUpdate{
+			Type:        pb.ProgressType_PROGRESS_TYPE_PROGRESS,
+			Message:     fmt.Sprintf("試行 %d/%d", n, total),
+			JobId:       job.id,
+			JobType:     job.jobType.Name,
+			CurrentStep: n,
+			TotalSteps:  total,
+		})
+		q.progress.BroadcastProgress(&pb.ProgressUpdate{
+			Type:        pb.ProgressType_PROGRESS_TYPE_STARTED,
+			Message:     fmt.Sprintf("試行 %d/%d を開始しました", n, total),
+			JobId:       attemptId,
+			JobType:     job.jobType.Name,
+			ParentJobId: job.id,
+		})
+
+		err := q.execute(job, attemptId)
+		q.finishAttempt(job, attemptId, err)
+		if err == nil {
+			return nil
+		}
+		if job.ctx.Err() != nil {
+			return err
+		}
+
+		class := ClassifyError(err)
+		if int(n) >= policy.MaxAttempts || !policy.retryable(class) {
+			return err
+		}
+
+		delay := policy.backoff(int(n))
+		log.Printf("Job %s attempt %d failed (%s): %v; retrying in %v", job.id, n, class, err, delay.Round(time.Second))
+		q.progress.reportJob(job.ctx, &pb.ProgressUpdate{
+			Type:        pb.ProgressType_PROGRESS_TYPE_PROGRESS,
+			Message:     fmt.Sprintf("%d秒後に再試行します: %v", int(math.Ceil(delay.Seconds())), err),
+			JobId:       job.id,
+			JobType:     job.jobType.Name,
+			CurrentStep: n,
+			TotalSteps:  total,
+		})
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-job.ctx.Done():
+			timer.Stop()
+			return context.Cause(job.ctx)
+		}
+	}
+}
+
+// nextAttempt counts a new attempt of a job and returns its number
+func (q *JobQueueService) nextAttempt(job *queuedJob) int32 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job.attempts++
+	return job.attempts
+}
+
+// finishAttempt reports the outcome of an attempt sub-job unless the job
+// function already did
+func (q *JobQueueService) finishAttempt(job *queuedJob, attemptId string, err error) {
+	update := &pb.ProgressUpdate{
+		Type:        pb.ProgressType_PROGRESS_TYPE_COMPLETE,
+		Message:     "試行が成功しました",
+		JobId:       attemptId,
+		Percentage:  100,
+		ParentJobId: job.id,
+	}
+	switch {
+	case errors.Is(context.Cause(job.ctx), ErrJobCancelled):
+		update.Type = pb.ProgressType_PROGRESS_TYPE_CANCELLED
+		update.Message = "ジョブがキャンセルされました"
+		update.Percentage = 0
+	case err != nil:
+		update.Type = pb.ProgressType_PROGRESS_TYPE_ERROR
+		update.Message = fmt.Sprintf("[%s] %v", ClassifyError(err), err)
+		update.Percentage = 0
+	}
+	q.progress.reportJob(context.Background(), update)
+}
+
+// deadLetterLocked keeps a failed job so that RetryJob can run it again
+func (q *JobQueueService) deadLetterLocked(job *queuedJob) {
+	if _, ok := q.deadLetters[job.id]; !ok {
+		q.deadOrder = append(q.deadOrder, job.id)
+	}
+	q.deadLetters[job.id] = job
+
+	for len(q.deadOrder) > deadLetterMax {
+		delete(q.deadLetters, q.deadOrder[0])
+		q.deadOrder = q.deadOrder[1:]
+	}
+}
+
+// ListDeadLetters returns failed jobs that can be retried, oldest first
+func (q *JobQueueService) ListDeadLetters(ctx context.Context, req *pb.ListDeadLettersRequest) (*pb.ListQueueResponse, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	resp := &pb.ListQueueResponse{}
+	for _, id := range q.deadOrder {
+		job := q.deadLetters[id]
+		if req.JobType != "" && job.jobType.Name != req.JobType {
+			continue
+		}
+		resp.Jobs = append(resp.Jobs, q.describeLocked(job))
+	}
+	return resp, nil
+}
+
+// RetryJob queues a failed job again under the same job ID, with a fresh
+// set of attempts
+func (q *JobQueueService) RetryJob(ctx context.Context, req *pb.RetryJobRequest) (*pb.QueuedJob, error) {
+	if req.JobId == "" {
+		return nil, status.Error(codes.InvalidArgument, "job_id is required")
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	dead, ok := q.deadLetters[req.JobId]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "job not in dead letters: %s", req.JobId)
+	}
+
+	job, err := q.submitLocked(&pb.SubmitJobRequest{
+		JobType:          dead.jobType.Name,
+		Priority:         dead.priority,
+		ConcurrencyGroup: dead.group,
+		Params:           dead.params,
+		JobId:            dead.id,
+	}, dead.attempts)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(q.deadLetters, req.JobId)
+	for i, id := range q.deadOrder {
+		if id == req.JobId {
+			q.deadOrder = append(q.deadOrder[:i], q.deadOrder[i+1:]...)
+			break
+		}
+	}
+	log.Printf("Job retried from dead letters: %s", req.JobId)
+	return job, nil
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// jsonlStore is an append-only JSON Lines file of protobuf records, used by
+// the job and query histories. The owner keeps the records in memory, decides
+// which survive a compaction, and serializes every call with its own lock.
+type jsonlStore[T proto.Message] struct {
+	// name describes the store in errors and log messages
+	name     string
+	path     string
+	maxBytes int64
+
+	file *os.File
+	size int64
+}
+
+// load passes every record of the file to add, oldest first. A missing file
+// is an empty store.
+func (s *jsonlStore[T]) load(newRecord func() T, add func(T)) error {
+	f, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", s.name, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		record := newRecord()
+		if err := protojson.Unmarshal(scanner.Bytes(), record); err != nil {
+			// A torn last line after a crash must not lose the whole history
+			log.Printf("Warning: Skipping invalid %s line %d: %v", s.name, lineNo, err)
+			continue
+		}
+		add(record)
+	}
+	return scanner.Err()
+}
+
+// rewrite replaces the file with records, given newest first, and reopens it
+// for appending. Only the newest records that fit in half of maxBytes are
+// written, so that compaction doesn't run on every append; it returns how
+// many were kept.
+func (s *jsonlStore[T]) rewrite(records []T) (int, error) {
+	if s.file != nil {
+		s.file.Close()
+		s.file = nil
+	}
+
+	lines := make([][]byte, 0, len(records))
+	var size int64
+	for _, record := range records {
+		line, err := protojson.Marshal(record)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode %s: %w", s.name, err)
+		}
+		line = append(line, '\n')
+		if s.maxBytes > 0 && size+int64(len(line)) > s.maxBytes/2 {
+			break
+		}
+		lines = append(lines, line)
+		size += int64(len(line))
+	}
+
+	tmpPath := s.path + ".tmp"
+	tmp, err := os.Create(tmpPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create %s: %w", s.name, err)
+	}
+	w := bufio.NewWriter(tmp)
+	for i := len(lines) - 1; i >= 0; i-- {
+		w.Write(lines[i])
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return 0, fmt.Errorf("failed to write %s: %w", s.name, err)
+	}
+	tmp.Close()
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return 0, fmt.Errorf("failed to replace %s: %w", s.name, err)
+	}
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open %s: %w", s.name, err)
+	}
+	s.file = f
+	s.size = size
+	return len(lines), nil
+}
+
+// append writes a record and reports whether the file has outgrown maxBytes
+// and should be compacted. Failures are logged; the record stays in memory.
+func (s *jsonlStore[T]) append(record T) bool {
+	if s.file == nil {
+		return false
+	}
+	line, err := protojson.Marshal(record)
+	if err != nil {
+		log.Printf("Warning: Failed to encode %s: %v", s.name, err)
+		return false
+	}
+	n, err := s.file.Write(append(line, '\n'))
+	s.size += int64(n)
+	if err != nil {
+		log.Printf("Warning: Failed to write %s: %v", s.name, err)
+		return false
+	}
+	return s.maxBytes > 0 && s.size > s.maxBytes
+}
+
+// close closes the file; later appends are dropped
+func (s *jsonlStore[T]) close() error {
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
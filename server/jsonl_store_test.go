@@ -0,0 +1,116 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+)
+
+func TestJobHistoryReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.jsonl")
+	now := time.Now().Unix()
+
+	h, err := OpenJobHistory(path, time.Hour, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Record(&pb.Job{JobId: "a", StartedAt: now, State: pb.JobState_JOB_STATE_RUNNING})
+	h.Record(&pb.Job{JobId: "a", StartedAt: now, State: pb.JobState_JOB_STATE_COMPLETE})
+	h.Record(&pb.Job{JobId: "old", StartedAt: now - 7200})
+	h.Close()
+
+	// A torn last line is skipped
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"jobId":"b",`)
+	f.Close()
+
+	h, err = OpenJobHistory(path, time.Hour, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	jobs := h.Jobs()
+	if len(jobs) != 1 || jobs[0].JobId != "a" || jobs[0].State != pb.JobState_JOB_STATE_COMPLETE {
+		t.Fatalf("reloaded jobs = %v, want the latest snapshot of a", jobs)
+	}
+}
+
+func TestJobHistoryCompaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.jsonl")
+	now := time.Now().Unix()
+
+	h, err := OpenJobHistory(path, time.Hour, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 100 {
+		h.Record(&pb.Job{JobId: fmt.Sprintf("job-%d", i), StartedAt: now + int64(i)})
+	}
+	// Records are visible before the writer has caught up
+	if jobs := h.Jobs(); len(jobs) == 0 {
+		t.Fatal("no jobs right after Record")
+	}
+	if err := h.Close(); err != nil {
+		t.Fatal(err)
+	}
+	h.Record(&pb.Job{JobId: "late", StartedAt: now})
+
+	info, err := os.Stat(path)
+	if err != nil || info.Size() > 2048 {
+		t.Fatalf("history file is %d bytes (%v), want at most 2048", info.Size(), err)
+	}
+	h, err = OpenJobHistory(path, time.Hour, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	jobs := h.Query(&pb.ListJobHistoryRequest{Limit: 1000})
+	if len(jobs) == 0 || jobs[0].JobId != "job-99" {
+		t.Fatalf("reloaded %d jobs, want the newest job-99 first", len(jobs))
+	}
+}
+
+func TestQueryHistoryCompaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queries.jsonl")
+	now := time.Now().Unix()
+
+	h, err := OpenQueryHistory(path, 0, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 100 {
+		h.Record(&pb.QueryHistoryEntry{Sql: fmt.Sprintf("SELECT %d", i), StartedAt: now})
+	}
+	entries := h.Query(&pb.ListQueryHistoryRequest{Limit: 1000})
+	if len(entries) == 0 || len(entries) >= 100 || entries[0].Sql != "SELECT 99" {
+		t.Fatalf("kept %d entries, newest %q; want the newest below the size limit", len(entries), entries[0].GetSql())
+	}
+	h.Close()
+
+	info, err := os.Stat(path)
+	if err != nil || info.Size() > 2048 {
+		t.Fatalf("history file is %d bytes (%v), want at most 2048", info.Size(), err)
+	}
+	h, err = OpenQueryHistory(path, 0, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	// Opening compacts again, keeping the newest entries
+	reloaded := h.Query(&pb.ListQueryHistoryRequest{Limit: 1000})
+	if len(reloaded) == 0 || len(reloaded) > len(entries) {
+		t.Fatalf("reloaded %d entries, want at most the %d kept before", len(reloaded), len(entries))
+	}
+	for i, e := range reloaded {
+		if e.Sql != entries[i].Sql || e.Id != entries[i].Id {
+			t.Fatalf("reloaded entry %d = %q, want %q", i, e.Sql, entries[i].Sql)
+		}
+	}
+}
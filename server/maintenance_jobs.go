@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/yhonda-ohishi-pub-dev/desktop-server/frontend"
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+)
+
+const (
+	// FrontendUpdateCheckJob is the job type that updates the frontend when
+	// a newer release is available
+	FrontendUpdateCheckJob = "frontend_update_check"
+
+	// DownloadCleanupJob is the job type that removes old download folders
+	DownloadCleanupJob = "download_cleanup"
+
+	// defaultDownloadKeep is how many download folders cleanup keeps
+	defaultDownloadKeep = 10
+)
+
+// FrontendUpdateCheckJobType compares the installed frontend with the latest
+// GitHub release and downloads the release if they differ
+func FrontendUpdateCheckJobType() JobType {
+	return JobType{
+		Name:  FrontendUpdateCheckJob,
+		Group: "maintenance",
+		Retry: RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: 10 * time.Second,
+			MaxBackoff:     time.Minute,
+			Jitter:         0.2,
+		},
+		Run: func(ctx context.Context, run *JobRun) error {
+			run.Progress("最新バージョンを確認しています", 1, 2)
+			latest, err := frontend.GetLatestFrontendVersion()
+			if err != nil {
+				return err
+			}
+
+			installed := frontend.InstalledFrontendVersion()
+			if installed == latest {
+				run.Report(&pb.ProgressUpdate{
+					Type:       pb.ProgressType_PROGRESS_TYPE_COMPLETE,
+					Message:    fmt.Sprintf("フロントエンドは最新です（%s）", installed),
+					Percentage: 100,
+				})
+				return nil
+			}
+
+			run.Progress(fmt.Sprintf("フロントエンドを更新しています（%s → %s）", installed, latest), 2, 2)
+			if err := frontend.DownloadLatestRelease(true); err != nil {
+				return err
+			}
+			log.Printf("Frontend updated from %q to %s", installed, latest)
+			return nil
+		},
+	}
+}
+
+// DownloadCleanupJobType removes the oldest folders in the downloads
+// directory next to the executable, keeping the newest ones. The "keep"
+// param overrides how many are kept.
+func DownloadCleanupJobType() JobType {
+	return JobType{
+		Name:  DownloadCleanupJob,
+		Group: "maintenance",
+		Run: func(ctx context.Context, run *JobRun) error {
+			keep := defaultDownloadKeep
+			if v := run.Params["keep"]; v != "" {
+				n, err := strconv.Atoi(v)
+				if err != nil || n < 0 {
+					return fmt.Errorf("invalid keep param: %q", v)
+				}
+				keep = n
+			}
+
+			exePath, err := os.Executable()
+			if err != nil {
+				return fmt.Errorf("failed to get executable path: %w", err)
+			}
+			downloadsDir := filepath.Join(filepath.Dir(exePath), "downloads")
+
+			entries, err := os.ReadDir(downloadsDir)
+			if os.IsNotExist(err) {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read downloads directory: %w", err)
+			}
+
+			type folder struct {
+				name    string
+				modTime int64
+			}
+			var folders []folder
+			for _, entry := range entries {
+				if !entry.IsDir() {
+					continue
+				}
+				info, err := entry.Info()
+				if err != nil {
+					continue
+				}
+				folders = append(folders, folder{entry.Name(), info.ModTime().UnixNano()})
+			}
+			if len(folders) <= keep {
+				return nil
+			}
+
+			// Oldest first
+			sort.Slice(folders, func(i, j int) bool {
+				return folders[i].modTime < folders[j].modTime
+			})
+			remove := folders[:len(folders)-keep]
+
+			for i, f := range remove {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				run.Progress(fmt.Sprintf("%s を削除しています", f.name), int32(i+1), int32(len(remove)))
+				if err := os.RemoveAll(filepath.Join(downloadsDir, f.name)); err != nil {
+					return fmt.Errorf("failed to delete %s: %w", f.name, err)
+				}
+			}
+			log.Printf("Download cleanup removed %d folders (kept %d)", len(remove), keep)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"sort"
+	"time"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+)
+
+const (
+	// replayBufferSize is the number of updates kept per job for late subscribers
+	replayBufferSize = 256
+	// replayMaxJobs bounds the number of jobs kept in the replay buffer
+	replayMaxJobs = 64
+	// replayRetention is how long a finished job stays replayable
+	replayRetention = 10 * time.Minute
+)
+
+// jobBuffer is a fixed-size ring of the most recent updates for one job
+type jobBuffer struct {
+	updates    []*pb.ProgressUpdate
+	start      int
+	count      int
+	lastSeen   time.Time
+	finishedAt time.Time
+}
+
+func (b *jobBuffer) append(update *pb.ProgressUpdate) {
+	if b.count < len(b.updates) {
+		b.updates[(b.start+b.count)%len(b.updates)] = update
+		b.count++
+		return
+	}
+	// Buffer is full: overwrite the oldest entry
+	b.updates[b.start] = update
+	b.start = (b.start + 1) % len(b.updates)
+}
+
+func (b *jobBuffer) each(fn func(*pb.ProgressUpdate)) {
+	for i := 0; i < b.count; i++ {
+		fn(b.updates[(b.start+i)%len(b.updates)])
+	}
+}
+
+// progressBuffer keeps recent updates per job so that clients connecting
+// mid-job (e.g. after a browser reload) can catch up. It is not safe for
+// concurrent use; ProgressService guards it with its own mutex.
+type progressBuffer struct {
+	jobs map[string]*jobBuffer
+}
+
+func newProgressBuffer() *progressBuffer {
+	return &progressBuffer{
+		jobs: make(map[string]*jobBuffer),
+	}
+}
+
+// add records an update, evicting expired or excess jobs as needed
+func (pbuf *progressBuffer) add(update *pb.ProgressUpdate, now time.Time) {
+	pbuf.evict(now)
+
+	jb, ok := pbuf.jobs[update.JobId]
+	if !ok {
+		if len(pbuf.jobs) >= replayMaxJobs {
+			pbuf.evictOldest()
+		}
+		jb = &jobBuffer{updates: make([]*pb.ProgressUpdate, replayBufferSize)}
+		pbuf.jobs[update.JobId] = jb
+	}
+
+	jb.append(update)
+	jb.lastSeen = now
+	if isTerminal(update.Type) {
+		jb.finishedAt = now
+	} else {
+		jb.finishedAt = time.Time{}
+	}
+}
+
+// snapshot returns buffered updates newer than the given sequence number and
+// timestamp, ordered by sequence. Callers apply their own job filters.
+func (pbuf *progressBuffer) snapshot(sinceSequence, sinceTimestamp int64, now time.Time) []*pb.ProgressUpdate {
+	pbuf.evict(now)
+
+	var result []*pb.ProgressUpdate
+	collect := func(u *pb.ProgressUpdate) {
+		if u.Sequence <= sinceSequence {
+			return
+		}
+		if sinceTimestamp > 0 && u.Timestamp < sinceTimestamp {
+			return
+		}
+		result = append(result, u)
+	}
+
+	for _, jb := range pbuf.jobs {
+		jb.each(collect)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Sequence < result[j].Sequence
+	})
+	return result
+}
+
+// evict drops finished jobs whose retention window has passed
+func (pbuf *progressBuffer) evict(now time.Time) {
+	for id, jb := range pbuf.jobs {
+		if !jb.finishedAt.IsZero() && now.Sub(jb.finishedAt) > replayRetention {
+			delete(pbuf.jobs, id)
+		}
+	}
+}
+
+// evictOldest makes room for a new job, preferring finished jobs
+func (pbuf *progressBuffer) evictOldest() {
+	var victim string
+	var victimJob *jobBuffer
+	for id, jb := range pbuf.jobs {
+		if victimJob == nil {
+			victim, victimJob = id, jb
+			continue
+		}
+		victimDone := !victimJob.finishedAt.IsZero()
+		jbDone := !jb.finishedAt.IsZero()
+		if jbDone != victimDone {
+			if jbDone {
+				victim, victimJob = id, jb
+			}
+			continue
+		}
+		if jb.lastSeen.Before(victimJob.lastSeen) {
+			victim, victimJob = id, jb
+		}
+	}
+	if victimJob != nil {
+		delete(pbuf.jobs, victim)
+	}
+}
+
+// isTerminal reports whether an update ends its job
+func isTerminal(t pb.ProgressType) bool {
+	switch t {
+	case pb.ProgressType_PROGRESS_TYPE_COMPLETE,
+		pb.ProgressType_PROGRESS_TYPE_ERROR,
+		pb.ProgressType_PROGRESS_TYPE_CANCELLED,
+		pb.ProgressType_PROGRESS_TYPE_DEAD_LETTER:
+		return true
+	}
+	return false
+}
+
+// isStart reports whether an update type begins a new run of a job ID
+func isStart(t pb.ProgressType) bool {
+	return t == pb.ProgressType_PROGRESS_TYPE_STARTED || t == pb.ProgressType_PROGRESS_TYPE_QUEUED
+}
@@ -0,0 +1,194 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync/atomic"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// PublisherIDMetadataKey identifies an external publisher across reconnects
+const PublisherIDMetadataKey = "x-publisher-id"
+
+// anonymousPublishers numbers publishers that did not send an ID
+var anonymousPublishers atomic.Uint64
+
+// publisherIdentity returns the publisher ID of a PublishProgress stream and
+// whether it was supplied by the client
+func publisherIdentity(stream pb.ProgressService_PublishProgressServer) (string, bool) {
+	if md, ok := metadata.FromIncomingContext(stream.Context()); ok {
+		if ids := md.Get(PublisherIDMetadataKey); len(ids) > 0 && ids[0] != "" {
+			return ids[0], true
+		}
+	}
+
+	addr := "unknown"
+	if p, ok := peer.FromContext(stream.Context()); ok {
+		addr = p.Addr.String()
+	}
+	return fmt.Sprintf("anonymous-%d@%s", anonymousPublishers.Add(1), addr), false
+}
+
+// publishableTypes are the update types an external publisher may send; the
+// queue, cancellation and heartbeat types belong to the server
+var publishableTypes = map[pb.ProgressType]bool{
+	pb.ProgressType_PROGRESS_TYPE_STARTED:  true,
+	pb.ProgressType_PROGRESS_TYPE_PROGRESS: true,
+	pb.ProgressType_PROGRESS_TYPE_COMPLETE: true,
+	pb.ProgressType_PROGRESS_TYPE_ERROR:    true,
+}
+
+// PublishProgress receives progress updates from external processes and
+// broadcasts them. A publisher owns every job ID it starts publishing and may
+// not write to jobs owned by another publisher or by the server itself.
+// Ownership only keeps cooperating processes apart: the publisher ID is
+// client-supplied metadata, so any client that can reach the server can
+// claim it.
+func (s *ProgressService) PublishProgress(stream pb.ProgressService_PublishProgressServer) error {
+	publisherId, named := publisherIdentity(stream)
+	log.Printf("Progress publisher connected: %s", publisherId)
+
+	owned := make(map[string]bool)
+	cancelled := make(map[string]bool)
+	resp := &pb.PublishProgressResponse{}
+
+	defer func() {
+		s.releasePublisher(publisherId, owned, !named)
+		log.Printf("Progress publisher disconnected: %s (%d updates accepted)", publisherId, resp.Accepted)
+	}()
+
+	for {
+		update, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(resp)
+		}
+		if err != nil {
+			return err
+		}
+
+		if update.JobId == "" {
+			return status.Error(codes.InvalidArgument, "job_id is required")
+		}
+
+		accepted, err := s.publish(publisherId, update)
+		if err != nil {
+			log.Printf("Rejected progress from %s: %v", publisherId, err)
+			return err
+		}
+		if !accepted {
+			if !cancelled[update.JobId] {
+				cancelled[update.JobId] = true
+				resp.CancelledJobIds = append(resp.CancelledJobIds, update.JobId)
+			}
+			continue
+		}
+
+		owned[update.JobId] = !isTerminal(update.Type)
+		resp.Accepted++
+	}
+}
+
+// publish checks ownership and broadcasts an update from an external
+// publisher. It reports false if the update was discarded because the job
+// has been cancelled.
+func (s *ProgressService) publish(publisherId string, update *pb.ProgressUpdate) (bool, error) {
+	if !publishableTypes[update.Type] {
+		return false, status.Errorf(codes.InvalidArgument, "publishers cannot send %v updates", update.Type)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	owner, hasOwner := s.owners[update.JobId]
+	if hasOwner && owner != publisherId {
+		return false, status.Errorf(codes.PermissionDenied, "job %s is owned by another publisher", update.JobId)
+	}
+	if !hasOwner {
+		if job, ok := s.jobs.get(update.JobId); ok && job.FinishedAt == 0 {
+			return false, status.Errorf(codes.PermissionDenied, "job %s is owned by the server", update.JobId)
+		}
+	}
+
+	if !isStart(update.Type) && s.jobs.cancelled(update.JobId) {
+		return false, nil
+	}
+
+	// Server assigns ordering; a client-supplied sequence is meaningless
+	update.Sequence = 0
+
+	if isTerminal(update.Type) {
+		delete(s.owners, update.JobId)
+		if reg, ok := s.cancels[update.JobId]; ok {
+			delete(s.cancels, update.JobId)
+			reg.cancel(nil)
+		}
+	} else {
+		s.owners[update.JobId] = publisherId
+		if _, ok := s.cancels[update.JobId]; !ok {
+			// Make published jobs cancellable through CancelJob
+			s.cancels[update.JobId] = &registeredJob{cancel: func(error) {}}
+		}
+	}
+
+	s.broadcastLocked(update)
+	return true, nil
+}
+
+// GrantJob lets a named publisher write to a job owned by the server, such as
+// a helper process launched to do the work. The returned revoke function
+// withdraws the grant if the publisher did not finish the job itself.
+//
+// A grant is not an authentication boundary: x-publisher-id is sent by the
+// client, so any local process that knows or guesses the ID can write to the
+// job while it is granted.
+func (s *ProgressService) GrantJob(jobId, publisherId string) (revoke func()) {
+	s.mu.Lock()
+	s.owners[jobId] = publisherId
+	s.mu.Unlock()
+
+	return func() {
+		s.mu.Lock()
+		if s.owners[jobId] == publisherId {
+			delete(s.owners, jobId)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// releasePublisher cleans up after a publisher disconnects. Jobs left
+// unfinished by an anonymous publisher can never be resumed, so they are
+// reported as failed; named publishers keep ownership for a reconnect.
+func (s *ProgressService) releasePublisher(publisherId string, owned map[string]bool, failUnfinished bool) {
+	if !failUnfinished {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for jobId, running := range owned {
+		if !running || s.owners[jobId] != publisherId {
+			continue
+		}
+		delete(s.owners, jobId)
+		delete(s.cancels, jobId)
+		if s.jobs.cancelled(jobId) {
+			continue
+		}
+
+		job, _ := s.jobs.get(jobId)
+		s.broadcastLocked(&pb.ProgressUpdate{
+			Type:    pb.ProgressType_PROGRESS_TYPE_ERROR,
+			Message: "進捗の送信元が切断されました",
+			JobId:   jobId,
+			JobType: job.GetJobType(),
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package server
+
+import (
+	"testing"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPublishUpdateTypes(t *testing.T) {
+	s := NewProgressService()
+	for typ := range pb.ProgressType_name {
+		update := &pb.ProgressUpdate{Type: pb.ProgressType(typ), JobId: "external"}
+		_, err := s.publish("publisher", update)
+		if publishableTypes[update.Type] {
+			if err != nil {
+				t.Errorf("publish(%v) = %v, want it accepted", update.Type, err)
+			}
+			continue
+		}
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("publish(%v) = %v, want InvalidArgument", update.Type, err)
+		}
+	}
+}
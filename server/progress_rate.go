@@ -0,0 +1,106 @@
+package server
+
+import (
+	"math"
+	"time"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+)
+
+const (
+	// rateSmoothing is the time constant of the exponential moving average
+	// used for transfer rates; larger values react more slowly to bursts
+	rateSmoothing = 5 * time.Second
+	// rateIdleTimeout drops trackers of jobs that stopped reporting without
+	// ever finishing
+	rateIdleTimeout = time.Hour
+)
+
+// rateTracker smooths the byte and step rates of one job
+type rateTracker struct {
+	lastAt    time.Time
+	lastBytes int64
+	lastStep  int32
+	bytesRate float64
+	stepRate  float64
+	primed    bool
+}
+
+// rateEstimator computes rate and ETA from the raw counters reported by
+// producers. It is not safe for concurrent use; ProgressService guards it
+// with its own mutex.
+type rateEstimator struct {
+	jobs map[string]*rateTracker
+}
+
+func newRateEstimator() *rateEstimator {
+	return &rateEstimator{
+		jobs: make(map[string]*rateTracker),
+	}
+}
+
+// apply overwrites BytesPerSecond and EtaSeconds of an update
+func (e *rateEstimator) apply(update *pb.ProgressUpdate, now time.Time) {
+	update.BytesPerSecond = 0
+	update.EtaSeconds = 0
+
+	if update.JobId == "" {
+		return
+	}
+	if isTerminal(update.Type) {
+		delete(e.jobs, update.JobId)
+		return
+	}
+
+	t, ok := e.jobs[update.JobId]
+	if !ok || isStart(update.Type) {
+		e.prune(now)
+		e.jobs[update.JobId] = &rateTracker{
+			lastAt:    now,
+			lastBytes: update.BytesTransferred,
+			lastStep:  update.CurrentStep,
+		}
+		return
+	}
+
+	if dt := now.Sub(t.lastAt).Seconds(); dt > 0 {
+		// The first sample seeds the average instead of being damped towards 0
+		alpha := 1.0
+		if t.primed {
+			alpha = 1 - math.Exp(-dt/rateSmoothing.Seconds())
+		}
+		t.primed = true
+		if update.BytesTransferred >= t.lastBytes {
+			instant := float64(update.BytesTransferred-t.lastBytes) / dt
+			t.bytesRate += alpha * (instant - t.bytesRate)
+		}
+		if update.CurrentStep >= t.lastStep {
+			instant := float64(update.CurrentStep-t.lastStep) / dt
+			t.stepRate += alpha * (instant - t.stepRate)
+		}
+		t.lastAt = now
+		t.lastBytes = update.BytesTransferred
+		t.lastStep = update.CurrentStep
+	}
+
+	update.BytesPerSecond = t.bytesRate
+
+	// Prefer bytes for the ETA and fall back to steps
+	switch {
+	case update.BytesTotal > 0 && t.bytesRate > 0:
+		remaining := float64(update.BytesTotal - update.BytesTransferred)
+		update.EtaSeconds = int64(math.Ceil(math.Max(remaining, 0) / t.bytesRate))
+	case update.TotalSteps > 0 && t.stepRate > 0:
+		remaining := float64(update.TotalSteps - update.CurrentStep)
+		update.EtaSeconds = int64(math.Ceil(math.Max(remaining, 0) / t.stepRate))
+	}
+}
+
+// prune forgets jobs that have been idle for longer than rateIdleTimeout
+func (e *rateEstimator) prune(now time.Time) {
+	for id, t := range e.jobs {
+		if now.Sub(t.lastAt) > rateIdleTimeout {
+			delete(e.jobs, id)
+		}
+	}
+}
@@ -1,80 +1,320 @@
 package server
 
 import (
+	"context"
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
-// streamInfo holds stream and its associated job ID
-type streamInfo struct {
-	stream pb.ProgressService_StreamDownloadProgressServer
-	jobId  string
-	done   chan struct{}
-}
+const (
+	// defaultQueueSize is the number of updates buffered per subscriber
+	defaultQueueSize = 128
+	// defaultSubscriberTimeout is how long a send may block before the
+	// subscriber is considered dead
+	defaultSubscriberTimeout = 30 * time.Second
+	// sweepInterval is how often subscribers are checked for liveness
+	sweepInterval = 5 * time.Second
+
+	// minHeartbeatInterval and maxHeartbeatInterval clamp client requests
+	minHeartbeatInterval = 1 * time.Second
+	maxHeartbeatInterval = 5 * time.Minute
+)
 
 // ProgressService implements the ProgressService gRPC service
 type ProgressService struct {
 	pb.UnimplementedProgressServiceServer
 	mu      sync.RWMutex
 	streams []*streamInfo
+	buffer  *progressBuffer
+	jobs    *jobRegistry
+	rates   *rateEstimator
+	cancels map[string]*registeredJob
+	owners  map[string]string
+	history *JobHistory
+	seq     int64
+
+	queueSize         int
+	overflowPolicy    OverflowPolicy
+	subscriberTimeout time.Duration
+	droppedUpdates    atomic.Uint64
+	sweepOnce         sync.Once
 }
 
-// NewProgressService creates a new ProgressService
-func NewProgressService() *ProgressService {
-	return &ProgressService{
-		streams: make([]*streamInfo, 0),
+// ProgressOption configures a ProgressService
+type ProgressOption func(*ProgressService)
+
+// WithQueueSize sets the number of updates buffered per subscriber
+func WithQueueSize(n int) ProgressOption {
+	return func(s *ProgressService) {
+		if n > 0 {
+			s.queueSize = n
+		}
 	}
 }
 
-// StreamDownloadProgress streams download progress updates to clients
-func (s *ProgressService) StreamDownloadProgress(req *pb.StreamProgressRequest, stream pb.ProgressService_StreamDownloadProgressServer) error {
-	log.Printf("New progress stream client connected (job_id filter: %s)", req.JobId)
+// WithOverflowPolicy sets what happens when a subscriber's queue is full
+func WithOverflowPolicy(p OverflowPolicy) ProgressOption {
+	return func(s *ProgressService) {
+		s.overflowPolicy = p
+	}
+}
 
-	info := &streamInfo{
-		stream: stream,
-		jobId:  req.JobId,
-		done:   make(chan struct{}),
+// WithSubscriberTimeout sets how long a send may block before the
+// subscriber is disconnected as dead
+func WithSubscriberTimeout(d time.Duration) ProgressOption {
+	return func(s *ProgressService) {
+		if d > 0 {
+			s.subscriberTimeout = d
+		}
 	}
+}
 
-	// Add stream to list
-	s.mu.Lock()
-	s.streams = append(s.streams, info)
-	s.mu.Unlock()
+// WithJobHistory persists job lifecycle events to h and restores the jobs
+// it contains. Jobs that were still running when the history was written
+// are recorded as interrupted.
+func WithJobHistory(h *JobHistory) ProgressOption {
+	return func(s *ProgressService) {
+		s.history = h
 
-	// Remove stream when done
-	defer func() {
-		s.mu.Lock()
-		for i, si := range s.streams {
-			if si == info {
-				s.streams = append(s.streams[:i], s.streams[i+1:]...)
-				break
+		now := time.Now().Unix()
+		for _, job := range h.Jobs() {
+			if job.FinishedAt == 0 {
+				job.State = pb.JobState_JOB_STATE_ERROR
+				job.Message = "サーバーの再起動により中断されました"
+				job.UpdatedAt = now
+				job.FinishedAt = now
+				h.Record(job)
 			}
+			s.jobs.restore(job)
 		}
-		s.mu.Unlock()
+		s.jobs.trim()
+	}
+}
+
+// NewProgressService creates a new ProgressService
+func NewProgressService(opts ...ProgressOption) *ProgressService {
+	s := &ProgressService{
+		streams:           make([]*streamInfo, 0),
+		buffer:            newProgressBuffer(),
+		jobs:              newJobRegistry(),
+		rates:             newRateEstimator(),
+		cancels:           make(map[string]*registeredJob),
+		owners:            make(map[string]string),
+		queueSize:         defaultQueueSize,
+		overflowPolicy:    OverflowDropOldest,
+		subscriberTimeout: defaultSubscriberTimeout,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// DroppedUpdates returns the total number of updates dropped for slow subscribers
+func (s *ProgressService) DroppedUpdates() uint64 {
+	return s.droppedUpdates.Load()
+}
+
+// lastSequence returns the sequence number of the latest broadcast update
+func (s *ProgressService) lastSequence() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.seq
+}
+
+// StreamDownloadProgress streams download progress updates to clients
+func (s *ProgressService) StreamDownloadProgress(req *pb.StreamProgressRequest, stream pb.ProgressService_StreamDownloadProgressServer) error {
+	log.Printf("New progress stream client connected (job_id filter: %s, job_ids: %v, job_types: %v, mode: %v, since_sequence: %d, since_timestamp: %d, heartbeat: %ds)",
+		req.JobId, req.JobIds, req.JobTypes, req.Mode, req.SinceSequence, req.SinceTimestamp, req.HeartbeatIntervalSeconds)
+
+	// Send must not be called once the handler has returned, but the handler
+	// cannot wait for the delivery goroutine: a Send blocked on a dead client
+	// only returns when the stream context ends, which is when the handler
+	// returns. Later sends are dropped instead, and grpc ends a blocked one.
+	var returned atomic.Bool
+	info := s.subscribe(stream.Context(), req, func(update *pb.ProgressUpdate) error {
+		if returned.Load() {
+			return context.Canceled
+		}
+		return stream.Send(update)
+	})
+	defer func() {
+		returned.Store(true)
+		s.unsubscribe(info)
 		log.Println("Progress stream client disconnected")
 	}()
 
 	// Wait for completion or context cancellation
 	select {
 	case <-info.done:
-		return nil
+		_, err := info.result()
+		return err
 	case <-stream.Context().Done():
 		return stream.Context().Err()
 	}
 }
 
-// BroadcastProgress sends a progress update to all connected clients
+// subscribe registers a subscriber whose queued updates are delivered by
+// send on a dedicated goroutine. Buffered updates matching req are queued
+// first. The caller must call unsubscribe when the subscriber goes away;
+// subscribers whose ctx has ended are also removed by the sweeper.
+func (s *ProgressService) subscribe(ctx context.Context, req *pb.StreamProgressRequest, send func(*pb.ProgressUpdate) error) *streamInfo {
+	s.sweepOnce.Do(func() {
+		go s.sweep()
+	})
+
+	info := newStreamInfo(send, newStreamFilter(req), s.queueSize, s.overflowPolicy)
+	info.ctxDone = ctx.Done()
+	if req.HeartbeatIntervalSeconds > 0 {
+		interval := time.Duration(req.HeartbeatIntervalSeconds) * time.Second
+		info.heartbeat = min(max(interval, minHeartbeatInterval), maxHeartbeatInterval)
+	}
+
+	// Queue buffered updates and add stream to list under the same lock so
+	// that no broadcast can slip in between the replay and live updates
+	s.mu.Lock()
+	replay := s.buffer.snapshot(req.SinceSequence, req.SinceTimestamp, time.Now())
+	items := make([]queuedUpdate, 0, len(replay))
+	for _, update := range replay {
+		if !info.filter.matches(update) {
+			continue
+		}
+		items = append(items, queuedUpdate{update: update})
+	}
+	// A stream whose jobs already ended has nothing left to wait for. Only
+	// the last update of each job counts, in case a job ID was reused.
+	last := make(map[string]int)
+	for i, item := range items {
+		last[item.update.JobId] = i
+	}
+	for i, item := range items {
+		if last[item.update.JobId] == i && info.filter.closesAfter(item.update, true) {
+			item.closeAfter = true
+			items[i] = item
+			items = items[:i+1]
+			break
+		}
+	}
+	info.prefill(items)
+	s.streams = append(s.streams, info)
+	s.mu.Unlock()
+
+	if len(items) > 0 {
+		log.Printf("Replaying %d buffered updates to new client", len(items))
+	}
+
+	go info.run()
+	return info
+}
+
+// unsubscribe removes a subscriber and stops its sending goroutine
+func (s *ProgressService) unsubscribe(info *streamInfo) {
+	s.mu.Lock()
+	for i, si := range s.streams {
+		if si == info {
+			s.streams = append(s.streams[:i], s.streams[i+1:]...)
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	info.finish(nil)
+	if dropped, _ := info.result(); dropped > 0 {
+		log.Printf("Progress subscriber removed after dropping %d updates", dropped)
+	}
+}
+
+// errDeadSubscriber ends a stream whose client stopped reading
+var errDeadSubscriber = status.Error(codes.Unavailable, "progress stream closed: client is not responding")
+
+// sweep periodically disconnects subscribers blocked in a send for longer
+// than subscriberTimeout and removes those whose request context has ended,
+// so that s.streams never accumulates zombie entries
+func (s *ProgressService) sweep() {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		s.mu.Lock()
+		alive := s.streams[:0]
+		for _, info := range s.streams {
+			select {
+			case <-info.ctxDone:
+				info.finish(nil)
+				log.Println("Removed progress subscriber with ended context")
+				continue
+			default:
+			}
+			if stuck := info.stuckFor(now); stuck > s.subscriberTimeout {
+				info.finish(errDeadSubscriber)
+				log.Printf("Disconnected progress subscriber blocked for %v", stuck.Round(time.Second))
+				continue
+			}
+			alive = append(alive, info)
+		}
+		for i := len(alive); i < len(s.streams); i++ {
+			s.streams[i] = nil
+		}
+		s.streams = alive
+		s.mu.Unlock()
+	}
+}
+
+// BroadcastProgress queues a progress update for all connected clients.
+// It never blocks on a client; see OverflowPolicy for slow subscribers.
 func (s *ProgressService) BroadcastProgress(update *pb.ProgressUpdate) {
+	// Copy so that later changes by the producer don't alter buffered history
+	update = proto.Clone(update).(*pb.ProgressUpdate)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// A cancelled job stays cancelled until it is started or queued again
+	if !isStart(update.Type) && s.jobs.cancelled(update.JobId) {
+		log.Printf("Ignoring progress for cancelled job %s (type: %v)", update.JobId, update.Type)
+		return
+	}
+
+	s.broadcastLocked(update)
+}
+
+// broadcastLocked records and fans out an update; s.mu must be held
+func (s *ProgressService) broadcastLocked(update *pb.ProgressUpdate) {
 	// Set timestamp if not already set
+	now := time.Now()
 	if update.Timestamp == 0 {
-		update.Timestamp = time.Now().Unix()
+		update.Timestamp = now.Unix()
 	}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	// Rate and ETA are always computed here from the raw counters
+	s.rates.apply(update, now)
+
+	// Assign sequence number and keep the update for late subscribers
+	s.seq++
+	update.Sequence = s.seq
+	job, changed := s.jobs.apply(update)
+	if job != nil {
+		// Producers usually name the job type and parent only when starting;
+		// carry them on every update so that subscribers can filter on them
+		if update.JobType == "" {
+			update.JobType = job.JobType
+		}
+		if update.ParentJobId == "" {
+			update.ParentJobId = job.ParentJobId
+		}
+	}
+	if changed && s.history != nil {
+		// Record only queues the snapshot; the file is written without s.mu
+		s.history.Record(job)
+	}
+	s.buffer.add(update, now)
 
 	if len(s.streams) == 0 {
 		return
@@ -82,22 +322,96 @@ func (s *ProgressService) BroadcastProgress(update *pb.ProgressUpdate) {
 
 	log.Printf("Broadcasting progress to %d clients: %s (type: %v, jobId: %s)", len(s.streams), update.Message, update.Type, update.JobId)
 
-	// Send to all connected streams (or matching job ID)
+	// Queue for all connected streams (or matching job ID / type)
 	for i, info := range s.streams {
-		// Filter by job ID if specified
-		if update.JobId != "" && info.jobId != "" && info.jobId != update.JobId {
+		if !info.filter.matches(update) {
 			continue
 		}
 
-		if err := info.stream.Send(update); err != nil {
-			log.Printf("Failed to send progress to client %d: %v", i, err)
-			continue
+		// Close stream once the jobs it waits for have finished, unless the
+		// subscription is persistent
+		closeAfter := info.filter.closesAfter(update, false)
+		if dropped := info.enqueue(update, closeAfter); dropped > 0 {
+			s.droppedUpdates.Add(uint64(dropped))
+			log.Printf("Client %d is too slow, dropped %d update(s) (policy: %v)", i, dropped, info.policy)
 		}
-
-		// Close stream if job is complete or error
-		if update.Type == pb.ProgressType_PROGRESS_TYPE_COMPLETE || update.Type == pb.ProgressType_PROGRESS_TYPE_ERROR {
+		if closeAfter {
 			log.Printf("Closing stream for job %s (type: %v)", update.JobId, update.Type)
-			close(info.done)
+		}
+	}
+}
+
+// ListJobs returns known jobs, newest first
+func (s *ProgressService) ListJobs(ctx context.Context, req *pb.ListJobsRequest) (*pb.ListJobsResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return &pb.ListJobsResponse{
+		Jobs: s.jobs.list(req.States, req.JobType, req.ParentJobId),
+	}, nil
+}
+
+// GetJob returns a single job by ID
+func (s *ProgressService) GetJob(ctx context.Context, req *pb.GetJobRequest) (*pb.Job, error) {
+	if req.JobId == "" {
+		return nil, status.Error(codes.InvalidArgument, "job_id is required")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, ok := s.jobs.get(req.JobId)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "job not found: %s", req.JobId)
+	}
+	return job, nil
+}
+
+// ListJobHistory searches jobs persisted across restarts
+func (s *ProgressService) ListJobHistory(ctx context.Context, req *pb.ListJobHistoryRequest) (*pb.ListJobsResponse, error) {
+	if s.history == nil {
+		return nil, status.Error(codes.Unavailable, "job history is not enabled")
+	}
+	if req.StartedFrom != 0 && req.StartedTo != 0 && req.StartedFrom > req.StartedTo {
+		return nil, status.Error(codes.InvalidArgument, "started_from must not be after started_to")
+	}
+
+	return &pb.ListJobsResponse{
+		Jobs: s.history.Query(req),
+	}, nil
+}
+
+// WatchJobs sends all current jobs, then every job state change
+func (s *ProgressService) WatchJobs(req *pb.WatchJobsRequest, stream pb.ProgressService_WatchJobsServer) error {
+	log.Printf("New job watcher connected (job_type filter: %s)", req.JobType)
+
+	w := newJobWatcher(req.JobType)
+
+	s.mu.Lock()
+	for _, job := range s.jobs.list(nil, req.JobType, "") {
+		w.notify(job)
+	}
+	s.jobs.addWatcher(w)
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.jobs.removeWatcher(w)
+		s.mu.Unlock()
+		log.Println("Job watcher disconnected")
+	}()
+
+	for {
+		select {
+		case <-w.wake:
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+
+		for _, job := range w.drain() {
+			if err := stream.Send(job); err != nil {
+				return err
+			}
 		}
 	}
 }
@@ -0,0 +1,369 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OverflowPolicy decides what happens when a subscriber's queue is full
+type OverflowPolicy int
+
+const (
+	// OverflowDropOldest discards the oldest queued update
+	OverflowDropOldest OverflowPolicy = iota
+	// OverflowCoalesce replaces queued PROGRESS updates with newer ones,
+	// keeping STARTED/COMPLETE/ERROR updates whenever possible
+	OverflowCoalesce
+	// OverflowDisconnect closes the stream of the slow client
+	OverflowDisconnect
+)
+
+// ParseOverflowPolicy converts a configuration value ("drop_oldest",
+// "coalesce" or "disconnect") to an OverflowPolicy
+func ParseOverflowPolicy(s string) (OverflowPolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "drop_oldest":
+		return OverflowDropOldest, nil
+	case "coalesce":
+		return OverflowCoalesce, nil
+	case "disconnect":
+		return OverflowDisconnect, nil
+	default:
+		return OverflowDropOldest, fmt.Errorf("unknown overflow policy: %s", s)
+	}
+}
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowCoalesce:
+		return "coalesce"
+	case OverflowDisconnect:
+		return "disconnect"
+	default:
+		return "drop_oldest"
+	}
+}
+
+// streamFilter selects the updates a subscriber receives and decides when
+// its stream ends
+type streamFilter struct {
+	// jobIds is the set of requested jobs (empty = all jobs)
+	jobIds map[string]bool
+	// jobTypes is the set of requested job types (empty = all types)
+	jobTypes map[string]bool
+	// persistent keeps the stream open across job completions
+	persistent bool
+	// pending holds requested jobs that have not finished yet (AUTO_CLOSE)
+	pending map[string]bool
+}
+
+func newStreamFilter(req *pb.StreamProgressRequest) streamFilter {
+	f := streamFilter{
+		jobIds:     make(map[string]bool),
+		jobTypes:   make(map[string]bool),
+		persistent: req.Mode == pb.SubscriptionMode_SUBSCRIPTION_MODE_PERSISTENT,
+		pending:    make(map[string]bool),
+	}
+	for _, id := range append([]string{req.JobId}, req.JobIds...) {
+		if id != "" {
+			f.jobIds[id] = true
+			f.pending[id] = true
+		}
+	}
+	for _, t := range req.JobTypes {
+		if t != "" {
+			f.jobTypes[t] = true
+		}
+	}
+	return f
+}
+
+// matches reports whether an update passes the filter. A job-filtered
+// stream also receives the updates of the jobs' sub-jobs.
+func (f *streamFilter) matches(update *pb.ProgressUpdate) bool {
+	if len(f.jobTypes) > 0 && !f.jobTypes[update.JobType] {
+		return false
+	}
+	if update.JobId == "" || len(f.jobIds) == 0 {
+		return true
+	}
+	return f.jobIds[update.JobId] || f.jobIds[update.ParentJobId]
+}
+
+// closesAfter reports whether the stream should end once update has been
+// sent. Only job-filtered streams end during a replay, since an unfiltered
+// replay may contain long-finished jobs.
+func (f *streamFilter) closesAfter(update *pb.ProgressUpdate, replay bool) bool {
+	if f.persistent || !isTerminal(update.Type) {
+		return false
+	}
+	if len(f.jobIds) == 0 {
+		return !replay
+	}
+	if !f.jobIds[update.JobId] {
+		// Sub-jobs finishing don't end the parent's stream
+		return false
+	}
+	delete(f.pending, update.JobId)
+	return len(f.pending) == 0
+}
+
+// errSlowSubscriber is returned to a client disconnected by OverflowDisconnect
+var errSlowSubscriber = status.Error(codes.ResourceExhausted, "progress stream closed: client is not keeping up")
+
+// queuedUpdate is an update waiting to be sent to one subscriber
+type queuedUpdate struct {
+	update *pb.ProgressUpdate
+	// closeAfter ends the stream once this update has been sent
+	closeAfter bool
+}
+
+// streamInfo holds a subscriber's send function, its associated job ID and
+// its outbound queue. Each streamInfo is drained by its own goroutine (run)
+// so that a slow client never blocks producers or other clients.
+type streamInfo struct {
+	send    func(*pb.ProgressUpdate) error
+	filter  streamFilter
+	done    chan struct{}
+	stopped chan struct{}
+
+	mu       sync.Mutex
+	queue    []queuedUpdate
+	capacity int
+	policy   OverflowPolicy
+	closing  bool
+	dropped  uint64
+	err      error
+	wake     chan struct{}
+	doneOnce sync.Once
+
+	// heartbeat is the interval of PROGRESS_TYPE_HEARTBEAT messages (0 = off)
+	heartbeat time.Duration
+	// ctxDone is closed when the client's request context ends
+	ctxDone <-chan struct{}
+	// sendingSince is the UnixNano time the current send began (0 = idle)
+	sendingSince atomic.Int64
+	// lastSent is the sequence of the last update sent (writer goroutine only)
+	lastSent int64
+}
+
+func newStreamInfo(send func(*pb.ProgressUpdate) error, filter streamFilter, capacity int, policy OverflowPolicy) *streamInfo {
+	return &streamInfo{
+		send:     send,
+		filter:   filter,
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+		capacity: capacity,
+		policy:   policy,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// prefill queues replayed updates ahead of live ones. The queue grows to hold
+// them so that a long replay does not count as overflow.
+func (info *streamInfo) prefill(items []queuedUpdate) {
+	info.mu.Lock()
+	defer info.mu.Unlock()
+
+	info.queue = append(info.queue, items...)
+	info.capacity += len(items)
+	for _, item := range items {
+		if item.closeAfter {
+			info.closing = true
+		}
+	}
+	if len(info.queue) > 0 {
+		select {
+		case info.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// enqueue queues an update without blocking and returns the number of
+// updates dropped to make room for it
+func (info *streamInfo) enqueue(update *pb.ProgressUpdate, closeAfter bool) int {
+	info.mu.Lock()
+	defer info.mu.Unlock()
+
+	if info.closing {
+		return 0
+	}
+
+	dropped := 0
+	if len(info.queue) >= info.capacity {
+		switch info.policy {
+		case OverflowDisconnect:
+			info.closing = true
+			info.err = errSlowSubscriber
+			info.dropped += uint64(len(info.queue)) + 1
+			dropped = len(info.queue) + 1
+			info.queue = nil
+			info.finishLocked()
+			return dropped
+		case OverflowCoalesce:
+			if update.Type == pb.ProgressType_PROGRESS_TYPE_PROGRESS && info.replaceProgressLocked(update) {
+				info.dropped++
+				return 1
+			}
+			info.removeOldestLocked(true)
+		default:
+			info.removeOldestLocked(false)
+		}
+		info.dropped++
+		dropped = 1
+	}
+
+	info.queue = append(info.queue, queuedUpdate{update: update, closeAfter: closeAfter})
+	if closeAfter {
+		info.closing = true
+	}
+
+	select {
+	case info.wake <- struct{}{}:
+	default:
+	}
+	return dropped
+}
+
+// replaceProgressLocked overwrites the newest queued PROGRESS update of the
+// same job, reporting whether one was found
+func (info *streamInfo) replaceProgressLocked(update *pb.ProgressUpdate) bool {
+	for i := len(info.queue) - 1; i >= 0; i-- {
+		q := info.queue[i].update
+		if q.JobId == update.JobId && q.Type == pb.ProgressType_PROGRESS_TYPE_PROGRESS {
+			info.queue[i].update = update
+			return true
+		}
+	}
+	return false
+}
+
+// removeOldestLocked drops one queued update, preferring PROGRESS updates
+// when preferProgress is set
+func (info *streamInfo) removeOldestLocked(preferProgress bool) {
+	idx := 0
+	if preferProgress {
+		for i, q := range info.queue {
+			if q.update.Type == pb.ProgressType_PROGRESS_TYPE_PROGRESS {
+				idx = i
+				break
+			}
+		}
+	}
+	info.queue = append(info.queue[:idx], info.queue[idx+1:]...)
+}
+
+// pop takes the next queued update
+func (info *streamInfo) pop() (queuedUpdate, bool) {
+	info.mu.Lock()
+	defer info.mu.Unlock()
+
+	if len(info.queue) == 0 {
+		return queuedUpdate{}, false
+	}
+	item := info.queue[0]
+	info.queue[0] = queuedUpdate{}
+	info.queue = info.queue[1:]
+	return item, true
+}
+
+// run sends queued updates until the stream is finished. stopped is closed
+// once it no longer calls send.
+func (info *streamInfo) run() {
+	defer close(info.stopped)
+
+	var ticker *time.Ticker
+	var heartbeat <-chan time.Time
+	if info.heartbeat > 0 {
+		ticker = time.NewTicker(info.heartbeat)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+
+	for {
+		select {
+		case <-info.wake:
+		case <-heartbeat:
+			// Heartbeats only fill silence; they are never queued or buffered
+			if err := info.deliver(&pb.ProgressUpdate{
+				Type:      pb.ProgressType_PROGRESS_TYPE_HEARTBEAT,
+				Timestamp: time.Now().Unix(),
+				Sequence:  info.lastSent,
+			}); err != nil {
+				info.finish(err)
+				return
+			}
+			continue
+		case <-info.done:
+			return
+		}
+
+		for {
+			item, ok := info.pop()
+			if !ok {
+				break
+			}
+			if err := info.deliver(item.update); err != nil {
+				info.finish(err)
+				return
+			}
+			info.lastSent = item.update.Sequence
+			if ticker != nil {
+				ticker.Reset(info.heartbeat)
+			}
+			if item.closeAfter {
+				info.finish(nil)
+				return
+			}
+		}
+	}
+}
+
+// deliver calls send while recording how long it has been blocked
+func (info *streamInfo) deliver(update *pb.ProgressUpdate) error {
+	info.sendingSince.Store(time.Now().UnixNano())
+	defer info.sendingSince.Store(0)
+	return info.send(update)
+}
+
+// stuckFor returns how long the current send has been blocked
+func (info *streamInfo) stuckFor(now time.Time) time.Duration {
+	since := info.sendingSince.Load()
+	if since == 0 {
+		return 0
+	}
+	return now.Sub(time.Unix(0, since))
+}
+
+// finish ends the stream with the given error (nil for a normal close)
+func (info *streamInfo) finish(err error) {
+	info.mu.Lock()
+	defer info.mu.Unlock()
+
+	info.closing = true
+	if info.err == nil {
+		info.err = err
+	}
+	info.finishLocked()
+}
+
+func (info *streamInfo) finishLocked() {
+	info.doneOnce.Do(func() {
+		close(info.done)
+	})
+}
+
+// result returns the drop counter and the error the stream ended with
+func (info *streamInfo) result() (uint64, error) {
+	info.mu.Lock()
+	defer info.mu.Unlock()
+	return info.dropped, info.err
+}
@@ -0,0 +1,393 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	// Embedded zone data so Asia/Tokyo resolves on PCs without it
+	_ "time/tzdata"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultScheduleTimeZone is used for schedules without a time zone
+const DefaultScheduleTimeZone = "Asia/Tokyo"
+
+// scheduleEntry is a schedule with its parsed expression
+type scheduleEntry struct {
+	schedule *pb.Schedule
+	spec     *cronSpec
+	loc      *time.Location
+}
+
+// SchedulerService submits jobs to the job queue on cron schedules. Runs
+// missed while the server was not running are skipped. Schedules are kept
+// in a JSON file so they survive restarts.
+type SchedulerService struct {
+	pb.UnimplementedSchedulerServiceServer
+
+	queue *JobQueueService
+	path  string
+
+	mu      sync.Mutex
+	entries map[string]*scheduleEntry
+	wake    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewSchedulerService loads the schedules stored at path
+func NewSchedulerService(queue *JobQueueService, path string) (*SchedulerService, error) {
+	s := &SchedulerService{
+		queue:   queue,
+		path:    path,
+		entries: make(map[string]*scheduleEntry),
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// load reads the schedule file, skipping schedules that no longer parse
+func (s *SchedulerService) load() error {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read schedules: %w", err)
+	}
+
+	stored := &pb.ListSchedulesResponse{}
+	if err := protojson.Unmarshal(data, stored); err != nil {
+		return fmt.Errorf("failed to decode schedules: %w", err)
+	}
+
+	now := time.Now()
+	for _, sch := range stored.Schedules {
+		entry, err := newScheduleEntry(sch)
+		if err != nil {
+			log.Printf("Warning: Skipping schedule %s: %v", sch.Id, err)
+			continue
+		}
+		entry.plan(now)
+		s.entries[sch.Id] = entry
+	}
+	return nil
+}
+
+// saveLocked writes every schedule to the schedule file
+func (s *SchedulerService) saveLocked() error {
+	data, err := protojson.MarshalOptions{Multiline: true}.Marshal(&pb.ListSchedulesResponse{
+		Schedules: s.listLocked(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode schedules: %w", err)
+	}
+
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write schedules: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return fmt.Errorf("failed to replace schedules: %w", err)
+	}
+	return nil
+}
+
+// newScheduleEntry validates a schedule and parses its expression
+func newScheduleEntry(sch *pb.Schedule) (*scheduleEntry, error) {
+	spec, err := parseCron(sch.Cron)
+	if err != nil {
+		return nil, err
+	}
+
+	zone := sch.TimeZone
+	if zone == "" {
+		zone = DefaultScheduleTimeZone
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", zone)
+	}
+
+	return &scheduleEntry{schedule: sch, spec: spec, loc: loc}, nil
+}
+
+// plan sets the next run after now
+func (e *scheduleEntry) plan(now time.Time) {
+	e.schedule.NextRunAt = 0
+	if !e.schedule.Enabled {
+		return
+	}
+	if next := e.spec.next(now.In(e.loc)); !next.IsZero() {
+		e.schedule.NextRunAt = next.Unix()
+	}
+}
+
+// Start runs the scheduling loop until Stop is called
+func (s *SchedulerService) Start() {
+	go s.loop()
+}
+
+// Stop ends the scheduling loop
+func (s *SchedulerService) Stop() {
+	close(s.stop)
+	<-s.done
+}
+
+// notify makes the loop recompute its timer after a schedule change
+func (s *SchedulerService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *SchedulerService) loop() {
+	defer close(s.done)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-s.wake:
+		case <-timer.C:
+			s.runDue(time.Now())
+		}
+
+		timer.Reset(s.untilNext(time.Now()))
+	}
+}
+
+// untilNext returns how long to sleep until the earliest next run
+func (s *SchedulerService) untilNext(now time.Time) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Wake up at least hourly so that clock changes are picked up
+	wait := time.Hour
+	for _, entry := range s.entries {
+		if entry.schedule.NextRunAt == 0 {
+			continue
+		}
+		if d := time.Unix(entry.schedule.NextRunAt, 0).Sub(now); d < wait {
+			wait = d
+		}
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait
+}
+
+// runDue submits the job of every schedule whose next run has come
+func (s *SchedulerService) runDue(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := false
+	for _, entry := range s.entries {
+		if entry.schedule.NextRunAt == 0 || entry.schedule.NextRunAt > now.Unix() {
+			continue
+		}
+		s.runLocked(entry, now)
+		entry.plan(now)
+		changed = true
+	}
+
+	if changed {
+		if err := s.saveLocked(); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+}
+
+// runLocked submits a schedule's job. A job that cannot be queued is still
+// reported through ProgressService so the failure shows up in the UI.
+func (s *SchedulerService) runLocked(entry *scheduleEntry, now time.Time) (*pb.QueuedJob, error) {
+	sch := entry.schedule
+	sch.LastRunAt = now.Unix()
+
+	job, err := s.queue.Submit(&pb.SubmitJobRequest{
+		JobType:          sch.JobType,
+		Priority:         sch.Priority,
+		ConcurrencyGroup: sch.ConcurrencyGroup,
+		Params:           sch.Params,
+	})
+	if err != nil {
+		log.Printf("Scheduled run of %s (%s) failed: %v", sch.Id, sch.Name, err)
+		sch.LastJobId = newID(sch.JobType)
+		sch.LastError = status.Convert(err).Message()
+		s.queue.progress.BroadcastProgress(&pb.ProgressUpdate{
+			Type:    pb.ProgressType_PROGRESS_TYPE_ERROR,
+			Message: fmt.Sprintf("スケジュール「%s」のジョブを登録できませんでした: %s", sch.Name, sch.LastError),
+			JobId:   sch.LastJobId,
+			JobType: sch.JobType,
+		})
+		return nil, err
+	}
+
+	log.Printf("Scheduled run of %s (%s): job %s", sch.Id, sch.Name, job.JobId)
+	sch.LastJobId = job.JobId
+	sch.LastError = ""
+	return job, nil
+}
+
+// listLocked returns copies of all schedules, oldest first
+func (s *SchedulerService) listLocked() []*pb.Schedule {
+	schedules := make([]*pb.Schedule, 0, len(s.entries))
+	for _, entry := range s.entries {
+		schedules = append(schedules, proto.Clone(entry.schedule).(*pb.Schedule))
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		if schedules[i].CreatedAt != schedules[j].CreatedAt {
+			return schedules[i].CreatedAt < schedules[j].CreatedAt
+		}
+		return schedules[i].Id < schedules[j].Id
+	})
+	return schedules
+}
+
+// validate checks the user-editable fields of a schedule
+func (s *SchedulerService) validate(sch *pb.Schedule) (*scheduleEntry, error) {
+	if sch == nil {
+		return nil, status.Error(codes.InvalidArgument, "schedule is required")
+	}
+	if !s.queue.hasJobType(sch.JobType) {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown job type: %s", sch.JobType)
+	}
+	entry, err := newScheduleEntry(sch)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return entry, nil
+}
+
+// ListSchedules returns all schedules
+func (s *SchedulerService) ListSchedules(ctx context.Context, req *pb.ListSchedulesRequest) (*pb.ListSchedulesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return &pb.ListSchedulesResponse{Schedules: s.listLocked()}, nil
+}
+
+// CreateSchedule adds a schedule
+func (s *SchedulerService) CreateSchedule(ctx context.Context, req *pb.CreateScheduleRequest) (*pb.Schedule, error) {
+	sch := proto.Clone(req.GetSchedule()).(*pb.Schedule)
+	entry, err := s.validate(sch)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	sch.Id = newID("schedule")
+	sch.CreatedAt = now.Unix()
+	sch.LastRunAt = 0
+	sch.LastJobId = ""
+	sch.LastError = ""
+	entry.plan(now)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[sch.Id] = entry
+	if err := s.saveLocked(); err != nil {
+		delete(s.entries, sch.Id)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	log.Printf("Schedule created: %s (%s, %q, %s)", sch.Id, sch.Name, sch.Cron, sch.JobType)
+
+	s.notify()
+	return proto.Clone(sch).(*pb.Schedule), nil
+}
+
+// UpdateSchedule replaces the settings of a schedule, keeping its run status
+func (s *SchedulerService) UpdateSchedule(ctx context.Context, req *pb.UpdateScheduleRequest) (*pb.Schedule, error) {
+	sch := proto.Clone(req.GetSchedule()).(*pb.Schedule)
+	if sch.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "schedule.id is required")
+	}
+	entry, err := s.validate(sch)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, ok := s.entries[sch.Id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "schedule not found: %s", sch.Id)
+	}
+	sch.CreatedAt = old.schedule.CreatedAt
+	sch.LastRunAt = old.schedule.LastRunAt
+	sch.LastJobId = old.schedule.LastJobId
+	sch.LastError = old.schedule.LastError
+	entry.plan(time.Now())
+
+	s.entries[sch.Id] = entry
+	if err := s.saveLocked(); err != nil {
+		s.entries[sch.Id] = old
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	log.Printf("Schedule updated: %s (%s, %q, %s)", sch.Id, sch.Name, sch.Cron, sch.JobType)
+
+	s.notify()
+	return proto.Clone(sch).(*pb.Schedule), nil
+}
+
+// DeleteSchedule removes a schedule
+func (s *SchedulerService) DeleteSchedule(ctx context.Context, req *pb.DeleteScheduleRequest) (*pb.DeleteScheduleResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, ok := s.entries[req.Id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "schedule not found: %s", req.Id)
+	}
+
+	delete(s.entries, req.Id)
+	if err := s.saveLocked(); err != nil {
+		s.entries[req.Id] = old
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	log.Printf("Schedule deleted: %s (%s)", req.Id, old.schedule.Name)
+
+	s.notify()
+	return &pb.DeleteScheduleResponse{}, nil
+}
+
+// RunSchedule submits a schedule's job now without moving its next run
+func (s *SchedulerService) RunSchedule(ctx context.Context, req *pb.RunScheduleRequest) (*pb.QueuedJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[req.Id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "schedule not found: %s", req.Id)
+	}
+
+	job, err := s.runLocked(entry, time.Now())
+	if saveErr := s.saveLocked(); saveErr != nil {
+		log.Printf("Warning: %v", saveErr)
+	}
+	return job, err
+}
@@ -0,0 +1,406 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"unicode"
+)
+
+// readOnlyMode blocks every statement but reads on all connections
+var readOnlyMode atomic.Bool
+
+// SetReadOnlyMode turns the server-wide read-only mode on or off
+func SetReadOnlyMode(on bool) {
+	readOnlyMode.Store(on)
+}
+
+// ReadOnlyMode reports whether the server-wide read-only mode is on
+func ReadOnlyMode() bool {
+	return readOnlyMode.Load()
+}
+
+// StatementKind is the class of a SQL statement
+type StatementKind string
+
+const (
+	// StatementRead is a statement that only reads (SELECT, SHOW, EXPLAIN...)
+	StatementRead StatementKind = "read"
+	// StatementDML changes rows (INSERT, UPDATE, DELETE, MERGE...)
+	StatementDML StatementKind = "dml"
+	// StatementDDL changes the schema (CREATE, ALTER, DROP, TRUNCATE...)
+	StatementDDL StatementKind = "ddl"
+	// StatementOther is anything else, e.g. EXEC, SET, GRANT or transaction
+	// control; it may have side effects the classifier cannot see
+	StatementOther StatementKind = "other"
+)
+
+// kindRank orders kinds from harmless to dangerous
+var kindRank = map[StatementKind]int{
+	StatementRead:  0,
+	StatementDML:   1,
+	StatementOther: 2,
+	StatementDDL:   3,
+}
+
+var statementKeywords = map[string]StatementKind{
+	"SELECT":   StatementRead,
+	"SHOW":     StatementRead,
+	"EXPLAIN":  StatementRead,
+	"DESCRIBE": StatementRead,
+	"DESC":     StatementRead,
+	"VALUES":   StatementRead,
+	"TABLE":    StatementRead,
+
+	"INSERT":  StatementDML,
+	"UPDATE":  StatementDML,
+	"DELETE":  StatementDML,
+	"MERGE":   StatementDML,
+	"REPLACE": StatementDML,
+	"LOAD":    StatementDML,
+
+	"CREATE":   StatementDDL,
+	"ALTER":    StatementDDL,
+	"DROP":     StatementDDL,
+	"TRUNCATE": StatementDDL,
+	"RENAME":   StatementDDL,
+}
+
+// writeKeywords make a statement at least this kind wherever they appear, so
+// that T-SQL batches without semicolons ("SELECT 1 DELETE FROM t") and
+// SELECT ... INTO are not mistaken for reads. A keyword followed by "(" is a
+// function call (MySQL's INSERT() and REPLACE()) and is ignored.
+var writeKeywords = map[string]StatementKind{
+	"INSERT":   StatementDML,
+	"UPDATE":   StatementDML,
+	"DELETE":   StatementDML,
+	"MERGE":    StatementDML,
+	"INTO":     StatementDML,
+	"CREATE":   StatementDDL,
+	"ALTER":    StatementDDL,
+	"DROP":     StatementDDL,
+	"TRUNCATE": StatementDDL,
+	"EXEC":     StatementOther,
+	"EXECUTE":  StatementOther,
+	"CALL":     StatementOther,
+	"GRANT":    StatementOther,
+	"REVOKE":   StatementOther,
+	"DENY":     StatementOther,
+}
+
+// batchKeywords start a statement of a T-SQL batch. SQL Server does not need
+// semicolons between statements, so "SELECT 1 SHUTDOWN" is two statements;
+// these words cannot continue a read and split the batch where they appear
+// outside parentheses. SET continues an UPDATE, DISABLE and ENABLE are only
+// statements before TRIGGER, and statements led by CREATE, ALTER or DROP (a
+// procedure body, ALTER DATABASE ... WITH ROLLBACK IMMEDIATE, DROP ... IF
+// EXISTS) are not split; they are DDL whatever follows.
+var batchKeywords = map[string]bool{
+	"BACKUP": true, "BEGIN": true, "BREAK": true, "BULK": true,
+	"CHECKPOINT": true, "CLOSE": true, "COMMIT": true, "CONTINUE": true,
+	"DBCC": true, "DEALLOCATE": true, "DECLARE": true, "DISABLE": true,
+	"ENABLE": true, "GOTO": true, "IF": true, "KILL": true,
+	"OPEN": true, "PRINT": true, "RAISERROR": true, "READTEXT": true,
+	"RECONFIGURE": true, "RESTORE": true, "RETURN": true, "REVERT": true,
+	"ROLLBACK": true, "SAVE": true, "SET": true, "SETUSER": true,
+	"SHUTDOWN": true, "THROW": true, "UPDATETEXT": true, "USE": true,
+	"WAITFOR": true, "WHILE": true, "WRITETEXT": true,
+}
+
+// Statement is one statement of a SQL text
+type Statement struct {
+	// Keyword decides the kind, in upper case: the leading keyword, or a
+	// write keyword found later in the statement ("SELECT ... INTO")
+	Keyword string
+	Kind    StatementKind
+}
+
+// Classification is the result of ClassifySQL
+type Classification struct {
+	Statements []Statement
+	// Kind is the most dangerous kind among the statements
+	Kind StatementKind
+}
+
+// MultiStatement reports whether the text holds more than one statement
+func (c *Classification) MultiStatement() bool {
+	return len(c.Statements) > 1
+}
+
+// ClassifySQL splits a SQL text into statements and classifies each of them.
+// Comments, string literals and quoted identifiers are skipped using the
+// lexical rules of driver ("sqlserver" or "mysql"). Anything the classifier
+// does not recognise is StatementOther, so it errs on the side of blocking.
+func ClassifySQL(driver, query string) *Classification {
+	c := &Classification{Kind: StatementRead}
+	for _, words := range splitStatements(driver, query) {
+		stmt := classifyStatement(words)
+		c.Statements = append(c.Statements, stmt)
+		if kindRank[stmt.Kind] > kindRank[c.Kind] {
+			c.Kind = stmt.Kind
+		}
+	}
+	return c
+}
+
+// sqlWord is a keyword or identifier
+type sqlWord struct {
+	text string
+	// depth is the number of enclosing parentheses
+	depth int
+	// call is set if "(" follows the word
+	call bool
+}
+
+func classifyStatement(words []sqlWord) Statement {
+	// Skip to the statement after a CTE: WITH a AS (...), b AS (...) DELETE ...
+	first := words[0].text
+	kind, ok := statementKeywords[first]
+	if first == "WITH" {
+		for _, w := range words[1:] {
+			if k, known := statementKeywords[w.text]; known && w.depth == 0 {
+				first, kind, ok = w.text, k, true
+				break
+			}
+		}
+	}
+	if !ok {
+		kind = StatementOther
+	}
+
+	for i, w := range words {
+		k, found := writeKeywords[w.text]
+		if !found || w.call {
+			continue
+		}
+		// SELECT ... FOR UPDATE only locks rows
+		if w.text == "UPDATE" && i > 0 && words[i-1].text == "FOR" {
+			continue
+		}
+		if kindRank[k] > kindRank[kind] {
+			first, kind = w.text, k
+		}
+	}
+	return Statement{Keyword: first, Kind: kind}
+}
+
+// splitStatements tokenizes a SQL text into the words of each statement,
+// split on semicolons and, on SQL Server, on batchKeywords. Only words matter
+// for classification; literals, numbers and punctuation are dropped. Words
+// inside parentheses are kept along with their depth, so that DML hidden in
+// a subquery is still seen.
+func splitStatements(driver, query string) [][]sqlWord {
+	mysql := driver == "mysql"
+	var statements [][]sqlWord
+	var words []sqlWord
+	depth := 0
+
+	flush := func() {
+		if len(words) > 0 {
+			if mysql {
+				statements = append(statements, words)
+			} else {
+				statements = append(statements, splitBatch(words)...)
+			}
+			words = nil
+		}
+		depth = 0
+	}
+
+	// skipQuoted returns the index after a literal closed by quote; a doubled
+	// quote is an escaped quote, and so is a backslash on MySQL
+	skipQuoted := func(i int, quote byte, backslash bool) int {
+		for i++; i < len(query); i++ {
+			switch {
+			case backslash && query[i] == '\\':
+				i++
+			case query[i] == quote:
+				if i+1 < len(query) && query[i+1] == quote {
+					i++
+					continue
+				}
+				return i + 1
+			}
+		}
+		return len(query)
+	}
+
+	for i := 0; i < len(query); {
+		ch := query[i]
+		switch {
+		case ch == ';':
+			flush()
+			i++
+
+		case ch == '(':
+			depth++
+			i++
+
+		case ch == ')':
+			depth = max(depth-1, 0)
+			i++
+
+		case ch == '-' && strings.HasPrefix(query[i:], "--") &&
+			(!mysql || i+2 == len(query) || query[i+2] <= ' '):
+			// MySQL needs whitespace after "--" (1--1 is arithmetic)
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			i += end
+
+		case ch == '#' && mysql:
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			i += end
+
+		case ch == '/' && strings.HasPrefix(query[i:], "/*"):
+			if mysql && strings.HasPrefix(query[i:], "/*!") {
+				// MySQL executes the body of /*!version ... */, so only the
+				// markers are skipped
+				i += 3
+				for i < len(query) && query[i] >= '0' && query[i] <= '9' {
+					i++
+				}
+				continue
+			}
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				i = len(query)
+			} else {
+				i += end + 4
+			}
+
+		case ch == '*' && mysql && strings.HasPrefix(query[i:], "*/"):
+			// End of a /*! ... */ block
+			i += 2
+
+		case ch == '\'':
+			i = skipQuoted(i, '\'', mysql)
+
+		case ch == '"':
+			i = skipQuoted(i, '"', mysql)
+
+		case ch == '`' && mysql:
+			i = skipQuoted(i, '`', false)
+
+		case ch == '[' && !mysql:
+			i = skipQuoted(i, ']', false)
+
+		case ch == '_' || ch == '@' || ch >= 0x80 || unicode.IsLetter(rune(ch)):
+			start := i
+			for i < len(query) && (query[i] == '_' || query[i] == '@' || query[i] == '$' || query[i] == '#' ||
+				query[i] >= 0x80 || unicode.IsLetter(rune(query[i])) || unicode.IsDigit(rune(query[i]))) {
+				i++
+			}
+			j := i
+			for j < len(query) && (query[j] == ' ' || query[j] == '\t' || query[j] == '\r' || query[j] == '\n') {
+				j++
+			}
+			words = append(words, sqlWord{
+				text:  strings.ToUpper(query[start:i]),
+				depth: depth,
+				call:  j < len(query) && query[j] == '(',
+			})
+
+		default:
+			i++
+		}
+	}
+	flush()
+	return statements
+}
+
+// splitBatch splits the words of a T-SQL text without semicolons into its
+// statements
+func splitBatch(words []sqlWord) [][]sqlWord {
+	switch words[0].text {
+	case "CREATE", "ALTER", "DROP":
+		return [][]sqlWord{words}
+	}
+
+	var statements [][]sqlWord
+	start := 0
+	update := false
+	for i, w := range words {
+		if w.depth > 0 {
+			continue
+		}
+		boundary := i > start && batchKeywords[w.text]
+		switch w.text {
+		case "UPDATE":
+			update = true
+		case "SET":
+			boundary = boundary && !update
+		case "DISABLE", "ENABLE":
+			boundary = boundary && i+1 < len(words) && words[i+1].text == "TRIGGER"
+		}
+		if boundary {
+			statements = append(statements, words[start:i])
+			start, update = i, false
+		}
+	}
+	return append(statements, words[start:])
+}
+
+// StatementPolicy decides which statements a connection may run. The zero
+// value allows reads and DML, one statement at a time.
+type StatementPolicy struct {
+	// ReadOnly allows only reads
+	ReadOnly bool
+	// AllowDDL allows CREATE, ALTER, DROP and TRUNCATE
+	AllowDDL bool
+	// AllowOther allows statements the classifier cannot judge, such as EXEC
+	// of a stored procedure
+	AllowOther bool
+	// AllowMultiStatements allows several statements in one call
+	AllowMultiStatements bool
+}
+
+// StatementDeniedError is returned for a statement the policy blocks
+type StatementDeniedError struct {
+	Kind    StatementKind
+	Keyword string
+	Reason  string
+}
+
+func (e *StatementDeniedError) Error() string {
+	return "statement not allowed: " + e.Reason
+}
+
+// Check returns a *StatementDeniedError if the policy blocks the SQL text.
+// serverReadOnly applies the server-wide read-only mode on top of the policy.
+func (p StatementPolicy) Check(driver, query string, serverReadOnly bool) error {
+	c := ClassifySQL(driver, query)
+	if len(c.Statements) == 0 {
+		return &StatementDeniedError{Kind: StatementOther, Reason: "no SQL statement found"}
+	}
+	if c.MultiStatement() && !p.AllowMultiStatements {
+		return &StatementDeniedError{
+			Kind:   c.Kind,
+			Reason: fmt.Sprintf("multiple statements are not allowed (found %d)", len(c.Statements)),
+		}
+	}
+
+	for _, stmt := range c.Statements {
+		denied := func(reason string) error {
+			return &StatementDeniedError{Kind: stmt.Kind, Keyword: stmt.Keyword, Reason: reason}
+		}
+
+		switch {
+		case stmt.Kind == StatementRead:
+			continue
+		case serverReadOnly:
+			return denied(fmt.Sprintf("server is in read-only mode; %s statements (%s) are blocked", stmt.Keyword, stmt.Kind))
+		case p.ReadOnly:
+			return denied(fmt.Sprintf("connection is read-only; %s statements (%s) are blocked", stmt.Keyword, stmt.Kind))
+		case stmt.Kind == StatementDDL && !p.AllowDDL:
+			return denied(fmt.Sprintf("schema changes are not allowed; %s statements (ddl) are blocked", stmt.Keyword))
+		case stmt.Kind == StatementOther && !p.AllowOther:
+			return denied(fmt.Sprintf("%s statements cannot be classified and are blocked", stmt.Keyword))
+		}
+	}
+	return nil
+}
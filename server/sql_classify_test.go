@@ -0,0 +1,116 @@
+package server
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestClassifySQL(t *testing.T) {
+	tests := []struct {
+		name       string
+		driver     string
+		sql        string
+		kind       StatementKind
+		statements int
+	}{
+		{"select", "sqlserver", "SELECT * FROM t", StatementRead, 1},
+		{"cte", "sqlserver", "WITH a AS (SELECT 1 AS x) SELECT x FROM a", StatementRead, 1},
+		{"union", "sqlserver", "SELECT 1 UNION ALL SELECT 2", StatementRead, 1},
+		{"offset fetch", "sqlserver", "SELECT x FROM t ORDER BY x OFFSET 10 ROWS FETCH NEXT 5 ROWS ONLY", StatementRead, 1},
+		{"query hint", "sqlserver", "SELECT x FROM t OPTION (USE HINT ('DISABLE_OPTIMIZER_ROWGOAL'))", StatementRead, 1},
+		{"keywords in literals", "sqlserver", "SELECT 'SHUTDOWN', [KILL], N'BEGIN TRAN' -- DELETE\nFROM t", StatementRead, 1},
+		{"enable alias", "sqlserver", "SELECT flag AS enable FROM t", StatementRead, 1},
+		{"semicolons", "sqlserver", "SELECT 1; SELECT 2;", StatementRead, 2},
+
+		{"delete without semicolon", "sqlserver", "SELECT 1 DELETE FROM t", StatementDML, 1},
+		{"select into", "sqlserver", "SELECT * INTO t2 FROM t", StatementDML, 1},
+		{"update set", "sqlserver", "UPDATE t WITH (ROWLOCK) SET x = 1 WHERE y = 2", StatementDML, 1},
+		{"merge update set", "sqlserver",
+			"MERGE t USING s ON t.id = s.id WHEN MATCHED THEN UPDATE SET t.x = s.x WHEN NOT MATCHED THEN INSERT (id) VALUES (s.id)",
+			StatementDML, 1},
+		{"drop if exists", "sqlserver", "DROP TABLE IF EXISTS t", StatementDDL, 1},
+		{"alter database rollback", "sqlserver", "ALTER DATABASE d SET SINGLE_USER WITH ROLLBACK IMMEDIATE", StatementDDL, 1},
+		{"procedure body", "sqlserver", "CREATE PROCEDURE p AS BEGIN SET NOCOUNT ON SELECT 1 END", StatementDDL, 1},
+		{"alter table enable trigger", "sqlserver", "ALTER TABLE t ENABLE TRIGGER ALL", StatementDDL, 1},
+
+		{"shutdown", "sqlserver", "SELECT 1 SHUTDOWN WITH NOWAIT", StatementOther, 2},
+		{"kill", "sqlserver", "SELECT 1 KILL 53", StatementOther, 2},
+		{"restore", "sqlserver", "SELECT 1 RESTORE DATABASE x FROM DISK = 'c:\\x.bak' WITH REPLACE", StatementOther, 2},
+		{"dbcc", "sqlserver", "SELECT 1 DBCC FREEPROCCACHE", StatementOther, 2},
+		{"backup", "sqlserver", "SELECT 1 BACKUP DATABASE x TO DISK = 'c:\\x.bak'", StatementOther, 2},
+		{"disable trigger", "sqlserver", "SELECT 1 DISABLE TRIGGER ALL ON t", StatementOther, 2},
+		{"writetext", "sqlserver", "SELECT 1 WRITETEXT t.c @ptr 'text'", StatementOther, 2},
+		{"begin tran", "sqlserver", "SELECT 1 BEGIN TRAN", StatementOther, 2},
+		{"set after select", "sqlserver", "SELECT 1 SET IMPLICIT_TRANSACTIONS ON", StatementOther, 2},
+		{"declare set select", "sqlserver", "DECLARE @x INT SET @x = 1 SELECT @x", StatementOther, 2},
+		{"use", "sqlserver", "SELECT 1 USE master", StatementOther, 2},
+		{"reconfigure", "sqlserver", "SELECT 1 RECONFIGURE", StatementOther, 2},
+		{"waitfor", "sqlserver", "SELECT 1 WAITFOR DELAY '00:10'", StatementOther, 2},
+		{"raiserror", "sqlserver", "SELECT 1 RAISERROR('x', 16, 1)", StatementOther, 2},
+		{"exec", "sqlserver", "SELECT 1 EXEC sp_configure", StatementOther, 1},
+
+		{"mysql select", "mysql", "SELECT `set`, 'KILL' FROM t # DELETE", StatementRead, 1},
+		{"mysql for update", "mysql", "SELECT * FROM t FOR UPDATE", StatementRead, 1},
+		{"mysql insert function", "mysql", "SELECT INSERT('abc', 1, 1, 'x')", StatementRead, 1},
+		{"mysql update set", "mysql", "UPDATE t SET x = 1", StatementDML, 1},
+		{"mysql versioned comment", "mysql", "SELECT 1 /*!50000 ; DROP TABLE t */", StatementDDL, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := ClassifySQL(tt.driver, tt.sql)
+			if c.Kind != tt.kind || len(c.Statements) != tt.statements {
+				t.Errorf("ClassifySQL(%q) = %s with %d statements %v, want %s with %d",
+					tt.sql, c.Kind, len(c.Statements), c.Statements, tt.kind, tt.statements)
+			}
+		})
+	}
+}
+
+func TestStatementPolicyReadOnly(t *testing.T) {
+	denied := []string{
+		"SELECT 1 SHUTDOWN WITH NOWAIT",
+		"SELECT 1 KILL 53",
+		"SELECT 1 RESTORE DATABASE x FROM DISK = 'c:\\x.bak' WITH REPLACE",
+		"SELECT 1 DBCC CHECKDB",
+		"SELECT 1 BACKUP LOG x TO DISK = 'c:\\x.trn'",
+		"SELECT 1 DISABLE TRIGGER ALL ON t",
+		"SELECT 1 ENABLE TRIGGER ALL ON t",
+		"SELECT 1 WRITETEXT t.c @ptr 'text'",
+		"SELECT 1 UPDATETEXT t.c @ptr 0 NULL 'text'",
+		"SELECT 1 BEGIN TRAN",
+		"SELECT 1 COMMIT",
+		"SELECT 1 ROLLBACK",
+		"SELECT 1 SET DEADLOCK_PRIORITY HIGH",
+		"SELECT 1 DECLARE @x INT",
+		"SELECT 1 USE master",
+		"SELECT 1 RECONFIGURE WITH OVERRIDE",
+		"SELECT 1 WAITFOR DELAY '01:00'",
+		"SELECT 1 DELETE FROM t",
+		"SELECT * INTO t2 FROM t",
+		"SELECT 1 EXEC xp_cmdshell 'dir'",
+		"WITH a AS (SELECT 1 AS x) DELETE FROM t",
+		"/* SELECT */ UPDATE t SET x = 1",
+	}
+	allowed := []string{
+		"SELECT * FROM t",
+		"SELECT x FROM t ORDER BY x OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY",
+		"SELECT 'SHUTDOWN' AS s, [KILL] FROM t",
+	}
+
+	// The policy allows everything, so every denial comes from read-only mode
+	policy := StatementPolicy{AllowDDL: true, AllowOther: true, AllowMultiStatements: true}
+	for _, sql := range denied {
+		var err *StatementDeniedError
+		if !errors.As(policy.Check("sqlserver", sql, true), &err) {
+			t.Errorf("read-only mode allows %q", sql)
+		}
+		if !errors.As((StatementPolicy{ReadOnly: true, AllowOther: true, AllowMultiStatements: true}).Check("sqlserver", sql, false), &err) {
+			t.Errorf("read-only connection allows %q", sql)
+		}
+	}
+	for _, sql := range allowed {
+		if err := policy.Check("sqlserver", sql, true); err != nil {
+			t.Errorf("read-only mode denies %q: %v", sql, err)
+		}
+	}
+}
@@ -0,0 +1,550 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	pb "github.com/yhonda-ohishi-pub-dev/desktop-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// webhookLogSize is how many deliveries the delivery log keeps
+	webhookLogSize = 200
+	// webhookTimeout bounds a single POST
+	webhookTimeout = 10 * time.Second
+	// defaultWebhookAttempts is the number of attempts per delivery
+	defaultWebhookAttempts = 5
+
+	// Headers sent with every delivery. The signature is the hex HMAC-SHA256
+	// of "<timestamp>.<body>" keyed with the webhook's secret.
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// Webhook event names
+const (
+	WebhookEventStarted    = "started"
+	WebhookEventComplete   = "complete"
+	WebhookEventError      = "error"
+	WebhookEventCancelled  = "cancelled"
+	WebhookEventDeadLetter = "dead_letter"
+	WebhookEventTest       = "test"
+)
+
+// webhookEvents maps lifecycle updates to event names
+var webhookEvents = map[pb.ProgressType]string{
+	pb.ProgressType_PROGRESS_TYPE_STARTED:     WebhookEventStarted,
+	pb.ProgressType_PROGRESS_TYPE_COMPLETE:    WebhookEventComplete,
+	pb.ProgressType_PROGRESS_TYPE_ERROR:       WebhookEventError,
+	pb.ProgressType_PROGRESS_TYPE_CANCELLED:   WebhookEventCancelled,
+	pb.ProgressType_PROGRESS_TYPE_DEAD_LETTER: WebhookEventDeadLetter,
+}
+
+// defaultWebhookRetry is the backoff between delivery attempts
+var defaultWebhookRetry = RetryPolicy{
+	InitialBackoff: 2 * time.Second,
+	MaxBackoff:     5 * time.Minute,
+	Jitter:         0.2,
+}
+
+// WebhookConfig is one entry of webhooks.json
+type WebhookConfig struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// Secret signs deliveries; empty sends them unsigned
+	Secret string `json:"secret,omitempty"`
+	// Events to deliver; empty means every lifecycle event
+	Events []string `json:"events,omitempty"`
+	// JobTypes to deliver; empty means every job type
+	JobTypes []string `json:"job_types,omitempty"`
+	// IncludeSubJobs also delivers events of sub-jobs such as retry attempts
+	IncludeSubJobs bool `json:"include_sub_jobs,omitempty"`
+	// Template is a text/template producing the JSON body from a
+	// WebhookEvent; empty sends the event itself. The json function quotes
+	// a value, e.g. {"text": {{json .Message}}}.
+	Template    string            `json:"template,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	MaxAttempts int               `json:"max_attempts,omitempty"`
+	Disabled    bool              `json:"disabled,omitempty"`
+}
+
+// webhookFile is the layout of webhooks.json
+type webhookFile struct {
+	Webhooks []WebhookConfig `json:"webhooks"`
+}
+
+// LoadWebhookConfig reads the webhook settings at path. A missing file
+// means no webhooks.
+func LoadWebhookConfig(path string) ([]WebhookConfig, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook config: %w", err)
+	}
+
+	var file webhookFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook config: %w", err)
+	}
+	return file.Webhooks, nil
+}
+
+// WebhookEvent is the data available to webhook templates and the default
+// request body
+type WebhookEvent struct {
+	Event       string            `json:"event"`
+	JobID       string            `json:"job_id"`
+	JobType     string            `json:"job_type,omitempty"`
+	ParentJobID string            `json:"parent_job_id,omitempty"`
+	Message     string            `json:"message,omitempty"`
+	Percentage  int32             `json:"percentage"`
+	Details     map[string]string `json:"details,omitempty"`
+	Sequence    int64             `json:"sequence"`
+	Timestamp   time.Time         `json:"timestamp"`
+}
+
+// deadLetter reports whether the event is the ERROR of a job that was moved
+// to the dead letters
+func (e *WebhookEvent) deadLetter() bool {
+	return e.Event == WebhookEventError && e.Details[DeadLetterDetail] == "true"
+}
+
+// webhook is a validated WebhookConfig
+type webhook struct {
+	config   WebhookConfig
+	tmpl     *template.Template
+	events   map[string]bool
+	jobTypes map[string]bool
+}
+
+var webhookFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+func newWebhook(c WebhookConfig) (*webhook, error) {
+	if c.Name == "" {
+		return nil, fmt.Errorf("webhook name is required")
+	}
+	if !strings.HasPrefix(c.URL, "http://") && !strings.HasPrefix(c.URL, "https://") {
+		return nil, fmt.Errorf("webhook %s: invalid url %q", c.Name, c.URL)
+	}
+
+	h := &webhook{
+		config:   c,
+		events:   make(map[string]bool),
+		jobTypes: make(map[string]bool),
+	}
+	for _, e := range c.Events {
+		h.events[e] = true
+	}
+	for _, t := range c.JobTypes {
+		h.jobTypes[t] = true
+	}
+	if c.Template != "" {
+		tmpl, err := template.New(c.Name).Funcs(webhookFuncs).Option("missingkey=error").Parse(c.Template)
+		if err != nil {
+			return nil, fmt.Errorf("webhook %s: invalid template: %w", c.Name, err)
+		}
+		h.tmpl = tmpl
+	}
+	return h, nil
+}
+
+// matches reports whether an event should be delivered to the webhook
+func (h *webhook) matches(e *WebhookEvent) bool {
+	if h.config.Disabled {
+		return false
+	}
+	if e.Event == WebhookEventTest {
+		return true
+	}
+	if len(h.events) > 0 && !h.events[e.Event] && !(h.events[WebhookEventDeadLetter] && e.deadLetter()) {
+		return false
+	}
+	if len(h.jobTypes) > 0 && !h.jobTypes[e.JobType] {
+		return false
+	}
+	return h.config.IncludeSubJobs || e.ParentJobID == ""
+}
+
+// render produces the request body for an event
+func (h *webhook) render(e *WebhookEvent) ([]byte, error) {
+	if h.tmpl == nil {
+		return json.Marshal(e)
+	}
+
+	var buf bytes.Buffer
+	if err := h.tmpl.Execute(&buf, e); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("template did not produce valid JSON")
+	}
+	return buf.Bytes(), nil
+}
+
+// sign returns the signature header value of a body
+func (h *webhook) sign(timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(h.config.Secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookOption configures a WebhookService
+type WebhookOption func(*WebhookService)
+
+// WithWebhookHTTPClient replaces the HTTP client used for deliveries
+func WithWebhookHTTPClient(c *http.Client) WebhookOption {
+	return func(w *WebhookService) {
+		w.client = c
+	}
+}
+
+// WithWebhookRetry replaces the backoff between delivery attempts
+func WithWebhookRetry(p RetryPolicy) WebhookOption {
+	return func(w *WebhookService) {
+		w.retry = p
+	}
+}
+
+// WebhookService POSTs job lifecycle events from ProgressService to the
+// configured webhooks and keeps a log of recent deliveries
+type WebhookService struct {
+	pb.UnimplementedWebhookServiceServer
+
+	progress *ProgressService
+	hooks    []*webhook
+	client   *http.Client
+	retry    RetryPolicy
+
+	mu         sync.Mutex
+	deliveries []*pb.WebhookDelivery
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewWebhookService validates the webhook settings
+func NewWebhookService(progress *ProgressService, configs []WebhookConfig, opts ...WebhookOption) (*WebhookService, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &WebhookService{
+		progress: progress,
+		client:   &http.Client{Timeout: webhookTimeout},
+		retry:    defaultWebhookRetry,
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	names := make(map[string]bool)
+	for _, c := range configs {
+		h, err := newWebhook(c)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		if names[c.Name] {
+			cancel()
+			return nil, fmt.Errorf("duplicate webhook name: %s", c.Name)
+		}
+		names[c.Name] = true
+		w.hooks = append(w.hooks, h)
+	}
+	return w, nil
+}
+
+// Start listens for progress events broadcast from now on until Stop is
+// called
+func (w *WebhookService) Start() {
+	go w.listen(w.progress.lastSequence())
+}
+
+// Stop stops listening and abandons pending retries
+func (w *WebhookService) Stop() {
+	w.cancel()
+	<-w.done
+	w.wg.Wait()
+}
+
+// listen follows every update after since. The subscriber can be dropped if
+// it falls behind, in which case it resumes after the last update it saw.
+func (w *WebhookService) listen(since int64) {
+	defer close(w.done)
+
+	for {
+		req := &pb.StreamProgressRequest{
+			Mode:          pb.SubscriptionMode_SUBSCRIPTION_MODE_PERSISTENT,
+			SinceSequence: since,
+		}
+		info := w.progress.subscribe(w.ctx, req, func(update *pb.ProgressUpdate) error {
+			since = update.Sequence
+			w.handle(update)
+			return nil
+		})
+
+		select {
+		case <-info.done:
+		case <-w.ctx.Done():
+		}
+		w.progress.unsubscribe(info)
+		<-info.stopped
+
+		if w.ctx.Err() != nil {
+			return
+		}
+		_, err := info.result()
+		log.Printf("Warning: Webhook listener resubscribing after %d: %v", since, err)
+	}
+}
+
+// handle starts a delivery to every webhook interested in an update
+func (w *WebhookService) handle(update *pb.ProgressUpdate) {
+	name, ok := webhookEvents[update.Type]
+	if !ok {
+		return
+	}
+
+	event := &WebhookEvent{
+		Event:       name,
+		JobID:       update.JobId,
+		JobType:     update.JobType,
+		ParentJobID: update.ParentJobId,
+		Message:     update.Message,
+		Percentage:  update.Percentage,
+		Details:     update.Details,
+		Sequence:    update.Sequence,
+		Timestamp:   time.Unix(update.Timestamp, 0),
+	}
+	for _, h := range w.hooks {
+		if h.matches(event) {
+			w.send(h, event)
+		}
+	}
+}
+
+// send records a delivery and starts delivering it in the background
+func (w *WebhookService) send(h *webhook, event *WebhookEvent) *pb.WebhookDelivery {
+	d := &pb.WebhookDelivery{
+		Id:        newID("delivery"),
+		Webhook:   h.config.Name,
+		Event:     event.Event,
+		JobId:     event.JobID,
+		JobType:   event.JobType,
+		Status:    pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING,
+		CreatedAt: time.Now().Unix(),
+	}
+
+	body, err := h.render(event)
+	if err != nil {
+		d.Status = pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED
+		d.Error = err.Error()
+		log.Printf("Webhook %s: %v", h.config.Name, err)
+		return w.record(d)
+	}
+	d.Payload = string(body)
+	snapshot := w.record(d)
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		w.deliver(h, d, body)
+	}()
+	return snapshot
+}
+
+// record adds a delivery to the log and returns a copy of it
+func (w *WebhookService) record(d *pb.WebhookDelivery) *pb.WebhookDelivery {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.deliveries = append(w.deliveries, d)
+	if len(w.deliveries) > webhookLogSize {
+		w.deliveries = w.deliveries[len(w.deliveries)-webhookLogSize:]
+	}
+	return proto.Clone(d).(*pb.WebhookDelivery)
+}
+
+// deliver POSTs a body until it is accepted, is rejected permanently, or the
+// attempts run out
+func (w *WebhookService) deliver(h *webhook, d *pb.WebhookDelivery, body []byte) {
+	maxAttempts := h.config.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultWebhookAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		code, err := w.post(h, d, body)
+
+		w.mu.Lock()
+		d.Attempts = int32(attempt)
+		d.LastAttemptAt = time.Now().Unix()
+		d.ResponseStatus = int32(code)
+		d.Error = ""
+		if err == nil {
+			d.Status = pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_SUCCEEDED
+		} else {
+			d.Error = err.Error()
+			if attempt >= maxAttempts || ClassifyError(err) == ErrorClassPermanent {
+				d.Status = pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED
+			}
+		}
+		finished := d.Status != pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING
+		w.mu.Unlock()
+
+		if err == nil {
+			return
+		}
+		if finished {
+			log.Printf("Webhook %s: delivery %s failed after %d attempts: %v", h.config.Name, d.Id, attempt, err)
+			return
+		}
+
+		timer := time.NewTimer(w.retry.backoff(attempt))
+		select {
+		case <-timer.C:
+		case <-w.ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// post makes one delivery attempt and returns the response status code
+func (w *WebhookService) post(h *webhook, d *pb.WebhookDelivery, body []byte) (int, error) {
+	ctx, cancel := context.WithTimeout(w.ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, Permanent(err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	for k, v := range h.config.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "desktop-server-webhook")
+	req.Header.Set(WebhookEventHeader, d.Event)
+	req.Header.Set(WebhookDeliveryHeader, d.Id)
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	if h.config.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, h.sign(timestamp, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, nil
+	}
+	err = fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	// Other client errors won't change on retry
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		err = Permanent(err)
+	}
+	return resp.StatusCode, err
+}
+
+// VerifyWebhookSignature checks the signature headers of a delivery; it is
+// meant for receivers written in Go
+func VerifyWebhookSignature(secret, timestamp, signature string, body []byte) bool {
+	h := &webhook{config: WebhookConfig{Secret: secret}}
+	return hmac.Equal([]byte(h.sign(timestamp, body)), []byte(signature))
+}
+
+// ListWebhooks returns the configured webhooks without their secrets
+func (w *WebhookService) ListWebhooks(ctx context.Context, req *pb.ListWebhooksRequest) (*pb.ListWebhooksResponse, error) {
+	resp := &pb.ListWebhooksResponse{}
+	for _, h := range w.hooks {
+		resp.Webhooks = append(resp.Webhooks, &pb.Webhook{
+			Name:           h.config.Name,
+			Url:            h.config.URL,
+			Events:         h.config.Events,
+			JobTypes:       h.config.JobTypes,
+			IncludeSubJobs: h.config.IncludeSubJobs,
+			Signed:         h.config.Secret != "",
+			Enabled:        !h.config.Disabled,
+		})
+	}
+	return resp, nil
+}
+
+// ListWebhookDeliveries returns recent deliveries, newest first
+func (w *WebhookService) ListWebhookDeliveries(ctx context.Context, req *pb.ListWebhookDeliveriesRequest) (*pb.ListWebhookDeliveriesResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	resp := &pb.ListWebhookDeliveriesResponse{}
+	for i := len(w.deliveries) - 1; i >= 0 && len(resp.Deliveries) < limit; i-- {
+		d := w.deliveries[i]
+		if req.Webhook != "" && d.Webhook != req.Webhook {
+			continue
+		}
+		if req.JobId != "" && d.JobId != req.JobId {
+			continue
+		}
+		if req.Status != pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED && d.Status != req.Status {
+			continue
+		}
+		resp.Deliveries = append(resp.Deliveries, proto.Clone(d).(*pb.WebhookDelivery))
+	}
+	return resp, nil
+}
+
+// TestWebhook sends a "test" event to a webhook, even if it is disabled
+func (w *WebhookService) TestWebhook(ctx context.Context, req *pb.TestWebhookRequest) (*pb.WebhookDelivery, error) {
+	for _, h := range w.hooks {
+		if h.config.Name != req.Name {
+			continue
+		}
+		return w.send(h, &WebhookEvent{
+			Event:     WebhookEventTest,
+			JobID:     "webhook-test",
+			Message:   "テスト通知です",
+			Timestamp: time.Now(),
+		}), nil
+	}
+	return nil, status.Errorf(codes.NotFound, "webhook not found: %s", req.Name)
+}
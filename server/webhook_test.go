@@ -0,0 +1,31 @@
+package server
+
+import "testing"
+
+func TestWebhookMatchesDeadLetter(t *testing.T) {
+	failed := &WebhookEvent{Event: WebhookEventError, JobType: "etc_download"}
+	deadLetter := &WebhookEvent{Event: WebhookEventError, JobType: "etc_download", Details: map[string]string{DeadLetterDetail: "true"}}
+	tests := []struct {
+		name   string
+		events []string
+		event  *WebhookEvent
+		want   bool
+	}{
+		{"error", []string{WebhookEventError}, failed, true},
+		{"error of a dead letter", []string{WebhookEventError}, deadLetter, true},
+		{"dead letter", []string{WebhookEventDeadLetter}, deadLetter, true},
+		{"dead letter skips other errors", []string{WebhookEventDeadLetter}, failed, false},
+		{"complete", []string{WebhookEventComplete}, deadLetter, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &webhook{events: map[string]bool{}, jobTypes: map[string]bool{"etc_download": true}}
+			for _, e := range tt.events {
+				h.events[e] = true
+			}
+			if got := h.matches(tt.event); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}